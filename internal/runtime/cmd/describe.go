@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 
@@ -398,7 +399,7 @@ func describeMonitors(r resources.MonitorSlice) (string, error) {
 			buf.WriteString(fmt.Sprintf("  Follow Redirects:\t%t\n", m.Options.FollowRedirects))
 			requestTimeout := "<default>"
 			if m.Options.RequestTimeout != nil {
-				requestTimeout = string(*m.Options.RequestTimeout)
+				requestTimeout = strconv.Itoa(*m.Options.RequestTimeout)
 			}
 			buf.WriteString(fmt.Sprintf("  Request Timeout:\t%s\n", requestTimeout))
 			buf.WriteString(fmt.Sprintf("  Request Delay:\t%d\n", m.Options.RequestDelay))
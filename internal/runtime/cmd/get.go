@@ -132,8 +132,11 @@ func init() {
 	apisCmd := generateGetSubcommand(resources.APIType, "apis", []string{"api"}, getIndividualAPIs)
 	apisCmd.Flags().StringVar(&usingWorkspace, "workspace", "", "the associated workspace ID")
 
+	collectionsCmd := generateGetSubcommand(resources.CollectionType, "collections", []string{"collection", "co"}, getIndividualCollections)
+	collectionsCmd.Flags().BoolVar(&sharedOnly, "shared", false, "list collections shared from other workspaces")
+
 	getCmd.AddCommand(
-		generateGetSubcommand(resources.CollectionType, "collections", []string{"collection", "co"}, getIndividualCollections),
+		collectionsCmd,
 		generateGetSubcommand(resources.EnvironmentType, "environments", []string{"environment", "env"}, getIndividualEnvironments),
 		generateGetSubcommand(resources.MonitorType, "monitors", []string{"monitor", "mon"}, getIndividualMonitors),
 		generateGetSubcommand(resources.MockType, "mocks", []string{"mock"}, getIndividualMocks),
@@ -171,7 +174,11 @@ func getAllResources(resourceType resources.ResourceType, args ...string) error
 
 	switch resourceType {
 	case resources.CollectionType:
-		resource, err = service.Collections(ctx)
+		if sharedOnly {
+			resource, err = service.SharedCollections(ctx)
+		} else {
+			resource, err = service.Collections(ctx)
+		}
 	case resources.EnvironmentType:
 		resource, err = service.Environments(ctx)
 	case resources.MockType:
@@ -49,6 +49,7 @@ var (
 	forkLabel        string
 	mergeStrategy    string
 	mergeCollection  string
+	sharedOnly       bool
 )
 
 var configContextFound = true
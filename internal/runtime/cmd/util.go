@@ -19,6 +19,7 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -33,7 +34,8 @@ import (
 )
 
 func handleResponseError(err error) error {
-	if err, ok := err.(*client.RequestError); ok {
+	var requestErr *client.RequestError
+	if errors.As(err, &requestErr) {
 		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
 		return nil
@@ -0,0 +1,107 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// responseCache holds successful GET responses for a limited time.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	path    string
+	expires time.Time
+	resp    *http.Response
+	body    []byte
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// get returns a copy of the unexpired response cached under key.
+func (c *responseCache) get(key string) (*http.Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return e.response(), true
+}
+
+// set caches resp under key for ttl, consuming its body, and returns a copy
+// of the response for the caller.
+func (c *responseCache) set(key, path string, resp *http.Response, ttl time.Duration) (*http.Response, error) {
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	e := &cacheEntry{
+		path:    path,
+		expires: time.Now().Add(ttl),
+		resp:    resp,
+		body:    body,
+	}
+
+	c.mu.Lock()
+	c.entries[key] = e
+	c.mu.Unlock()
+
+	return e.response(), nil
+}
+
+// invalidate drops cached responses for path and for any resource path
+// containing or contained by it.
+func (c *responseCache) invalidate(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, e := range c.entries {
+		if e.path == path || strings.HasPrefix(e.path, path+"/") || strings.HasPrefix(path, e.path+"/") {
+			delete(c.entries, k)
+		}
+	}
+}
+
+func (e *cacheEntry) response() *http.Response {
+	resp := *e.resp
+	resp.Header = e.resp.Header.Clone()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(e.body))
+
+	return &resp
+}
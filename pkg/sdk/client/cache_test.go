@@ -0,0 +1,154 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+)
+
+func newCacheOptions(t *testing.T, ttl time.Duration) (*client.Options, *int, func()) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			calls++
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"collections":[]}`)); err != nil {
+			t.Error(err)
+		}
+	}))
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+	options.CacheTTL = ttl
+
+	return options, &calls, server.Close
+}
+
+func getCollections(t *testing.T, options *client.Options, refresh bool) {
+	req := client.NewRequest(options).Get().Path("collections")
+	if refresh {
+		req.Refresh()
+	}
+
+	resp, err := req.Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(body) != `{"collections":[]}` {
+		t.Errorf("Body is incorrect, have: %s", body)
+	}
+}
+
+func TestCacheHitWithinTTL(t *testing.T) {
+	options, calls, teardown := newCacheOptions(t, time.Minute)
+	defer teardown()
+
+	getCollections(t, options, false)
+	getCollections(t, options, false)
+
+	if *calls != 1 {
+		t.Errorf("Expected cached response, server received %d requests", *calls)
+	}
+
+	getCollections(t, options, true)
+
+	if *calls != 2 {
+		t.Errorf("Expected refresh to bypass the cache, server received %d requests", *calls)
+	}
+}
+
+func TestCacheMissAfterExpiry(t *testing.T) {
+	options, calls, teardown := newCacheOptions(t, 20*time.Millisecond)
+	defer teardown()
+
+	getCollections(t, options, false)
+	time.Sleep(50 * time.Millisecond)
+	getCollections(t, options, false)
+
+	if *calls != 2 {
+		t.Errorf("Expected expired response to be refetched, server received %d requests", *calls)
+	}
+}
+
+func TestCacheInvalidatedByMutation(t *testing.T) {
+	options, calls, teardown := newCacheOptions(t, time.Minute)
+	defer teardown()
+
+	getCollections(t, options, false)
+
+	if _, err := client.NewRequest(options).Put().Path("collections", "abcdef").Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	getCollections(t, options, false)
+
+	if *calls != 2 {
+		t.Errorf("Expected mutation to invalidate the cache, server received %d requests", *calls)
+	}
+}
+
+func TestCacheDisabledByDefault(t *testing.T) {
+	options, calls, teardown := newCacheOptions(t, 0)
+	defer teardown()
+
+	getCollections(t, options, false)
+	getCollections(t, options, false)
+
+	if *calls != 2 {
+		t.Errorf("Expected no caching, server received %d requests", *calls)
+	}
+}
+
+func TestCacheSeparatesCredentials(t *testing.T) {
+	options, calls, teardown := newCacheOptions(t, time.Minute)
+	defer teardown()
+	options.APIKey = "PMAK-a"
+
+	requests := []func() *client.Request{
+		func() *client.Request { return client.NewRequest(options) },
+		func() *client.Request { return client.NewRequest(options).Bearer("token") },
+		func() *client.Request { return client.NewRequest(options).WithoutAPIKey() },
+		func() *client.Request { return client.NewRequest(options) },
+	}
+
+	for _, newRequest := range requests {
+		resp, err := newRequest().Get().Path("collections").Do()
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	if *calls != 3 {
+		t.Errorf("Expected one request per set of credentials, server received %d requests", *calls)
+	}
+}
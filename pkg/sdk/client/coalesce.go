@@ -0,0 +1,136 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// credentialHeaders authenticate a request, so responses to requests with
+// different values must never be shared.
+var credentialHeaders = []string{"X-API-Key", "Authorization"}
+
+// credentialKey returns a hash of the credential headers of h, for keying
+// shared responses by the credentials they were fetched with.
+func credentialKey(h http.Header) string {
+	sum := sha256.New()
+	for _, k := range credentialHeaders {
+		for _, v := range h.Values(k) {
+			sum.Write([]byte(k + ":" + v + "\n"))
+		}
+	}
+
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// coalescer shares the result of a single in-flight HTTP round trip with
+// every concurrent caller using the same key.
+type coalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+type coalescedCall struct {
+	done    chan struct{}
+	waiters int
+	resp    *http.Response
+	body    []byte
+	err     error
+}
+
+func newCoalescer() *coalescer {
+	return &coalescer{
+		calls: make(map[string]*coalescedCall),
+	}
+}
+
+// do executes fn once for all concurrent callers sharing key.  Each caller
+// receives its own copy of the response with a fresh body reader.  A caller
+// stops waiting when its own ctx is done, and runs fn itself when the shared
+// round trip was cut short by the context of the caller that started it.
+func (c *coalescer) do(ctx context.Context, key string, fn func() (*http.Response, error)) (*http.Response, error) {
+	c.mu.Lock()
+	if call, ok := c.calls[key]; ok {
+		call.waiters++
+		c.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		if isContextError(call.err) && ctx.Err() == nil {
+			return fn()
+		}
+
+		return call.response()
+	}
+
+	call := &coalescedCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	call.resp, call.err = fn()
+	if call.err == nil {
+		call.body, call.err = ioutil.ReadAll(call.resp.Body)
+		call.resp.Body.Close()
+	}
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	c.mu.Unlock()
+	close(call.done)
+
+	return call.response()
+}
+
+// waiting returns the number of callers waiting on in-flight round trips.
+func (c *coalescer) waiting() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for _, call := range c.calls {
+		n += call.waiters
+	}
+
+	return n
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func (c *coalescedCall) response() (*http.Response, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	resp := *c.resp
+	resp.Header = c.resp.Header.Clone()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(c.body))
+
+	return &resp, nil
+}
@@ -0,0 +1,262 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+)
+
+// waitForCoalescedWaiters blocks until n requests are waiting on in-flight
+// round trips made with options.
+func waitForCoalescedWaiters(t *testing.T, options *client.Options, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for client.CoalescedWaiters(options) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for coalesced requests, have: %d, want: %d", client.CoalescedWaiters(options), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCoalesceConcurrentGets(t *testing.T) {
+	const n = 10
+	var calls int32
+	started := make(chan struct{}, n)
+	release := make(chan struct{})
+
+	c := &http.Client{
+		Transport: roundTripFunc(func(*http.Request) *http.Response {
+			atomic.AddInt32(&calls, 1)
+			started <- struct{}{}
+			<-release
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"hello":"world"}`)),
+			}
+		}),
+	}
+
+	u, _ := url.Parse("https://api.example.com")
+	options := client.NewOptions(u, "", c)
+	options.CoalesceRequests = true
+
+	type subj struct {
+		Hello string `json:"hello"`
+	}
+
+	var wg sync.WaitGroup
+	results := make([]subj, n)
+	errs := make([]error, n)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = client.NewRequest(options).
+				Get().
+				Path("collections", "abcdef").
+				Into(&results[i]).
+				Do()
+		}(i)
+	}
+
+	<-started
+	waitForCoalescedWaiters(t, options, n-1)
+	close(release)
+	wg.Wait()
+
+	if have := atomic.LoadInt32(&calls); have != 1 {
+		t.Errorf("Incorrect number of round trips, have: %d, want: %d", have, 1)
+	}
+
+	for i := 0; i < n; i++ {
+		if errs[i] != nil {
+			t.Errorf("Unexpected error: %s", errs[i])
+		}
+		if results[i].Hello != "world" {
+			t.Errorf("Unexpected value, have: %s, want: %s", results[i].Hello, "world")
+		}
+	}
+}
+
+func TestCoalesceDisabledByDefault(t *testing.T) {
+	var calls int32
+
+	c := &http.Client{
+		Transport: roundTripFunc(func(*http.Request) *http.Response {
+			atomic.AddInt32(&calls, 1)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+			}
+		}),
+	}
+
+	u, _ := url.Parse("https://api.example.com")
+	options := client.NewOptions(u, "", c)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.NewRequest(options).Get().Do(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if have := atomic.LoadInt32(&calls); have != 2 {
+		t.Errorf("Incorrect number of round trips, have: %d, want: %d", have, 2)
+	}
+}
+
+func TestCoalesceSeparatesCredentials(t *testing.T) {
+	var calls int32
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+
+	c := &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) *http.Response {
+			atomic.AddInt32(&calls, 1)
+			started <- struct{}{}
+			<-release
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"hello":"` + req.Header.Get("X-API-Key") + req.Header.Get("Authorization") + `"}`)),
+			}
+		}),
+	}
+
+	u, _ := url.Parse("https://api.example.com")
+	options := client.NewOptions(u, "PMAK-a", c)
+	options.CoalesceRequests = true
+
+	type subj struct {
+		Hello string `json:"hello"`
+	}
+
+	var wg sync.WaitGroup
+	results := make([]subj, 3)
+	requests := []func() *client.Request{
+		func() *client.Request { return client.NewRequest(options) },
+		func() *client.Request { return client.NewRequest(options).Bearer("token") },
+		func() *client.Request { return client.NewRequest(options).WithoutAPIKey() },
+	}
+
+	for i, newRequest := range requests {
+		wg.Add(1)
+		go func(i int, r *client.Request) {
+			defer wg.Done()
+			if _, err := r.Get().Path("collections").Into(&results[i]).Do(); err != nil {
+				t.Error(err)
+			}
+		}(i, newRequest())
+	}
+
+	// Every request reaching the transport while the others are still in
+	// flight shows none of them was coalesced.
+	for range requests {
+		<-started
+	}
+	close(release)
+	wg.Wait()
+
+	if have := atomic.LoadInt32(&calls); have != 3 {
+		t.Errorf("Incorrect number of round trips, have: %d, want: %d", have, 3)
+	}
+
+	for i, want := range []string{"PMAK-a", "Bearer token", ""} {
+		if results[i].Hello != want {
+			t.Errorf("Response was shared across credentials, have: %q, want: %q", results[i].Hello, want)
+		}
+	}
+}
+
+type errRoundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f errRoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestCoalesceFollowerOutlivesCancelledLeader(t *testing.T) {
+	var calls int32
+	started := make(chan struct{})
+
+	c := &http.Client{
+		Transport: errRoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				close(started)
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"hello":"world"}`)),
+			}, nil
+		}),
+	}
+
+	u, _ := url.Parse("https://api.example.com")
+	options := client.NewOptions(u, "", c)
+	options.CoalesceRequests = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.NewRequestWithContext(ctx, options).Get().Path("collections").Do()
+		leaderErr <- err
+	}()
+	<-started
+
+	var result struct {
+		Hello string `json:"hello"`
+	}
+	followerErr := make(chan error, 1)
+	go func() {
+		_, err := client.NewRequest(options).Get().Path("collections").Into(&result).Do()
+		followerErr <- err
+	}()
+
+	waitForCoalescedWaiters(t, options, 1)
+	cancel()
+
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected leader to be cancelled, have: %v", err)
+	}
+
+	if err := <-followerErr; err != nil {
+		t.Fatalf("Follower received the leader's error: %v", err)
+	}
+
+	if result.Hello != "world" {
+		t.Errorf("Unexpected value, have: %s, want: %s", result.Hello, "world")
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+)
+
+// RoundTrip holds a request sent to the Postman API and the raw response
+// received, captured by a request in debug mode.  Sensitive request headers
+// are redacted.  The response fields are empty when no response was
+// received.
+type RoundTrip struct {
+	Method         string
+	URL            string
+	RequestHeader  http.Header
+	RequestBody    []byte
+	StatusCode     int
+	ResponseHeader http.Header
+	ResponseBody   []byte
+}
+
+// Debug captures the round trip of the request, available from RoundTrip
+// once it is sent.
+func (r *Request) Debug() *Request {
+	r.debug = true
+	return r
+}
+
+// RoundTrip returns the round trip captured by a request in debug mode, or
+// nil when debug mode is off or the request was not sent.
+func (r *Request) RoundTrip() *RoundTrip {
+	return r.roundTrip
+}
+
+// captureRequest records the request side of the round trip.
+func (r *Request) captureRequest(req *http.Request, body []byte) {
+	if !r.debug {
+		return
+	}
+
+	r.roundTrip = &RoundTrip{
+		Method:        req.Method,
+		URL:           req.URL.String(),
+		RequestHeader: redactHeaders(req.Header),
+		RequestBody:   body,
+	}
+}
+
+// captureResponse records the response side of the round trip, replacing
+// the response body so it can still be read.
+func (r *Request) captureResponse(resp *http.Response) error {
+	if r.roundTrip == nil || resp == nil {
+		return nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	r.roundTrip.StatusCode = resp.StatusCode
+	r.roundTrip.ResponseHeader = resp.Header.Clone()
+	r.roundTrip.ResponseBody = body
+
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+)
+
+func TestDebugCapturesRoundTrip(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/collections", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(body) != `{"name":"test"}` {
+			t.Errorf("Request body is incorrect, have: %s", string(body))
+		}
+
+		if r.Header.Get("X-API-Key") != "secret" {
+			t.Error("Expected the real API key to be sent.")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"collection":{"uid":"1234"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "secret", http.DefaultClient)
+	req := client.NewRequest(options)
+
+	var out map[string]interface{}
+	_, err := req.
+		Post().
+		Path("collections").
+		Body(bytes.NewReader([]byte(`{"name":"test"}`))).
+		Into(&out).
+		Debug().
+		Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := out["collection"]; !ok {
+		t.Errorf("Expected the response to be decoded, have: %v", out)
+	}
+
+	rt := req.RoundTrip()
+	if rt == nil {
+		t.Fatal("Expected a captured round trip.")
+	}
+
+	if rt.Method != http.MethodPost || rt.URL != server.URL+"/collections" || string(rt.RequestBody) != `{"name":"test"}` {
+		t.Errorf("Request side is incorrect, have: %s %s %s", rt.Method, rt.URL, rt.RequestBody)
+	}
+
+	if have := rt.RequestHeader.Get("X-API-Key"); have != "REDACTED" {
+		t.Errorf("X-API-Key was not redacted, have: %s", have)
+	}
+
+	if rt.StatusCode != http.StatusOK || rt.ResponseHeader.Get("Content-Type") != "application/json" || string(rt.ResponseBody) != `{"collection":{"uid":"1234"}}` {
+		t.Errorf("Response side is incorrect, have: %d %v %s", rt.StatusCode, rt.ResponseHeader, rt.ResponseBody)
+	}
+}
+
+func TestRoundTripNilWithoutDebug(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	u, _ := url.Parse(server.URL)
+	req := client.NewRequest(client.NewOptions(u, "", http.DefaultClient))
+
+	if _, err := req.Get().Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	if req.RoundTrip() != nil {
+		t.Error("Expected no round trip without debug mode.")
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+)
+
+func TestParseErrorResponse(t *testing.T) {
+	e := client.ParseErrorResponse(http.StatusNotFound,
+		[]byte(`{"error":{"name":"instanceNotFoundError","message":"We could not find the collection you are looking for"}}`))
+
+	if e.StatusCode != http.StatusNotFound {
+		t.Errorf("Status code is incorrect, have: %d, want: %d", e.StatusCode, http.StatusNotFound)
+	}
+
+	if e.Name != "instanceNotFoundError" {
+		t.Errorf("Name is incorrect, have: %s, want: %s", e.Name, "instanceNotFoundError")
+	}
+
+	if e.Message != "We could not find the collection you are looking for" {
+		t.Errorf("Message is incorrect, have: %s", e.Message)
+	}
+}
+
+func TestParseErrorResponseFallsBackToStatusText(t *testing.T) {
+	for _, body := range []string{"", "  ", `{}`, `{"error":{}}`, `null`} {
+		e := client.ParseErrorResponse(http.StatusBadGateway, []byte(body))
+		if e.Message != http.StatusText(http.StatusBadGateway) {
+			t.Errorf("Message is incorrect for body %q, have: %s", body, e.Message)
+		}
+	}
+}
+
+func TestParseErrorResponseUndecodableBody(t *testing.T) {
+	e := client.ParseErrorResponse(http.StatusBadGateway, []byte("<html>Bad Gateway</html>"))
+
+	if e.Message == "" || e.Message == http.StatusText(http.StatusBadGateway) {
+		t.Errorf("Expected decode error in message, have: %s", e.Message)
+	}
+}
+
+func FuzzParseErrorResponse(f *testing.F) {
+	f.Add(http.StatusBadRequest, []byte(`{"error":{"name":"malformedRequestError","message":"Found 2 errors with the supplied collection.","details":["info: must have required property 'name'","item: must be array"]}}`))
+	f.Add(http.StatusBadRequest, []byte(`{"error":{"name":"paramMissingError","message":"Parameter is missing in the request.","details":{"param":["collection"]}}}`))
+	f.Add(http.StatusUnauthorized, []byte(`{"error":{"name":"AuthenticationError","message":"API Key missing. Every request requires an API Key to be sent."}}`))
+	f.Add(http.StatusForbidden, []byte(`{"error":{"name":"forbiddenError","message":"You are not permitted to perform the action."}}`))
+	f.Add(http.StatusTooManyRequests, []byte(`{"error":"rateLimited","message":"Rate limit exceeded. Please retry after 1669048687"}`))
+	f.Add(http.StatusInternalServerError, []byte(`{"error":{"name":"serverError","message":"Something went wrong"`))
+	f.Add(http.StatusBadGateway, []byte("<html><body>502 Bad Gateway</body></html>"))
+	f.Add(0, []byte{})
+
+	f.Fuzz(func(t *testing.T, status int, body []byte) {
+		e := client.ParseErrorResponse(status, body)
+		if e == nil {
+			t.Fatal("Expected a non-nil error.")
+		}
+
+		if e.StatusCode != status {
+			t.Errorf("Status code is incorrect, have: %d, want: %d", e.StatusCode, status)
+		}
+
+		_ = e.Error()
+	})
+}
@@ -0,0 +1,29 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+// ParseErrorResponse exposes parseErrorResponse to the client_test package.
+var ParseErrorResponse = parseErrorResponse
+
+// RetryDelay exposes Options.retryDelay to the client_test package.
+var RetryDelay = (*Options).retryDelay
+
+// CoalescedWaiters returns the number of requests waiting on an in-flight
+// round trip started by another request with the same options.
+func CoalescedWaiters(o *Options) int {
+	return o.coalescer.waiting()
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// redacted replaces sensitive header values in log output.
+const redacted = "REDACTED"
+
+// sensitiveHeaders are never written to logs.
+var sensitiveHeaders = []string{"X-API-Key", "Authorization"}
+
+// redactHeaders returns a copy of h with sensitive values replaced.
+func redactHeaders(h http.Header) http.Header {
+	ret := h.Clone()
+	for _, k := range sensitiveHeaders {
+		if _, ok := ret[http.CanonicalHeaderKey(k)]; ok {
+			ret.Set(k, redacted)
+		}
+	}
+
+	return ret
+}
+
+// logRoundTrip logs a completed request when a logger is configured: at
+// debug level, or at warn level with the requested Retry-After delay for
+// rate limited (429) and server error (5xx) responses.
+func (r *Request) logRoundTrip(req *http.Request, resp *http.Response, err error, start time.Time) {
+	logger := r.options.Logger
+	if logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("resource", r.path),
+		slog.Duration("duration", time.Since(start)),
+		slog.Any("headers", redactHeaders(req.Header)),
+	}
+
+	level := slog.LevelDebug
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+			level = slog.LevelWarn
+			attrs = append(attrs, slog.Duration("retry_after", parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())))
+		}
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	logger.LogAttrs(r.ctx, level, "postman api request", attrs...)
+}
+
+// logRetry logs at warn level that a failed request is retried after delay,
+// when a logger is configured.
+func (r *Request) logRetry(err error, attempt int, delay time.Duration) {
+	logger := r.options.Logger
+	if logger == nil {
+		return
+	}
+
+	logger.LogAttrs(r.ctx, slog.LevelWarn, "retrying postman api request",
+		slog.String("method", r.method),
+		slog.String("resource", r.path),
+		slog.Int("attempt", attempt),
+		slog.Duration("delay", delay),
+		slog.String("error", err.Error()),
+	)
+}
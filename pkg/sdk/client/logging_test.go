@@ -0,0 +1,176 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+)
+
+type recordingHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *recordingHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	return nil
+}
+
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h *recordingHandler) WithGroup(string) slog.Handler { return h }
+
+func recordAttrs(r slog.Record) map[string]slog.Value {
+	attrs := make(map[string]slog.Value)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value
+		return true
+	})
+
+	return attrs
+}
+
+func TestLoggerRecordsRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	h := &recordingHandler{}
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "PMAK-secret", http.DefaultClient)
+	options.Logger = slog.New(h)
+
+	if _, err := client.NewRequest(options).Get().Path("collections").Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(h.records) != 1 {
+		t.Fatalf("Incorrect number of log records, have: %d, want: %d", len(h.records), 1)
+	}
+
+	r := h.records[0]
+	if r.Level != slog.LevelDebug {
+		t.Errorf("Log level is incorrect, have: %s, want: %s", r.Level, slog.LevelDebug)
+	}
+
+	attrs := recordAttrs(r)
+	if v := attrs["method"].String(); v != http.MethodGet {
+		t.Errorf("Method is incorrect, have: %s, want: %s", v, http.MethodGet)
+	}
+
+	if v := attrs["resource"].String(); v != "collections" {
+		t.Errorf("Resource is incorrect, have: %s, want: %s", v, "collections")
+	}
+
+	if v := attrs["status"].Int64(); v != http.StatusOK {
+		t.Errorf("Status is incorrect, have: %d, want: %d", v, http.StatusOK)
+	}
+
+	if _, ok := attrs["duration"]; !ok {
+		t.Error("Expected duration to be logged.")
+	}
+
+	headers, ok := attrs["headers"].Any().(http.Header)
+	if !ok {
+		t.Fatalf("Expected headers to be logged, have: %v", attrs["headers"])
+	}
+
+	if key := headers.Get("X-API-Key"); key != "REDACTED" {
+		t.Errorf("Expected API key to be redacted, have: %s", key)
+	}
+}
+
+func TestLoggerDoesNotRedactRequestHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get("X-API-Key"); key != "PMAK-secret" {
+			t.Errorf("API key is incorrect, have: %s, want: %s", key, "PMAK-secret")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "PMAK-secret", http.DefaultClient)
+	options.Logger = slog.New(&recordingHandler{})
+
+	if _, err := client.NewRequest(options).Get().Do(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoggerWarnsOnRateLimitAndServerError(t *testing.T) {
+	status := http.StatusTooManyRequests
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "30")
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	h := &recordingHandler{}
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "PMAK-secret", http.DefaultClient)
+	options.Logger = slog.New(h)
+
+	for _, s := range []int{http.StatusTooManyRequests, http.StatusBadGateway} {
+		status = s
+		if _, err := client.NewRequest(options).Get().Path("collections").Do(); err == nil {
+			t.Fatal("Expected error.")
+		}
+	}
+
+	if len(h.records) != 2 {
+		t.Fatalf("Incorrect number of log records, have: %d, want: %d", len(h.records), 2)
+	}
+
+	for i, want := range []struct {
+		status     int64
+		retryAfter time.Duration
+	}{
+		{http.StatusTooManyRequests, 30 * time.Second},
+		{http.StatusBadGateway, 0},
+	} {
+		r := h.records[i]
+		if r.Level != slog.LevelWarn {
+			t.Errorf("Log level is incorrect, have: %s, want: %s", r.Level, slog.LevelWarn)
+		}
+
+		attrs := recordAttrs(r)
+		if v := attrs["status"].Int64(); v != want.status {
+			t.Errorf("Status is incorrect, have: %d, want: %d", v, want.status)
+		}
+
+		if v, ok := attrs["retry_after"]; !ok || v.Duration() != want.retryAfter {
+			t.Errorf("Retry-After is incorrect, have: %v, want: %s", v, want.retryAfter)
+		}
+	}
+}
@@ -17,9 +17,15 @@ limitations under the License.
 package client
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Options allows for storing a base URL and containing common functionality.
@@ -27,6 +33,77 @@ type Options struct {
 	base   *url.URL
 	APIKey string
 	Client *http.Client
+
+	// CoalesceRequests makes concurrent identical GET requests share a
+	// single round trip to the Postman API.  Requests are only identical
+	// when they are also sent with the same credentials.
+	CoalesceRequests bool
+
+	// MaxRedirects limits how many redirects are followed.  Zero keeps the
+	// net/http default of 10, a negative value disables following redirects.
+	MaxRedirects int
+
+	// CheckRedirect, if set, is consulted before following each redirect
+	// with the same semantics as http.Client.CheckRedirect.
+	CheckRedirect func(req *http.Request, via []*http.Request) error
+
+	// Timeout bounds each request, including redirects and reading the
+	// response body.  Zero uses the timeout of Client.
+	Timeout time.Duration
+
+	// Logger, if set, receives a debug record for every request with the
+	// API key redacted.  Rate limited and server error responses are
+	// recorded at warn level instead.
+	Logger *slog.Logger
+
+	// CacheTTL, if positive, caches successful GET responses in memory for
+	// the given duration, separately for each set of credentials.  Mutating
+	// requests invalidate cached responses for the same resource path.
+	CacheTTL time.Duration
+
+	// ReadOnly makes requests other than GET and HEAD fail with ErrReadOnly
+	// before they are sent, so the client can't modify any resource.
+	ReadOnly bool
+
+	// Marshaler and Unmarshaler, if set, replace encoding/json for request
+	// bodies set with JSONBody and for decoding responses, including error
+	// responses.
+	Marshaler   func(v interface{}) ([]byte, error)
+	Unmarshaler func(data []byte, v interface{}) error
+
+	// MaxIdleConns, MaxIdleConnsPerHost, and IdleConnTimeout, if set, tune
+	// the connection pool of the client's transport, as the fields of the
+	// same names on http.Transport.  They are applied to a copy of the
+	// transport the first time a request is sent, leaving Client unchanged.
+	// Transports other than *http.Transport are used as they are.
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+
+	// MaxRetries, if positive, retries a failed request up to that many
+	// times when RetryIf reports its error as retryable, replaying the
+	// request body on every attempt.  Each retry waits for the RetryAfter
+	// delay of a rate limited response, or otherwise for RetryBackoff,
+	// doubled after every attempt.  Non-idempotent requests are retried too,
+	// so enable retries only where a repeated create is acceptable.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry of a request failed
+	// without a Retry-After delay.  Zero uses 500 milliseconds.
+	RetryBackoff time.Duration
+	// RetryIf reports whether a request failed with err is retried.  Nil
+	// uses IsRetryable, retrying server errors and rate limited requests.
+	RetryIf func(err error) bool
+
+	coalescer *coalescer
+	cache     *responseCache
+	transport *tunedTransport
+}
+
+// tunedTransport holds the transport built for the connection pool
+// settings of the options, so every request shares its pool.
+type tunedTransport struct {
+	once      sync.Once
+	transport http.RoundTripper
 }
 
 // NewOptions creates a new instance of the Postman API client options.
@@ -39,8 +116,149 @@ func NewOptions(baseURL *url.URL, apiKey string, client *http.Client) *Options {
 	base.Fragment = ""
 
 	return &Options{
-		base:   &base,
-		APIKey: apiKey,
-		Client: client,
+		base:      &base,
+		APIKey:    apiKey,
+		Client:    client,
+		coalescer: newCoalescer(),
+		cache:     newResponseCache(),
+		transport: &tunedTransport{},
+	}
+}
+
+// defaultRetryBackoff is the delay before the first retry when RetryBackoff
+// isn't set.
+const defaultRetryBackoff = 500 * time.Millisecond
+
+// maxRetryBackoff caps the doubling retry delay.
+const maxRetryBackoff = 30 * time.Second
+
+func (o *Options) retryable(err error) bool {
+	if o.RetryIf != nil {
+		return o.RetryIf(err)
+	}
+
+	return IsRetryable(err)
+}
+
+// retryDelay returns how long to wait before retrying a request that failed
+// with err on the given zero-based attempt.
+func (o *Options) retryDelay(err error, attempt int) time.Duration {
+	var e *RequestError
+	if errors.As(err, &e) && e.RetryAfter > 0 {
+		return e.RetryAfter
+	}
+
+	delay := o.RetryBackoff
+	if delay <= 0 {
+		delay = defaultRetryBackoff
+	}
+
+	for i := 0; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+
+	return delay
+}
+
+func (o *Options) marshal(v interface{}) ([]byte, error) {
+	if o.Marshaler != nil {
+		return o.Marshaler(v)
 	}
+
+	return json.Marshal(v)
+}
+
+func (o *Options) unmarshal(data []byte, v interface{}) error {
+	if o.Unmarshaler != nil {
+		return o.Unmarshaler(data, v)
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// HTTPClient returns the HTTP client used for requests, configured with
+// the redirect policy, timeout, and connection pool settings of the options.
+func (o *Options) HTTPClient() *http.Client {
+	c := o.Client
+	if c == nil {
+		c = http.DefaultClient
+	}
+
+	client := *c
+	if o.Timeout > 0 {
+		client.Timeout = o.Timeout
+	}
+
+	if o.transport != nil && (o.MaxIdleConns > 0 || o.MaxIdleConnsPerHost > 0 || o.IdleConnTimeout > 0) {
+		o.transport.once.Do(func() {
+			o.transport.transport = o.tuneTransport(c.Transport)
+		})
+		client.Transport = o.transport.transport
+	}
+
+	next := c.CheckRedirect
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if o.MaxRedirects < 0 {
+			return http.ErrUseLastResponse
+		}
+
+		max := o.MaxRedirects
+		if max == 0 {
+			max = 10
+		}
+
+		if len(via) >= max {
+			return fmt.Errorf("stopped after %d redirects", max)
+		}
+
+		// Only send the API key along while the redirect stays on the
+		// original host.
+		if key := via[0].Header.Get("X-API-Key"); key != "" && req.URL.Host == via[0].URL.Host {
+			req.Header.Set("X-API-Key", key)
+		} else {
+			req.Header.Del("X-API-Key")
+		}
+
+		if o.CheckRedirect != nil {
+			return o.CheckRedirect(req, via)
+		}
+
+		if next != nil {
+			return next(req, via)
+		}
+
+		return nil
+	}
+
+	return &client
+}
+
+// tuneTransport returns a copy of base, or of http.DefaultTransport when
+// base is nil, with the connection pool settings of the options applied.
+func (o *Options) tuneTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	t, ok := base.(*http.Transport)
+	if !ok {
+		return base
+	}
+
+	t = t.Clone()
+	if o.MaxIdleConns > 0 {
+		t.MaxIdleConns = o.MaxIdleConns
+	}
+	if o.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+	}
+	if o.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = o.IdleConnTimeout
+	}
+
+	return t
 }
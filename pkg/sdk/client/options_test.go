@@ -0,0 +1,214 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+)
+
+func TestRedirectKeepsAPIKeyOnSameHost(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusFound)
+	})
+
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get("X-API-Key"); key != "PMAK-123" {
+			t.Errorf("API key is incorrect, have: %s, want: %s", key, "PMAK-123")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "PMAK-123", http.DefaultClient)
+
+	if _, err := client.NewRequest(options).Get().Path("old").Do(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRedirectDropsAPIKeyOnOtherHost(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get("X-API-Key"); key != "" {
+			t.Errorf("Expected API key not to be sent to another host, have: %s", key)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other.URL+"/new", http.StatusFound)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "PMAK-123", http.DefaultClient)
+
+	if _, err := client.NewRequest(options).Get().Path("old").Do(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRedirectsDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/old" {
+			t.Errorf("Expected redirect not to be followed, have: %s", r.URL.Path)
+		}
+		http.Redirect(w, r, "/new", http.StatusFound)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "PMAK-123", http.DefaultClient)
+	options.MaxRedirects = -1
+
+	_, err := client.NewRequest(options).Get().Path("old").Do()
+
+	var e *client.RequestError
+	if !errors.As(err, &e) {
+		t.Fatalf("Incorrect error, expected RequestError, got: %v", err)
+	}
+
+	if e.StatusCode != http.StatusFound {
+		t.Errorf("Status code is incorrect, have: %d, want: %d", e.StatusCode, http.StatusFound)
+	}
+}
+
+func TestMaxRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, r.URL.Path+"x", http.StatusFound)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+	options.MaxRedirects = 2
+
+	if _, err := client.NewRequest(options).Get().Path("a").Do(); err == nil {
+		t.Error("Expected error.")
+	}
+}
+
+func TestCheckRedirect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusFound)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+
+	errRedirect := errors.New("redirect rejected")
+	options.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return errRedirect
+	}
+
+	_, err := client.NewRequest(options).Get().Path("old").Do()
+	if !errors.Is(err, errRedirect) {
+		t.Errorf("Incorrect error, have: %v, want: %v", err, errRedirect)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+	options.Timeout = 5 * time.Millisecond
+
+	if _, err := client.NewRequest(options).Get().Do(); err == nil {
+		t.Error("Expected error.")
+	}
+}
+
+func TestReadOnlyBlocksMutations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Request was sent in read-only mode: %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "PMAK-123", http.DefaultClient)
+	options.ReadOnly = true
+
+	if _, err := client.NewRequest(options).Post().Path("collections").Do(); !errors.Is(err, client.ErrReadOnly) {
+		t.Errorf("Error is incorrect, have: %v, want: %v", err, client.ErrReadOnly)
+	}
+
+	if _, err := client.NewRequest(options).Delete().Path("collections", "abcdef").Do(); !errors.Is(err, client.ErrReadOnly) {
+		t.Errorf("Error is incorrect, have: %v, want: %v", err, client.ErrReadOnly)
+	}
+
+	res, err := client.NewRequest(options).Get().Path("collections").Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("Status code is incorrect, have: %d, want: %d", res.StatusCode, http.StatusOK)
+	}
+}
+
+func TestConnectionPoolSettings(t *testing.T) {
+	u, _ := url.Parse("https://api.getpostman.com")
+	options := client.NewOptions(u, "", nil)
+	options.MaxIdleConns = 50
+	options.MaxIdleConnsPerHost = 20
+	options.IdleConnTimeout = 2 * time.Minute
+
+	c := options.HTTPClient()
+	transport, ok := c.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected an *http.Transport, have: %T", c.Transport)
+	}
+
+	if transport.MaxIdleConns != 50 || transport.MaxIdleConnsPerHost != 20 || transport.IdleConnTimeout != 2*time.Minute {
+		t.Errorf("Transport settings are incorrect, have: %d, %d, %s", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+
+	if transport == http.DefaultTransport {
+		t.Error("Expected the default transport to be left unchanged")
+	}
+
+	if options.HTTPClient().Transport != c.Transport {
+		t.Error("Expected requests to share a single transport")
+	}
+}
+
+func TestConnectionPoolDefaults(t *testing.T) {
+	u, _ := url.Parse("https://api.getpostman.com")
+	if c := client.NewOptions(u, "", nil).HTTPClient(); c.Transport != nil {
+		t.Errorf("Expected the default transport, have: %T", c.Transport)
+	}
+}
@@ -17,25 +17,39 @@ limitations under the License.
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
 )
 
+// ErrReadOnly is returned for requests that would modify a resource when
+// the client is read-only.
+var ErrReadOnly = errors.New("the client is read-only")
+
 // RequestError represents an error from the Postman API.
 type RequestError struct {
-	StatusCode int
-	Name       string
-	Message    string
-	Details    map[string]interface{}
+	StatusCode  int
+	Name        string
+	Message     string
+	Details     map[string]interface{}
+	FieldErrors []resources.FieldError
+
+	// RetryAfter is the delay requested by the Postman API before retrying
+	// a rate limited (429) request.  It is zero when not provided.
+	RetryAfter time.Duration
 }
 
 // NewRequestError creates a new RequestError for Postman API responses.
@@ -49,8 +63,149 @@ func NewRequestError(code int, name string, message string, details map[string]i
 }
 
 func (e *RequestError) Error() string {
-	return fmt.Sprintf("status code: %d, name: %s, message: %s, details %s", e.StatusCode,
+	msg := fmt.Sprintf("status code: %d, name: %s, message: %s, details %s", e.StatusCode,
 		e.Name, e.Message, e.Details)
+
+	if len(e.FieldErrors) > 0 {
+		fields := make([]string, len(e.FieldErrors))
+		for i, f := range e.FieldErrors {
+			fields[i] = f.String()
+		}
+		msg += ", fields: " + strings.Join(fields, "; ")
+	}
+
+	return msg
+}
+
+// IsValidationError reports whether err is a RequestError caused by the
+// Postman API rejecting the request as invalid (400).
+func IsValidationError(err error) bool {
+	var e *RequestError
+	return errors.As(err, &e) && e.StatusCode == http.StatusBadRequest
+}
+
+// IsPreconditionFailed reports whether err is a RequestError caused by the
+// Postman API rejecting a conditional request because the resource changed
+// (412).
+func IsPreconditionFailed(err error) bool {
+	var e *RequestError
+	return errors.As(err, &e) && e.StatusCode == http.StatusPreconditionFailed
+}
+
+// PermissionError is returned when the Postman API refuses an operation
+// because the API key lacks the required access (403).
+type PermissionError struct {
+	*RequestError
+}
+
+// Unwrap returns the underlying RequestError.
+func (e *PermissionError) Unwrap() error {
+	return e.RequestError
+}
+
+// IsPermissionError reports whether err was caused by the Postman API
+// refusing access (403).
+func IsPermissionError(err error) bool {
+	var e *RequestError
+	return errors.As(err, &e) && e.StatusCode == http.StatusForbidden
+}
+
+// ServerError is returned when the Postman API fails to handle a request
+// because of a problem on its side (5xx).  These errors are usually
+// transient.
+type ServerError struct {
+	*RequestError
+}
+
+func (e *ServerError) Error() string {
+	return e.RequestError.Error() + " (the Postman API is having problems, try again later)"
+}
+
+// Unwrap returns the underlying RequestError.
+func (e *ServerError) Unwrap() error {
+	return e.RequestError
+}
+
+// IsServerError reports whether err was caused by the Postman API failing
+// to handle a request (5xx).
+func IsServerError(err error) bool {
+	var e *ServerError
+	return errors.As(err, &e)
+}
+
+// IsRetryable reports whether the request that caused err may succeed when
+// retried: server errors and rate limited (429) requests.  It is the default
+// RetryIf of Options.
+func IsRetryable(err error) bool {
+	var e *RequestError
+	return IsServerError(err) || (errors.As(err, &e) && e.StatusCode == http.StatusTooManyRequests)
+}
+
+// parseRetryAfter parses a Retry-After header given in either delta-seconds
+// or HTTP-date format, returning zero when it is missing or invalid.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+
+	t, err := http.ParseTime(v)
+	if err != nil || !t.After(now) {
+		return 0
+	}
+
+	return t.Sub(now)
+}
+
+// maxErrorBodySnippet limits how much of an undecodable error body is kept
+// in the error message.
+const maxErrorBodySnippet = 256
+
+// parseErrorResponse builds a RequestError from a failed Postman API
+// response.  It never fails: bodies that can't be decoded are reported in
+// the message, and a missing message falls back to the status text.
+func parseErrorResponse(status int, body []byte) *RequestError {
+	return decodeErrorResponse(status, body, json.Unmarshal)
+}
+
+// decodeErrorResponse is parseErrorResponse with the body decoded by
+// unmarshal.
+func decodeErrorResponse(status int, body []byte, unmarshal func([]byte, interface{}) error) *RequestError {
+	e := &RequestError{StatusCode: status}
+
+	if len(bytes.TrimSpace(body)) > 0 {
+		var r resources.ErrorResponse
+		if err := unmarshal(body, &r); err != nil {
+			e.Message = err.Error() + " | " + errorBodySnippet(body)
+		} else {
+			e.Name = r.Error.Name
+			e.Message = r.Error.Message
+			e.Details = r.Error.Details
+			e.FieldErrors = r.Error.FieldErrors
+		}
+	}
+
+	if e.Message == "" {
+		e.Message = http.StatusText(status)
+	}
+
+	return e
+}
+
+func errorBodySnippet(body []byte) string {
+	s := strings.TrimSpace(string(body))
+	if len(s) > maxErrorBodySnippet {
+		s = s[:maxErrorBodySnippet] + "..."
+	}
+
+	return strings.ToValidUTF8(s, "\ufffd")
 }
 
 // Request holds state for a Postman API request.
@@ -60,9 +215,15 @@ type Request struct {
 	method        string
 	path          string
 	requestReader io.Reader
+	requestBody   []byte
+	requestValue  interface{}
 	result        interface{}
+	resultMap     map[string]interface{}
 	headers       http.Header
 	params        url.Values
+	refresh       bool
+	debug         bool
+	roundTrip     *RoundTrip
 	err           error
 }
 
@@ -93,6 +254,21 @@ func (r *Request) AddHeader(key string, value string) *Request {
 	return r
 }
 
+// WithoutAPIKey removes the X-API-Key header added to every request, for
+// endpoints that don't require authentication or use another scheme.
+func (r *Request) WithoutAPIKey() *Request {
+	r.headers.Del("X-API-Key")
+	return r
+}
+
+// Bearer authenticates the request with a bearer token instead of the API
+// key.
+func (r *Request) Bearer(token string) *Request {
+	r.headers.Del("X-API-Key")
+	r.headers.Set("Authorization", "Bearer "+token)
+	return r
+}
+
 // Param sets a query parameter.
 func (r *Request) Param(k, v string) *Request {
 	if r.params == nil {
@@ -141,12 +317,25 @@ func (r *Request) Put() *Request {
 	return r
 }
 
+// Patch sets the HTTP method to PATCH
+func (r *Request) Patch() *Request {
+	r.method = "PATCH"
+	return r
+}
+
 // Delete sets the HTTP method to DELETE
 func (r *Request) Delete() *Request {
 	r.method = "DELETE"
 	return r
 }
 
+// Refresh bypasses the response cache, replacing any cached response with
+// the one received.
+func (r *Request) Refresh() *Request {
+	r.refresh = true
+	return r
+}
+
 // Path sets the path of the HTTP request.
 func (r *Request) Path(p ...string) *Request {
 	r.path = path.Join(p...)
@@ -156,15 +345,35 @@ func (r *Request) Path(p ...string) *Request {
 // Body sets an input resource for the request
 func (r *Request) Body(reader io.Reader) *Request {
 	r.requestReader = reader
+	r.requestBody = nil
+	r.requestValue = nil
 	return r
 }
 
-// Into sets a destination resource for the output response
+// JSONBody sets an input resource for the request, encoded with the
+// Marshaler of the options when the request is executed.
+func (r *Request) JSONBody(v interface{}) *Request {
+	r.requestReader = nil
+	r.requestBody = nil
+	r.requestValue = v
+	return r
+}
+
+// Into sets a destination resource for the output response.  Responses
+// that aren't JSON are copied as they are into a *[]byte or io.Writer
+// destination instead of being decoded.
 func (r *Request) Into(o interface{}) *Request {
 	r.result = o
 	return r
 }
 
+// AsMap sets a generic map as the destination for the output response, so
+// fields the typed models don't know about yet are retained.
+func (r *Request) AsMap() *Request {
+	r.resultMap = make(map[string]interface{})
+	return r.Into(&r.resultMap)
+}
+
 // URL returns a complete URL for the current request.
 func (r *Request) URL() *url.URL {
 	finalURL := &url.URL{}
@@ -177,50 +386,156 @@ func (r *Request) URL() *url.URL {
 	return finalURL
 }
 
-// Do executes the HTTP request.
+// cached returns the cached response for a GET request unless the cache is
+// disabled or bypassed.
+func (r *Request) cached(cache *responseCache, key string) (*http.Response, bool) {
+	if cache == nil || r.refresh || r.method != http.MethodGet {
+		return nil, false
+	}
+
+	return cache.get(key)
+}
+
+// Do executes the HTTP request.  Failed requests are retried as configured
+// by the MaxRetries option.
 func (r *Request) Do() (*http.Response, error) {
 	url := r.URL().String()
 
-	req, err := http.NewRequestWithContext(r.ctx, r.method, url, r.requestReader)
+	if r.options.ReadOnly && r.method != "" && r.method != http.MethodGet && r.method != http.MethodHead {
+		return nil, fmt.Errorf("%w: refusing %s %s", ErrReadOnly, r.method, r.URL().Path)
+	}
+
+	body, err := r.bufferBody()
 	if err != nil {
 		return nil, err
 	}
-	req.Header = r.headers
-	client := r.options.Client
-	if client == nil {
-		client = http.DefaultClient
+
+	client := r.options.HTTPClient()
+
+	cache := r.options.cache
+	if r.options.CacheTTL <= 0 {
+		cache = nil
+	}
+
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		resp, err = r.send(client, cache, url, body)
+		if err == nil || attempt >= r.options.MaxRetries || !r.options.retryable(err) {
+			break
+		}
+
+		delay := r.options.retryDelay(err, attempt)
+		r.logRetry(err, attempt+1, delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-r.ctx.Done():
+			timer.Stop()
+			return nil, r.ctx.Err()
+		}
 	}
 
-	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return resp, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	if r.result != nil {
 		defer resp.Body.Close()
+
+		if !isJSONContentType(resp.Header.Get("Content-Type")) {
+			switch out := r.result.(type) {
+			case *[]byte:
+				body, err := ioutil.ReadAll(resp.Body)
+				if err != nil {
+					return resp, err
+				}
+				*out = body
+				return resp, nil
+			case io.Writer:
+				if _, err := io.Copy(out, resp.Body); err != nil {
+					return resp, err
+				}
+				return resp, nil
+			}
+		}
+
 		body, err := ioutil.ReadAll(resp.Body)
 
 		if err != nil {
 			return resp, err
 		}
 
-		var e resources.ErrorResponse
 		if len(body) > 0 {
-			err = json.Unmarshal(body, &e)
+			if err := r.options.unmarshal(body, &r.result); err != nil {
+				return nil, err
+			}
 		}
+	}
+
+	return resp, nil
+}
+
+// send makes a single attempt at the HTTP request, from the response cache
+// when possible.  Responses other than 2xx are returned as errors.
+func (r *Request) send(client *http.Client, cache *responseCache, url string, body []byte) (*http.Response, error) {
+	r.err = nil
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
 
-		var errorMessage *RequestError
-		if err == nil {
-			errorMessage = NewRequestError(resp.StatusCode, e.Error.Name, e.Error.Message, e.Error.Details)
+	req, err := http.NewRequestWithContext(r.ctx, r.method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+	req.Header = r.headers
+	r.captureRequest(req, body)
+
+	// Responses are only shared between requests sent with the same
+	// credentials.
+	key := url + " " + credentialKey(req.Header)
+
+	var resp *http.Response
+	if cached, ok := r.cached(cache, key); ok {
+		resp = cached
+	} else {
+		start := time.Now()
+		if r.method == http.MethodGet && r.options.CoalesceRequests && r.options.coalescer != nil {
+			resp, err = r.options.coalescer.do(r.ctx, r.method+" "+key, func() (*http.Response, error) {
+				return client.Do(req)
+			})
 		} else {
-			msg := []string{err.Error(), e.Error.Message}
-			errorMessage = NewRequestError(resp.StatusCode, e.Error.Name, strings.Join(msg, " | "), e.Error.Details)
+			resp, err = client.Do(req)
+		}
+		r.logRoundTrip(req, resp, err, start)
+
+		if cache != nil && r.method != http.MethodGet {
+			cache.invalidate(r.path)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if cache != nil && r.method == http.MethodGet && resp.StatusCode == http.StatusOK {
+			if resp, err = cache.set(key, r.path, resp, r.options.CacheTTL); err != nil {
+				return nil, err
+			}
 		}
-		r.err = errorMessage
-		return nil, errorMessage
 	}
 
-	if r.result != nil {
+	if err := r.captureResponse(resp); err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
 		defer resp.Body.Close()
 		body, err := ioutil.ReadAll(resp.Body)
 
@@ -228,10 +543,91 @@ func (r *Request) Do() (*http.Response, error) {
 			return resp, err
 		}
 
-		if err := json.Unmarshal(body, &r.result); err != nil {
-			return nil, err
+		errorMessage := decodeErrorResponse(resp.StatusCode, body, r.options.unmarshal)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			errorMessage.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		}
+		if resp.StatusCode >= http.StatusInternalServerError {
+			r.err = &ServerError{RequestError: errorMessage}
+		} else {
+			r.err = errorMessage
 		}
+		return nil, r.err
 	}
 
 	return resp, nil
 }
+
+// isJSONContentType reports whether a response Content-Type is JSON.  A
+// missing Content-Type is taken to be JSON, as the Postman API returns JSON
+// unless it says otherwise.
+func isJSONContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// bufferBody reads the request body into memory on first use, so it can be
+// replayed when the request is retried or redirected.
+func (r *Request) bufferBody() ([]byte, error) {
+	if r.requestBody == nil && r.requestValue != nil {
+		body, err := r.options.marshal(r.requestValue)
+		if err != nil {
+			return nil, err
+		}
+
+		r.requestBody = body
+		r.requestValue = nil
+	}
+
+	if r.requestBody != nil || r.requestReader == nil {
+		return r.requestBody, nil
+	}
+
+	body, err := ioutil.ReadAll(r.requestReader)
+	if err != nil {
+		return nil, err
+	}
+
+	if body == nil {
+		body = []byte{}
+	}
+	r.requestBody = body
+	r.requestReader = nil
+
+	return body, nil
+}
+
+// DoMap executes the HTTP request and returns the response decoded into a
+// generic map.
+func (r *Request) DoMap() (map[string]interface{}, error) {
+	if _, err := r.AsMap().Do(); err != nil {
+		return nil, err
+	}
+
+	return r.resultMap, nil
+}
+
+// DoInto sets the destination resource for the output response, executes
+// the HTTP request, and returns the response status code.  The status code
+// of a failed request is returned along with the error when one was
+// received.
+func (r *Request) DoInto(out interface{}) (int, error) {
+	resp, err := r.Into(out).Do()
+	if err != nil {
+		var e *RequestError
+		if errors.As(err, &e) {
+			return e.StatusCode, err
+		}
+		return 0, err
+	}
+
+	return resp.StatusCode, nil
+}
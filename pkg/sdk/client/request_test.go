@@ -18,7 +18,9 @@ package client_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -207,6 +209,52 @@ func TestAddHeader(t *testing.T) {
 	}
 }
 
+func TestWithoutAPIKey(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Header["X-Api-Key"]; ok {
+			t.Error("Expected X-API-Key header to be absent.")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "secret", http.DefaultClient)
+	req := client.NewRequest(options)
+
+	if _, err := req.Get().WithoutAPIKey().Do(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBearer(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Header["X-Api-Key"]; ok {
+			t.Error("Expected X-API-Key header to be absent.")
+		}
+
+		if have := r.Header.Get("Authorization"); have != "Bearer abc123" {
+			t.Errorf("Authorization header is incorrect, have: %s, want: %s", have, "Bearer abc123")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "secret", http.DefaultClient)
+	req := client.NewRequest(options)
+
+	if _, err := req.Get().Bearer("abc123").Do(); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestParam(t *testing.T) {
 	mux := http.NewServeMux()
 	server := httptest.NewServer(mux)
@@ -351,13 +399,13 @@ func TestHTTPError(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error.")
 	} else {
-		switch err.(type) {
-		case *client.RequestError:
+		var e *client.RequestError
+		if errors.As(err, &e) {
 			s := err.Error()
 			if !strings.Contains(s, "status code: 500") {
 				t.Error("Expected error to contain status code: 500")
 			}
-		default:
+		} else {
 			t.Errorf("Incorrect error, expected RequestError, got: %s", err)
 		}
 	}
@@ -596,3 +644,704 @@ func TestContext(t *testing.T) {
 		t.Errorf("Unexpected error, have: %s, want: context deadline exceeded", err)
 	}
 }
+
+func TestValidationErrorWithFieldDetails(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	subject := `{"error":{"name":"malformedRequestError","message":"Found 2 errors.","details":{"name":"is required","url":["is invalid","is too long"]}}}`
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+	req := client.NewRequest(options)
+
+	_, err := req.
+		Post().
+		Do()
+
+	if !client.IsValidationError(err) {
+		t.Fatalf("Expected validation error, got: %v", err)
+	}
+
+	var e *client.RequestError
+	if !errors.As(err, &e) {
+		t.Fatalf("Incorrect error, expected RequestError, got: %s", err)
+	}
+
+	if len(e.FieldErrors) != 2 {
+		t.Fatalf("Incorrect number of field errors, have: %d, want: %d", len(e.FieldErrors), 2)
+	}
+
+	if e.FieldErrors[0].Field != "name" || e.FieldErrors[0].Message != "is required" {
+		t.Errorf("Incorrect field error, have: %+v", e.FieldErrors[0])
+	}
+
+	if e.FieldErrors[1].Field != "url" || e.FieldErrors[1].Message != "is invalid, is too long" {
+		t.Errorf("Incorrect field error, have: %+v", e.FieldErrors[1])
+	}
+
+	if e.Details["name"] != "is required" {
+		t.Errorf("Expected details to be retained, have: %v", e.Details)
+	}
+
+	if !strings.Contains(err.Error(), "fields: name: is required; url: is invalid, is too long") {
+		t.Errorf("Expected error message to contain field errors, have: %s", err)
+	}
+}
+
+func TestValidationErrorWithDetailList(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	subject := `{"error":{"name":"malformedRequestError","message":"Found 1 errors.","details":["collection: property \"info\" is missing"]}}`
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+	req := client.NewRequest(options)
+
+	_, err := req.
+		Post().
+		Do()
+
+	var e *client.RequestError
+	if !errors.As(err, &e) {
+		t.Fatalf("Incorrect error, expected RequestError, got: %s", err)
+	}
+
+	if e.Name != "malformedRequestError" {
+		t.Errorf("Incorrect error name, have: %s, want: %s", e.Name, "malformedRequestError")
+	}
+
+	if len(e.FieldErrors) != 1 || e.FieldErrors[0].Message != `collection: property "info" is missing` {
+		t.Errorf("Incorrect field errors, have: %+v", e.FieldErrors)
+	}
+}
+
+func TestIsValidationErrorFalseForOtherStatusCodes(t *testing.T) {
+	err := client.NewRequestError(http.StatusNotFound, "instanceNotFoundError", "not found", nil)
+
+	if client.IsValidationError(err) {
+		t.Error("Expected 404 not to be a validation error.")
+	}
+
+	if client.IsValidationError(errors.New("boom")) {
+		t.Error("Expected plain error not to be a validation error.")
+	}
+}
+
+func TestPatch(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPatch)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+	req := client.NewRequest(options)
+
+	_, err := req.
+		Patch().
+		Do()
+
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func retryAfterError(t *testing.T, retryAfter string) *client.RequestError {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", retryAfter)
+		w.WriteHeader(http.StatusTooManyRequests)
+		if _, err := w.Write([]byte(`{"error":{"name":"rateLimited","message":"Rate limit exceeded"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+
+	_, err := client.NewRequest(options).Get().Do()
+
+	var e *client.RequestError
+	if !errors.As(err, &e) {
+		t.Fatalf("Incorrect error, expected RequestError, got: %v", err)
+	}
+
+	return e
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	e := retryAfterError(t, "120")
+
+	if e.RetryAfter != 2*time.Minute {
+		t.Errorf("RetryAfter is incorrect, have: %s, want: %s", e.RetryAfter, 2*time.Minute)
+	}
+}
+
+func TestRetryAfterHTTPDate(t *testing.T) {
+	e := retryAfterError(t, time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+
+	if e.RetryAfter <= 58*time.Minute || e.RetryAfter > time.Hour {
+		t.Errorf("RetryAfter is incorrect, have: %s, want: about %s", e.RetryAfter, time.Hour)
+	}
+}
+
+func TestRetryAfterInvalid(t *testing.T) {
+	e := retryAfterError(t, "soon")
+
+	if e.RetryAfter != 0 {
+		t.Errorf("RetryAfter is incorrect, have: %s, want: 0", e.RetryAfter)
+	}
+}
+
+func serverError(t *testing.T, status int) error {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		if _, err := w.Write([]byte(`{"error":{"name":"serverError","message":"Something went wrong"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+
+	_, err := client.NewRequest(options).Get().Do()
+
+	return err
+}
+
+func TestServerErrorStatuses(t *testing.T) {
+	for _, status := range []int{http.StatusInternalServerError, http.StatusServiceUnavailable} {
+		err := serverError(t, status)
+
+		var e *client.ServerError
+		if !errors.As(err, &e) {
+			t.Fatalf("Incorrect error for %d, expected ServerError, got: %v", status, err)
+		}
+
+		if e.StatusCode != status || e.Name != "serverError" {
+			t.Errorf("Server error is incorrect, have: %+v", e.RequestError)
+		}
+
+		var re *client.RequestError
+		if !errors.As(err, &re) {
+			t.Error("Expected ServerError to unwrap to a RequestError.")
+		}
+
+		if !client.IsServerError(err) || !client.IsRetryable(err) {
+			t.Errorf("Expected %d to be a retryable server error.", status)
+		}
+	}
+}
+
+func TestClientErrorIsNotServerError(t *testing.T) {
+	err := serverError(t, http.StatusNotFound)
+
+	if client.IsServerError(err) || client.IsRetryable(err) {
+		t.Errorf("Expected 404 not to be a retryable server error, got: %v", err)
+	}
+
+	if !client.IsRetryable(serverError(t, http.StatusTooManyRequests)) {
+		t.Error("Expected 429 to be retryable.")
+	}
+}
+
+// retryServer responds to each request with the next of statuses, then with
+// 200 once they run out, recording the request bodies it received.
+func retryServer(t *testing.T, statuses ...int) (*client.Options, *[]string, func()) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		bodies = append(bodies, string(body))
+
+		if len(bodies) <= len(statuses) {
+			w.WriteHeader(statuses[len(bodies)-1])
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+	options.RetryBackoff = time.Millisecond
+
+	return options, &bodies, server.Close
+}
+
+func TestRetryReplaysBody(t *testing.T) {
+	options, bodies, teardown := retryServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	defer teardown()
+	options.MaxRetries = 2
+
+	want := `{"collection":{"info":{"name":"retried"}}}`
+	reader := ioutil.NopCloser(strings.NewReader(want))
+
+	if _, err := client.NewRequest(options).Post().Body(reader).Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*bodies) != 3 {
+		t.Fatalf("Attempts are incorrect, have: %d, want: %d", len(*bodies), 3)
+	}
+
+	for i, body := range *bodies {
+		if body != want {
+			t.Errorf("Body is incorrect on attempt %d, have: %s, want: %s", i+1, body, want)
+		}
+	}
+}
+
+func TestRetryDisabledByDefault(t *testing.T) {
+	options, bodies, teardown := retryServer(t, http.StatusServiceUnavailable)
+	defer teardown()
+
+	if _, err := client.NewRequest(options).Get().Do(); !client.IsServerError(err) {
+		t.Errorf("Expected server error, have: %v", err)
+	}
+
+	if len(*bodies) != 1 {
+		t.Errorf("Attempts are incorrect, have: %d, want: %d", len(*bodies), 1)
+	}
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	options, bodies, teardown := retryServer(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+	defer teardown()
+	options.MaxRetries = 2
+
+	if _, err := client.NewRequest(options).Get().Do(); !client.IsServerError(err) {
+		t.Errorf("Expected server error, have: %v", err)
+	}
+
+	if len(*bodies) != 3 {
+		t.Errorf("Attempts are incorrect, have: %d, want: %d", len(*bodies), 3)
+	}
+}
+
+func TestRetrySkipsClientErrors(t *testing.T) {
+	options, bodies, teardown := retryServer(t, http.StatusBadRequest)
+	defer teardown()
+	options.MaxRetries = 2
+
+	if _, err := client.NewRequest(options).Get().Do(); !client.IsValidationError(err) {
+		t.Errorf("Expected validation error, have: %v", err)
+	}
+
+	if len(*bodies) != 1 {
+		t.Errorf("Attempts are incorrect, have: %d, want: %d", len(*bodies), 1)
+	}
+
+	options.RetryIf = func(err error) bool { return client.IsValidationError(err) }
+	if _, err := client.NewRequest(options).Get().Do(); err != nil {
+		t.Errorf("Expected RetryIf to retry the validation error, have: %v", err)
+	}
+}
+
+func TestRetryStopsWhenContextIsDone(t *testing.T) {
+	options, bodies, teardown := retryServer(t, http.StatusServiceUnavailable)
+	defer teardown()
+	options.MaxRetries = 1
+	options.RetryBackoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.NewRequestWithContext(ctx, options).Get().Do(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the context error, have: %v", err)
+	}
+
+	if len(*bodies) != 1 {
+		t.Errorf("Attempts are incorrect, have: %d, want: %d", len(*bodies), 1)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	u, _ := url.Parse("https://api.example.com")
+	options := client.NewOptions(u, "", http.DefaultClient)
+	options.RetryBackoff = time.Second
+
+	rateLimited := &client.RequestError{StatusCode: http.StatusTooManyRequests, RetryAfter: 45 * time.Second}
+	serverErr := &client.ServerError{RequestError: &client.RequestError{StatusCode: http.StatusBadGateway}}
+
+	for _, tc := range []struct {
+		err     error
+		attempt int
+		want    time.Duration
+	}{
+		{rateLimited, 0, 45 * time.Second},
+		{serverErr, 0, time.Second},
+		{serverErr, 2, 4 * time.Second},
+		{serverErr, 10, 30 * time.Second},
+	} {
+		if have := client.RetryDelay(options, tc.err, tc.attempt); have != tc.want {
+			t.Errorf("Delay for %v on attempt %d is incorrect, have: %s, want: %s", tc.err, tc.attempt, have, tc.want)
+		}
+	}
+}
+
+func TestDoIntoReturnsStatus(t *testing.T) {
+	for _, status := range []int{http.StatusOK, http.StatusCreated} {
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			if _, err := w.Write([]byte(`{"collection":{"uid":"abcdef"}}`)); err != nil {
+				t.Error(err)
+			}
+		})
+
+		u, _ := url.Parse(server.URL)
+		options := client.NewOptions(u, "", http.DefaultClient)
+
+		var out struct {
+			Collection struct {
+				UID string `json:"uid"`
+			} `json:"collection"`
+		}
+
+		have, err := client.NewRequest(options).Post().DoInto(&out)
+		server.Close()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have != status {
+			t.Errorf("Status is incorrect, have: %d, want: %d", have, status)
+		}
+
+		if out.Collection.UID != "abcdef" {
+			t.Errorf("Output is incorrect, have: %s, want: %s", out.Collection.UID, "abcdef")
+		}
+	}
+}
+
+func TestDoIntoReturnsErrorStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+
+	var out interface{}
+	status, err := client.NewRequest(options).Get().DoInto(&out)
+	if err == nil {
+		t.Error("Expected error.")
+	}
+
+	if status != http.StatusNotFound {
+		t.Errorf("Status is incorrect, have: %d, want: %d", status, http.StatusNotFound)
+	}
+}
+
+func TestDoMapRetainsUnknownFields(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write([]byte(`{"collection":{"uid":"abcdef","forkCount":3,"labels":["beta"]}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+
+	out, err := client.NewRequest(options).Get().DoMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection, ok := out["collection"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected a collection object, have: %#v", out)
+	}
+
+	if collection["forkCount"] != float64(3) {
+		t.Errorf("Unknown field is incorrect, have: %v, want: %v", collection["forkCount"], 3)
+	}
+
+	if labels, _ := collection["labels"].([]interface{}); len(labels) != 1 || labels[0] != "beta" {
+		t.Errorf("Unknown field is incorrect, have: %v, want: %v", collection["labels"], []string{"beta"})
+	}
+}
+
+func TestDoMapError(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+
+	out, err := client.NewRequest(options).Get().DoMap()
+	if err == nil {
+		t.Error("Expected an error for a failed request")
+	}
+
+	if out != nil {
+		t.Errorf("Expected no map for a failed request, have: %v", out)
+	}
+}
+
+func TestBodyReplayedOnRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	want := `{"collection":{"info":{"name":"redirected"}}}`
+
+	mux.HandleFunc("/collections", func(w http.ResponseWriter, r *http.Request) {
+		// A temporary redirect makes the HTTP client send the request again.
+		http.Redirect(w, r, "/collections/redirected", http.StatusTemporaryRedirect)
+	})
+
+	mux.HandleFunc("/collections/redirected", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPost)
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(body) != want {
+			t.Errorf("Body is incorrect after redirect, have: %s, want: %s", body, want)
+		}
+	})
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+
+	// A reader the HTTP client can't rewind on its own.
+	reader := ioutil.NopCloser(strings.NewReader(want))
+
+	if _, err := client.NewRequest(options).Post().Path("collections").Body(reader).Do(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBodyReplayedOnRepeatedDo(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	want := `{"environment":{"name":"again"}}`
+	attempts := 0
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(body) != want {
+			t.Errorf("Body is incorrect on attempt %d, have: %s, want: %s", attempts, body, want)
+		}
+
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+
+	req := client.NewRequest(options).Post().Body(ioutil.NopCloser(strings.NewReader(want)))
+
+	if _, err := req.Do(); !client.IsRetryable(err) {
+		t.Fatalf("Expected a retryable error, have: %v", err)
+	}
+
+	if _, err := req.Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("Attempts are incorrect, have: %d, want: %d", attempts, 2)
+	}
+}
+
+func TestCustomJSONCodec(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/collections", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := string(body), `{"name":"custom"}`; have != want {
+			t.Errorf("Body is incorrect, have: %s, want: %s", have, want)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"collection":{"uid":"1-abc"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		if _, err := w.Write([]byte(`{"error":{"name":"instanceNotFoundError","message":"Not found."}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	var marshalled, unmarshalled []string
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+	options.Marshaler = func(v interface{}) ([]byte, error) {
+		marshalled = append(marshalled, fmt.Sprintf("%T", v))
+		return json.Marshal(v)
+	}
+	options.Unmarshaler = func(data []byte, v interface{}) error {
+		unmarshalled = append(unmarshalled, string(data))
+		return json.Unmarshal(data, v)
+	}
+
+	var out struct {
+		Collection struct {
+			UID string `json:"uid"`
+		} `json:"collection"`
+	}
+
+	in := map[string]string{"name": "custom"}
+	if _, err := client.NewRequest(options).Post().Path("collections").JSONBody(in).Into(&out).Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Collection.UID != "1-abc" {
+		t.Errorf("Response is incorrect, have: %+v", out)
+	}
+
+	_, err := client.NewRequest(options).Get().Path("missing").Into(&out).Do()
+
+	var reqErr *client.RequestError
+	if !errors.As(err, &reqErr) || reqErr.Name != "instanceNotFoundError" {
+		t.Errorf("Error is incorrect, have: %v", err)
+	}
+
+	if len(marshalled) != 1 || marshalled[0] != "map[string]string" {
+		t.Errorf("Marshaler invocations are incorrect, have: %v", marshalled)
+	}
+
+	if len(unmarshalled) != 2 || !strings.Contains(unmarshalled[1], "instanceNotFoundError") {
+		t.Errorf("Unmarshaler invocations are incorrect, have: %v", unmarshalled)
+	}
+}
+
+func TestCustomMarshalerError(t *testing.T) {
+	u, _ := url.Parse("http://localhost")
+	options := client.NewOptions(u, "", http.DefaultClient)
+	options.Marshaler = func(v interface{}) ([]byte, error) {
+		return nil, errors.New("marshal failed")
+	}
+
+	if _, err := client.NewRequest(options).Post().JSONBody(1).Do(); err == nil || err.Error() != "marshal failed" {
+		t.Errorf("Error is incorrect, have: %v", err)
+	}
+}
+
+func TestNonJSONResponseIntoBytes(t *testing.T) {
+	subject := "openapi: 3.0.0\ninfo:\n  title: Users\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+
+	var out []byte
+	if _, err := client.NewRequest(options).Get().Into(&out).Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(out) != subject {
+		t.Errorf("Output is incorrect, have: %q, want: %q", out, subject)
+	}
+
+	var buf strings.Builder
+	if _, err := client.NewRequest(options).Get().Into(&buf).Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != subject {
+		t.Errorf("Output is incorrect, have: %q, want: %q", buf.String(), subject)
+	}
+}
+
+func TestJSONResponseIsDecodedWithContentType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(`"text"`)); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	options := client.NewOptions(u, "", http.DefaultClient)
+
+	var out string
+	if _, err := client.NewRequest(options).Get().Into(&out).Do(); err != nil {
+		t.Fatal(err)
+	}
+
+	if out != "text" {
+		t.Errorf("Output is incorrect, have: %q, want: %q", out, "text")
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/schema"
+)
+
+// maxSchemaSize limits how much of a fetched schema is read, as schema URLs
+// come from the collections themselves.
+const maxSchemaSize = 4 << 20
+
+// SchemaCache fetches and caches the JSON schemas referenced by collections.
+type SchemaCache struct {
+	Client *http.Client
+
+	mu      sync.Mutex
+	schemas map[string][]byte
+}
+
+// NewSchemaCache creates a new SchemaCache fetching schemas with client, or
+// http.DefaultClient when client is nil.
+func NewSchemaCache(client *http.Client) *SchemaCache {
+	return &SchemaCache{
+		Client:  client,
+		schemas: make(map[string][]byte),
+	}
+}
+
+// CollectionSchema returns the JSON schema declared in the info.schema field
+// of a collection.
+func (c *SchemaCache) CollectionSchema(ctx context.Context, collection *resources.Collection) []byte {
+	var u string
+	if collection != nil && collection.Collection != nil && collection.Info != nil {
+		u = collection.Info.Schema
+	}
+
+	return c.Fetch(ctx, u)
+}
+
+// Fetch returns the JSON schema at schemaURL, fetching it on first use.  The
+// embedded v2.1.0 collection schema is returned when schemaURL is empty or
+// the schema cannot be fetched or isn't a JSON object; failed fetches are
+// retried on the next call.
+func (c *SchemaCache) Fetch(ctx context.Context, schemaURL string) []byte {
+	if schemaURL == "" {
+		return schema.CollectionV21
+	}
+
+	c.mu.Lock()
+	s, ok := c.schemas[schemaURL]
+	c.mu.Unlock()
+	if ok {
+		return s
+	}
+
+	s, err := c.fetch(ctx, schemaURL)
+	if err != nil {
+		return schema.CollectionV21
+	}
+
+	c.mu.Lock()
+	if c.schemas == nil {
+		c.schemas = make(map[string][]byte)
+	}
+	c.schemas[schemaURL] = s
+	c.mu.Unlock()
+
+	return s
+}
+
+func (c *SchemaCache) fetch(ctx context.Context, schemaURL string) ([]byte, error) {
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("fetching schema %s: status code: %d", schemaURL, resp.StatusCode)
+	}
+
+	s, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxSchemaSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s) > maxSchemaSize {
+		return nil, fmt.Errorf("fetching schema %s: the schema is larger than %d bytes", schemaURL, maxSchemaSize)
+	}
+
+	var v map[string]interface{}
+	if err := json.Unmarshal(s, &v); err != nil {
+		return nil, fmt.Errorf("fetching schema %s: %w", schemaURL, err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/schema"
+)
+
+func TestSchemaCacheFetchesDeclaredSchema(t *testing.T) {
+	subject := `{"id":"https://schema.example.com/collection/v9.9.9/"}`
+	calls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	var c resources.Collection
+	data := `{"info":{"name":"schema","schema":"` + server.URL + `/collection.json"},"item":[]}`
+	if err := json.Unmarshal([]byte(data), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := client.NewSchemaCache(server.Client())
+	for i := 0; i < 2; i++ {
+		if s := cache.CollectionSchema(context.Background(), &c); string(s) != subject {
+			t.Errorf("Schema is incorrect, have: %s, want: %s", s, subject)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected schema to be cached, fetched %d times", calls)
+	}
+}
+
+func TestSchemaCacheFallsBackOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cache := client.NewSchemaCache(server.Client())
+
+	if s := cache.Fetch(context.Background(), server.URL); !bytes.Equal(s, schema.CollectionV21) {
+		t.Error("Expected embedded schema on fetch failure.")
+	}
+
+	server.Close()
+	if s := cache.Fetch(context.Background(), server.URL); !bytes.Equal(s, schema.CollectionV21) {
+		t.Error("Expected embedded schema on network failure.")
+	}
+
+	if len(schema.CollectionV21) == 0 {
+		t.Error("Expected embedded schema to be non-empty.")
+	}
+}
+
+func TestSchemaCacheRejectsNonJSON(t *testing.T) {
+	body := "<html>not a schema</html>"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	cache := client.NewSchemaCache(server.Client())
+
+	if s := cache.Fetch(context.Background(), server.URL); !bytes.Equal(s, schema.CollectionV21) {
+		t.Errorf("Expected embedded schema for a non-JSON response, have: %s", s)
+	}
+
+	body = `{"id":"fixed"}`
+	if s := cache.Fetch(context.Background(), server.URL); string(s) != body {
+		t.Errorf("Expected the non-JSON response not to be cached, have: %s", s)
+	}
+}
+
+func TestSchemaCacheRejectsOversizedSchema(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		// The client stops reading early, so write errors are expected.
+		_, _ = w.Write([]byte(`{"pad":"` + strings.Repeat("x", 5<<20) + `"}`))
+	}))
+	defer server.Close()
+
+	cache := client.NewSchemaCache(server.Client())
+
+	if s := cache.Fetch(context.Background(), server.URL); !bytes.Equal(s, schema.CollectionV21) {
+		t.Error("Expected embedded schema for an oversized response.")
+	}
+}
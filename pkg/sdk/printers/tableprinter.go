@@ -73,14 +73,22 @@ func (p *TablePrinter) PrintResource(r resources.Formatter, output io.Writer) {
 		vals := make([]string, len(cols))
 		for i, c := range cols {
 			rVal := reflect.ValueOf(obj)
-			f := reflect.Indirect(rVal).FieldByName(c).String()
-			vals[i] = f
+			vals[i] = fieldString(reflect.Indirect(rVal).FieldByName(c))
 		}
 
 		fmt.Fprintln(w, strings.Join(vals, "\t"))
 	}
 }
 
+// fieldString returns the printable value of a column field.
+func fieldString(f reflect.Value) string {
+	if !f.IsValid() || f.Kind() == reflect.String {
+		return f.String()
+	}
+
+	return fmt.Sprint(f.Interface())
+}
+
 // GetNewTabWriter returns a new formatted tabwriter.Writer.
 func GetNewTabWriter(output io.Writer) *tabwriter.Writer {
 	return tabwriter.NewWriter(output, tabwriterMinWidth, tabwriterWidth,
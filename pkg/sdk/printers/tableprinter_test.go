@@ -86,3 +86,24 @@ abcdef   test collection
 		t.Errorf("Unexpected output, have: \"%s\", want: \"%s\"", actual, expected)
 	}
 }
+
+func TestTablePrinterPrintsNumericColumns(t *testing.T) {
+	printer := printers.NewTablePrinter(printers.PrintOptions{})
+
+	var comments resources.CommentListItems = []resources.Comment{
+		{ID: 1, ThreadID: 10, CreatedBy: 12345, Body: "Looks good"},
+	}
+
+	var b bytes.Buffer
+
+	printer.PrintResource(comments, &b)
+
+	expected := `ID    THREADID   CREATEDBY   BODY
+1     10         12345       Looks good
+`
+
+	actual := b.String()
+	if expected != actual {
+		t.Errorf("Unexpected output, have: \"%s\", want: \"%s\"", actual, expected)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import "sync"
+
+// ProgressFunc is called by bulk operations each time a unit of work
+// completes, with the number of units done so far and the total.  Calls are
+// serialized, so done increases by one with each call.
+type ProgressFunc func(done, total int)
+
+// progress counts completed work for a ProgressFunc.  It is safe for
+// concurrent use, and a nil progress or ProgressFunc does nothing.
+type progress struct {
+	mu    sync.Mutex
+	done  int
+	total int
+	fn    ProgressFunc
+}
+
+func newProgress(total int, fn ProgressFunc) *progress {
+	return &progress{total: total, fn: fn}
+}
+
+// step records one completed unit of work.
+func (p *progress) step() {
+	if p == nil || p.fn == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done++
+	p.fn(p.done, p.total)
+}
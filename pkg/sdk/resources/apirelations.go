@@ -32,8 +32,8 @@ type FormattedAPIRelationItem struct {
 
 // NewFormattedAPIRelationItems returns a new human-readable view of API Relations.
 func NewFormattedAPIRelationItems(r *APIRelations) FormattedAPIRelationItems {
-	totalLen := len(r.Documentation) + len(r.ContractTest) + len(r.TestSuite) +
-		len(r.IntegrationTest) + len(r.Mock) + len(r.Monitor)
+	totalLen := len(r.Documentation) + len(r.Environment) + len(r.ContractTest) +
+		len(r.TestSuite) + len(r.IntegrationTest) + len(r.Mock) + len(r.Monitor)
 
 	i := 0
 	ret := make([]FormattedAPIRelationItem, totalLen)
@@ -114,6 +114,31 @@ func (r FormattedAPIRelationItems) Format() ([]string, []interface{}) {
 	return []string{"ID", "Name", "Type"}, s
 }
 
+// APIRelationType is the kind of element related to an API version.
+type APIRelationType string
+
+// API relation types.
+const (
+	APIRelationDocumentation   APIRelationType = "documentation"
+	APIRelationEnvironment     APIRelationType = "environment"
+	APIRelationContractTest    APIRelationType = "contracttest"
+	APIRelationTestSuite       APIRelationType = "testsuite"
+	APIRelationIntegrationTest APIRelationType = "integrationtest"
+	APIRelationMock            APIRelationType = "mock"
+	APIRelationMonitor         APIRelationType = "monitor"
+)
+
+// Valid reports whether the type is a known API relation type.
+func (t APIRelationType) Valid() bool {
+	switch t {
+	case APIRelationDocumentation, APIRelationEnvironment, APIRelationContractTest, APIRelationTestSuite,
+		APIRelationIntegrationTest, APIRelationMock, APIRelationMonitor:
+		return true
+	}
+
+	return false
+}
+
 // APIRelationsResource provides the top-level wrapper for API Relations.
 type APIRelationsResource struct {
 	Relations APIRelations `json:"relations"`
@@ -54,7 +54,8 @@ type APIResponse struct {
 	API API `json:"api"`
 }
 
-// API represents a single item in an APIListResponse.
+// API represents a single item in an APIListResponse.  Fields the SDK
+// doesn't model are kept in Extras.
 type API struct {
 	CreatedBy   string    `json:"createdBy"`
 	UpdatedBy   string    `json:"updatedBy"`
@@ -65,6 +66,7 @@ type API struct {
 	Description string    `json:"description"`
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
+	Extras      Extras    `json:"-"`
 }
 
 // Format returns column headers and values for the resource.
@@ -66,6 +66,7 @@ type APIVersion struct {
 	UpdatedBy     string    `json:"updatedBy"`
 	LastRevision  int64     `json:"lastRevision"`
 	Schema        []string  `json:"schema"`
+	Published     bool      `json:"published,omitempty"`
 }
 
 // Format returns column headers and values for the resource.
@@ -88,3 +89,42 @@ func (r APIVersionSlice) Format() ([]string, []interface{}) {
 
 	return []string{"ID", "Name"}, s
 }
+
+// PublishVisibility controls which API network a published API version is
+// listed on.
+type PublishVisibility string
+
+// Publish visibilities.
+const (
+	PublishVisibilityPrivate PublishVisibility = "private"
+	PublishVisibilityPublic  PublishVisibility = "public"
+)
+
+// Valid reports whether the visibility is a known publish visibility.
+func (v PublishVisibility) Valid() bool {
+	return v == PublishVisibilityPrivate || v == PublishVisibilityPublic
+}
+
+// PublishOptions holds the settings for publishing an API version.  An empty
+// Visibility publishes to the private API network.
+type PublishOptions struct {
+	Visibility   PublishVisibility `json:"visibility,omitempty"`
+	ReleaseNotes string            `json:"releaseNotes,omitempty"`
+}
+
+// PublishedElementResponse is the top-level response of publishing an API
+// version.
+type PublishedElementResponse struct {
+	Element PublishedElement `json:"element"`
+}
+
+// PublishedElement references an API version published to an API network.
+type PublishedElement struct {
+	ID          string            `json:"id"`
+	Type        string            `json:"type"`
+	API         string            `json:"apiId"`
+	Version     string            `json:"versionId"`
+	Visibility  PublishVisibility `json:"visibility"`
+	URL         string            `json:"url"`
+	PublishedAt time.Time         `json:"publishedAt"`
+}
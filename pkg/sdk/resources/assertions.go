@@ -0,0 +1,179 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Assertion is a check of the response to a request in a local run.
+// Check returns an error describing why the response fails the check.
+type Assertion interface {
+	Name() string
+	Check(res *RunResponse) error
+}
+
+// StatusEquals asserts that the response has status code Code.
+type StatusEquals struct {
+	Code int
+}
+
+// Name describes the assertion.
+func (a StatusEquals) Name() string {
+	return fmt.Sprintf("Status code is %d", a.Code)
+}
+
+// Check reports whether the response has the expected status code.
+func (a StatusEquals) Check(res *RunResponse) error {
+	if res.Code != a.Code {
+		return fmt.Errorf("expected status code %d, got %d", a.Code, res.Code)
+	}
+
+	return nil
+}
+
+// HeaderPresent asserts that the response has the header named Header.
+type HeaderPresent struct {
+	Header string
+}
+
+// Name describes the assertion.
+func (a HeaderPresent) Name() string {
+	return fmt.Sprintf("Header %s is present", a.Header)
+}
+
+// Check reports whether the response has the expected header.
+func (a HeaderPresent) Check(res *RunResponse) error {
+	for k := range res.Headers {
+		if strings.EqualFold(k, a.Header) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("expected header %s to be present", a.Header)
+}
+
+// JSONPathEquals asserts that the value at Path in the JSON response body
+// equals Value.  Path is a dotted path with optional array indexes, such as
+// $.data.users[0].name, where the leading $ is optional.  Values are
+// compared as decoded JSON, so numbers of any Go type match.
+type JSONPathEquals struct {
+	Path  string
+	Value interface{}
+}
+
+// Name describes the assertion.
+func (a JSONPathEquals) Name() string {
+	return fmt.Sprintf("Body %s equals %v", a.Path, a.Value)
+}
+
+// Check reports whether the response body has the expected value at Path.
+func (a JSONPathEquals) Check(res *RunResponse) error {
+	var body interface{}
+	if err := json.Unmarshal([]byte(runBodyText(res.Body)), &body); err != nil {
+		return fmt.Errorf("response body is not JSON: %w", err)
+	}
+
+	have, err := jsonPathValue(body, a.Path)
+	if err != nil {
+		return err
+	}
+
+	want, err := json.Marshal(a.Value)
+	if err != nil {
+		return err
+	}
+
+	var wantValue interface{}
+	if err := json.Unmarshal(want, &wantValue); err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(have, wantValue) {
+		got, _ := json.Marshal(have)
+		return fmt.Errorf("expected %s to equal %s, got %s", a.Path, want, got)
+	}
+
+	return nil
+}
+
+// jsonPathValue returns the value at a dotted path in a decoded JSON value.
+func jsonPathValue(v interface{}, path string) (interface{}, error) {
+	p := strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if p == "" {
+		return v, nil
+	}
+
+	for _, segment := range strings.Split(p, ".") {
+		key := segment
+		var indexes []string
+		if i := strings.Index(segment, "["); i >= 0 {
+			key = segment[:i]
+			for _, s := range strings.Split(segment[i+1:], "[") {
+				if !strings.HasSuffix(s, "]") {
+					return nil, fmt.Errorf("invalid path %q", path)
+				}
+				indexes = append(indexes, strings.TrimSuffix(s, "]"))
+			}
+		}
+
+		if key != "" {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s not found", path)
+			}
+			if v, ok = m[key]; !ok {
+				return nil, fmt.Errorf("%s not found", path)
+			}
+		}
+
+		for _, s := range indexes {
+			i, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q", path)
+			}
+
+			list, ok := v.([]interface{})
+			if !ok || i < 0 || i >= len(list) {
+				return nil, fmt.Errorf("%s not found", path)
+			}
+			v = list[i]
+		}
+	}
+
+	return v, nil
+}
+
+// runAssertions checks the response of an execution against assertions,
+// failing every assertion when there is no response.
+func runAssertions(execution *RunExecution, assertions []Assertion) {
+	for _, a := range assertions {
+		result := RunAssertion{Assertion: a.Name()}
+
+		if execution.Response == nil {
+			result.Error = &RunError{Name: "AssertionError", Message: "no response received"}
+		} else if err := a.Check(execution.Response); err != nil {
+			result.Error = &RunError{Name: "AssertionError", Message: err.Error()}
+		}
+
+		execution.Assertions = append(execution.Assertions, result)
+	}
+}
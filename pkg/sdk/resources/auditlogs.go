@@ -0,0 +1,72 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import "time"
+
+// AuditLogListResponse represents a page of the audit logs response from the
+// Postman API.
+type AuditLogListResponse struct {
+	Trails     AuditLogEntries `json:"trails"`
+	NextCursor string          `json:"nextCursor"`
+}
+
+// AuditLogEntries is a slice of AuditLogEntry.
+type AuditLogEntries []AuditLogEntry
+
+// Format returns column headers and values for the resource.
+func (r AuditLogEntries) Format() ([]string, []interface{}) {
+	s := make([]interface{}, len(r))
+	for i, v := range r {
+		s[i] = v
+	}
+
+	return []string{"ID", "Timestamp", "Action", "Message"}, s
+}
+
+// AuditLogEntry represents a single audit log event of a team.
+type AuditLogEntry struct {
+	ID        int          `json:"id"`
+	IP        string       `json:"ip"`
+	UserAgent string       `json:"userAgent"`
+	Action    string       `json:"action"`
+	Timestamp time.Time    `json:"timestamp"`
+	Message   string       `json:"message"`
+	Data      AuditLogData `json:"data"`
+}
+
+// AuditLogData holds the actor of an audit log event and the user or team
+// it affected.
+type AuditLogData struct {
+	Actor AuditLogUser  `json:"actor"`
+	User  *AuditLogUser `json:"user,omitempty"`
+	Team  *AuditLogTeam `json:"team,omitempty"`
+}
+
+// AuditLogUser represents a user referenced by an audit log event.
+type AuditLogUser struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// AuditLogTeam represents a team referenced by an audit log event.
+type AuditLogTeam struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
@@ -0,0 +1,231 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+// EffectiveAuth returns the auth used by the request or folder at itemPath,
+// given as folder names followed by the item name.  Items without auth of
+// their own inherit it from the nearest folder or the collection.  It
+// returns nil when no auth applies, either because none is defined or
+// because a noauth override stops inheritance.
+func (c *Collection) EffectiveAuth(itemPath []string) (*gen.Auth, error) {
+	if c.Collection == nil {
+		return nil, errors.New("the collection is empty")
+	}
+
+	chain := []interface{}{c.Auth}
+	items := c.Item
+	for i, name := range itemPath {
+		item := findRawItem(items, name)
+		if item == nil {
+			return nil, fmt.Errorf("item %q not found", strings.Join(itemPath[:i+1], "/"))
+		}
+
+		if sub, ok := item["item"].([]interface{}); ok {
+			chain = append(chain, item["auth"])
+			items = sub
+			continue
+		}
+
+		if i != len(itemPath)-1 {
+			return nil, fmt.Errorf("item %q is not a folder", strings.Join(itemPath[:i+1], "/"))
+		}
+
+		if request, ok := item["request"].(map[string]interface{}); ok {
+			chain = append(chain, request["auth"])
+		}
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		auth, err := parseAuth(chain[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if auth == nil {
+			continue
+		}
+
+		if auth.Type == "noauth" {
+			return nil, nil
+		}
+
+		return auth, nil
+	}
+
+	return nil, nil
+}
+
+// inheritAuth replaces the auth of r, the request at itemPath, with the one
+// it effectively uses, so requests without auth of their own are sent with
+// that of the nearest folder or the collection.
+func (c *Collection) inheritAuth(r *Request, itemPath []string) error {
+	auth, err := c.EffectiveAuth(itemPath)
+	if err != nil {
+		return err
+	}
+
+	r.Auth = auth
+
+	return nil
+}
+
+// findRawItem returns the raw item named name, or nil when there is none.
+func findRawItem(items []interface{}, name string) map[string]interface{} {
+	for _, v := range items {
+		if m, ok := v.(map[string]interface{}); ok && m["name"] == name {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// parseAuth converts a raw auth block into an Auth, returning nil when the
+// block is missing or inherits its auth.
+func parseAuth(raw interface{}) (*gen.Auth, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var auth gen.Auth
+	if err := json.Unmarshal(data, &auth); err != nil {
+		return nil, fmt.Errorf("invalid auth: %w", err)
+	}
+
+	if auth.Type == "" || auth.Type == "inherit" {
+		return nil, nil
+	}
+
+	return &auth, nil
+}
+
+// upgradeLegacyAuthJSON returns a collection document with its legacy auth
+// blocks upgraded by upgradeLegacyAuth.  ok is false when the document has
+// none, or can't be decoded, in which case it should be used as it is.
+func upgradeLegacyAuthJSON(b []byte) (upgraded []byte, ok bool) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil || !upgradeLegacyAuth(doc) {
+		return nil, false
+	}
+
+	upgraded, err := json.Marshal(doc)
+	if err != nil {
+		return nil, false
+	}
+
+	return upgraded, true
+}
+
+// upgradeLegacyAuth rewrites the auth blocks of a decoded collection
+// document, and of its folders and requests, that use a legacy form: a bare
+// auth type name, or the object form of collection format v2.0.0, where the
+// attributes of an auth type are a map of names to values.  They are
+// converted to the key/value lists of the current format, keeping the
+// values.  It reports whether any block was upgraded.
+func upgradeLegacyAuth(doc map[string]interface{}) bool {
+	upgraded := upgradeAuthField(doc)
+
+	var walk func(items []interface{})
+	walk = func(items []interface{}) {
+		for _, v := range items {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if upgradeAuthField(m) {
+				upgraded = true
+			}
+
+			if request, ok := m["request"].(map[string]interface{}); ok && upgradeAuthField(request) {
+				upgraded = true
+			}
+
+			if children, ok := m["item"].([]interface{}); ok {
+				walk(children)
+			}
+		}
+	}
+
+	if items, ok := doc["item"].([]interface{}); ok {
+		walk(items)
+	}
+
+	return upgraded
+}
+
+// upgradeAuthField upgrades the auth block of m, if it has a legacy one.
+func upgradeAuthField(m map[string]interface{}) bool {
+	switch auth := m["auth"].(type) {
+	case string:
+		if auth == "" {
+			return false
+		}
+
+		m["auth"] = map[string]interface{}{"type": auth}
+		return true
+	case map[string]interface{}:
+		upgraded := false
+		for k, v := range auth {
+			attrs, ok := v.(map[string]interface{})
+			if !ok || k == "type" {
+				continue
+			}
+
+			keys := make([]string, 0, len(attrs))
+			for key := range attrs {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			list := make([]interface{}, len(keys))
+			for i, key := range keys {
+				typ := "any"
+				if _, ok := attrs[key].(string); ok {
+					typ = "string"
+				}
+				list[i] = map[string]interface{}{"key": key, "value": attrs[key], "type": typ}
+			}
+
+			auth[k] = list
+			upgraded = true
+		}
+
+		return upgraded
+	}
+
+	return false
+}
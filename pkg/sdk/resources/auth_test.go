@@ -0,0 +1,140 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import "testing"
+
+const authSubject = `{
+  "info": {"name": "auth", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{token}}", "type": "string"}]},
+  "item": [
+    {"name": "Users", "item": [
+      {"name": "List users", "request": {"method": "GET", "url": "{{baseUrl}}/users"}},
+      {"name": "Admin", "auth": {"type": "basic", "basic": [{"key": "username", "value": "admin"}]}, "item": [
+        {"name": "Reset", "request": {"method": "POST", "url": "{{baseUrl}}/admin/reset"}},
+        {"name": "Audit", "request": {"method": "GET", "url": "{{baseUrl}}/admin/audit", "auth": {"type": "apikey", "apikey": [{"key": "key", "value": "X-Audit"}]}}}
+      ]}
+    ]},
+    {"name": "Health", "request": {"method": "GET", "url": "{{baseUrl}}/health", "auth": {"type": "noauth"}}},
+    {"name": "Public", "auth": {"type": "noauth"}, "item": [
+      {"name": "Status", "request": {"method": "GET", "url": "{{baseUrl}}/status"}}
+    ]}
+  ]
+}`
+
+func TestEffectiveAuthInherited(t *testing.T) {
+	c := unmarshalCollection(t, authSubject)
+
+	auth, err := c.EffectiveAuth([]string{"Users", "List users"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if auth == nil || auth.Type != "bearer" {
+		t.Fatalf("Expected collection bearer auth, have: %+v", auth)
+	}
+
+	if auth, _ := c.EffectiveAuth([]string{"Users", "Admin", "Reset"}); auth == nil || auth.Type != "basic" {
+		t.Errorf("Expected folder basic auth, have: %+v", auth)
+	}
+}
+
+func TestEffectiveAuthOverridden(t *testing.T) {
+	c := unmarshalCollection(t, authSubject)
+
+	auth, err := c.EffectiveAuth([]string{"Users", "Admin", "Audit"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if auth == nil || auth.Type != "apikey" {
+		t.Errorf("Expected request apikey auth, have: %+v", auth)
+	}
+}
+
+func TestEffectiveAuthNoauth(t *testing.T) {
+	c := unmarshalCollection(t, authSubject)
+
+	for _, path := range [][]string{{"Health"}, {"Public", "Status"}} {
+		auth, err := c.EffectiveAuth(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if auth != nil {
+			t.Errorf("Expected no auth for %v, have: %+v", path, auth)
+		}
+	}
+}
+
+func TestEffectiveAuthMissingItem(t *testing.T) {
+	c := unmarshalCollection(t, authSubject)
+
+	if _, err := c.EffectiveAuth([]string{"Users", "Missing"}); err == nil {
+		t.Error("Expected error.")
+	}
+
+	if _, err := c.EffectiveAuth([]string{"Health", "Nested"}); err == nil {
+		t.Error("Expected error.")
+	}
+}
+
+const legacyAuthSubject = `{
+  "info": {"name": "legacy", "schema": "https://schema.getpostman.com/json/collection/v2.0.0/collection.json"},
+  "auth": {"type": "basic", "basic": {"username": "ada", "password": "s3cret"}},
+  "item": [
+    {"name": "Admin", "auth": "noauth", "item": [
+      {"name": "Reset", "request": {"method": "POST", "url": "{{baseUrl}}/admin/reset"}}
+    ]},
+    {"name": "Users", "request": {"method": "GET", "url": "{{baseUrl}}/users", "auth": {"type": "bearer", "bearer": {"token": "{{token}}"}}}}
+  ]
+}`
+
+func TestLegacyAuthUpgraded(t *testing.T) {
+	c := unmarshalCollection(t, legacyAuthSubject)
+
+	basic, err := c.EffectiveAuth(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if basic == nil || basic.Type != "basic" || len(basic.Basic) != 2 {
+		t.Fatalf("Expected structured basic auth, have: %+v", basic)
+	}
+
+	credentials := map[string]interface{}{}
+	for _, attr := range basic.Basic {
+		credentials[attr.Key] = attr.Value
+	}
+
+	if credentials["username"] != "ada" || credentials["password"] != "s3cret" {
+		t.Errorf("Expected credentials to be preserved, have: %v", credentials)
+	}
+
+	if auth, err := c.EffectiveAuth([]string{"Admin", "Reset"}); err != nil || auth != nil {
+		t.Errorf("Expected noauth folder to stop inheritance, have: %+v, %v", auth, err)
+	}
+
+	auth, err := c.EffectiveAuth([]string{"Users"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if auth == nil || auth.Type != "bearer" || len(auth.Bearer) != 1 || auth.Bearer[0].Key != "token" || auth.Bearer[0].Value != "{{token}}" {
+		t.Errorf("Expected structured bearer auth, have: %+v", auth)
+	}
+}
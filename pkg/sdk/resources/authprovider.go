@@ -0,0 +1,71 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"net/http"
+)
+
+// AuthProvider supplies credentials for a request at the time it's built,
+// in place of the auth stored in the collection.
+type AuthProvider interface {
+	Authenticate(req *http.Request) error
+}
+
+// BasicAuth authenticates requests with a username and password.
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// Authenticate sets the Authorization header of req.
+func (a BasicAuth) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Password)
+	return nil
+}
+
+// BearerAuth authenticates requests with a bearer token.
+type BearerAuth struct {
+	Token string
+}
+
+// Authenticate sets the Authorization header of req.
+func (a BearerAuth) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
+
+// APIKeyAuth authenticates requests with an API key sent in the header
+// named Key, or in the query parameter named Key when InQuery is set.
+type APIKeyAuth struct {
+	Key     string
+	Value   string
+	InQuery bool
+}
+
+// Authenticate adds the API key to req.
+func (a APIKeyAuth) Authenticate(req *http.Request) error {
+	if a.InQuery {
+		q := req.URL.Query()
+		q.Set(a.Key, a.Value)
+		req.URL.RawQuery = q.Encode()
+	} else {
+		req.Header.Set(a.Key, a.Value)
+	}
+
+	return nil
+}
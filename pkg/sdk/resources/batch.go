@@ -0,0 +1,89 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import "fmt"
+
+// BatchResult represents a response of the Postman API to a request on
+// several items at once, reporting the outcome of each item in a single
+// successful response.
+type BatchResult struct {
+	Results []BatchItemResult `json:"results"`
+}
+
+// BatchItemResult is the outcome of a single item of a batch, with an
+// HTTP-style status and, for failed items, the error.
+type BatchItemResult struct {
+	ID     string `json:"id"`
+	Status int    `json:"status"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// Succeeded reports whether the item succeeded.  Items without a status
+// succeeded unless they have an error.
+func (r BatchItemResult) Succeeded() bool {
+	return r.Error == nil && (r.Status == 0 || (r.Status >= 200 && r.Status < 300))
+}
+
+// Errors returns a *BatchItemError for each item that failed, in order.
+func (r BatchResult) Errors() []error {
+	var errs []error
+	for i, item := range r.Results {
+		if item.Succeeded() {
+			continue
+		}
+
+		err := &BatchItemError{Index: i, ID: item.ID, Status: item.Status}
+		if item.Error != nil {
+			err.Err = *item.Error
+		}
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// BatchItemError describes an item of a batch that failed.  Index is the
+// position of the item in the batch.
+type BatchItemError struct {
+	Index  int
+	ID     string
+	Status int
+	Err    Error
+}
+
+func (e *BatchItemError) Error() string {
+	item := fmt.Sprintf("item %d", e.Index)
+	if e.ID != "" {
+		item = fmt.Sprintf("item %d (%s)", e.Index, e.ID)
+	}
+
+	msg := e.Err.Message
+	if msg == "" {
+		msg = "failed"
+	}
+
+	if e.Err.Name != "" {
+		msg = fmt.Sprintf("%s: %s", e.Err.Name, msg)
+	}
+
+	if e.Status != 0 {
+		return fmt.Sprintf("%s: status %d: %s", item, e.Status, msg)
+	}
+
+	return fmt.Sprintf("%s: %s", item, msg)
+}
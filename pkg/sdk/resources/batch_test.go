@@ -0,0 +1,71 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestBatchResultErrors(t *testing.T) {
+	subject := `{"results":[
+		{"id":"1234","status":200},
+		{"id":"5678","status":400,"error":{"name":"instanceNotFoundError","message":"The collection was not found.","details":{"id":"not found"}}}
+	]}`
+
+	var result resources.BatchResult
+	if err := json.Unmarshal([]byte(subject), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Results[0].Succeeded() || result.Results[1].Succeeded() {
+		t.Errorf("Item outcomes are incorrect, have: %+v", result.Results)
+	}
+
+	errs := result.Errors()
+	if len(errs) != 1 {
+		t.Fatalf("Incorrect number of errors, have: %d, want: %d", len(errs), 1)
+	}
+
+	var itemErr *resources.BatchItemError
+	if !errors.As(errs[0], &itemErr) {
+		t.Fatalf("Error type is incorrect, have: %T, want: %T", errs[0], itemErr)
+	}
+
+	if itemErr.Index != 1 || itemErr.ID != "5678" || itemErr.Status != 400 {
+		t.Errorf("Error is incorrect, have: %+v", itemErr)
+	}
+
+	if len(itemErr.Err.FieldErrors) != 1 || itemErr.Err.FieldErrors[0].Field != "id" {
+		t.Errorf("Field errors are incorrect, have: %+v", itemErr.Err.FieldErrors)
+	}
+
+	if have, want := itemErr.Error(), "item 1 (5678): status 400: instanceNotFoundError: The collection was not found."; have != want {
+		t.Errorf("Error message is incorrect, have: %s, want: %s", have, want)
+	}
+}
+
+func TestBatchResultAllSucceeded(t *testing.T) {
+	result := resources.BatchResult{Results: []resources.BatchItemResult{{ID: "1"}, {ID: "2", Status: 201}}}
+
+	if errs := result.Errors(); len(errs) != 0 {
+		t.Errorf("Expected no errors, have: %v", errs)
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// BundleOpenAPI reads the OpenAPI spec at rootPath, JSON or YAML, and
+// returns it as a single JSON spec with each $ref to another local file
+// replaced by the content it refers to.  References within the root spec,
+// which may be recursive, are kept; those within referenced files are
+// inlined too, since they can't be kept once the files are merged.
+// Circular references between files and references to missing files are
+// errors.
+func BundleOpenAPI(rootPath string) ([]byte, error) {
+	root, err := filepath.Abs(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	b := specBundler{
+		root:      root,
+		files:     make(map[string]interface{}),
+		resolving: make(map[string]bool),
+	}
+
+	doc, err := b.load(root)
+	if err != nil {
+		return nil, err
+	}
+
+	bundled, err := b.bundle(doc, root)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(bundled)
+}
+
+// specBundler inlines the external references of a spec.
+type specBundler struct {
+	root string
+
+	// files holds the parsed files by path.
+	files map[string]interface{}
+
+	// resolving holds the references being inlined, as path#pointer, to
+	// detect cycles.
+	resolving map[string]bool
+}
+
+func (b *specBundler) load(path string) (interface{}, error) {
+	if doc, ok := b.files[path]; ok {
+		return doc, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	if isJSONSpec(data) {
+		err = json.Unmarshal(data, &doc)
+	} else {
+		err = yaml.Unmarshal(data, &doc)
+		doc = normalizeYAML(doc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	b.files[path] = doc
+
+	return doc, nil
+}
+
+// bundle returns a copy of v, found in file, with its references inlined.
+func (b *specBundler) bundle(v interface{}, file string) (interface{}, error) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := t["$ref"].(string); ok {
+			return b.resolve(ref, t, file)
+		}
+
+		m := make(map[string]interface{}, len(t))
+		for k, child := range t {
+			bundled, err := b.bundle(child, file)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = bundled
+		}
+		return m, nil
+	case []interface{}:
+		s := make([]interface{}, len(t))
+		for i, child := range t {
+			bundled, err := b.bundle(child, file)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = bundled
+		}
+		return s, nil
+	}
+
+	return v, nil
+}
+
+// resolve returns the bundled content of the reference ref, made by node
+// in file.
+func (b *specBundler) resolve(ref string, node map[string]interface{}, file string) (interface{}, error) {
+	target, pointer := ref, ""
+	if i := strings.Index(ref, "#"); i >= 0 {
+		target, pointer = ref[:i], ref[i+1:]
+	}
+
+	switch {
+	case strings.Contains(target, "://"):
+		// Remote references are left for the importer.
+		return node, nil
+	case target == "" && file == b.root:
+		return node, nil
+	case target == "":
+		target = file
+	case !filepath.IsAbs(target):
+		target = filepath.Join(filepath.Dir(file), filepath.FromSlash(target))
+	}
+
+	key := target + "#" + pointer
+	if b.resolving[key] {
+		return nil, fmt.Errorf("circular $ref %q in %s", ref, file)
+	}
+
+	doc, err := b.load(target)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("$ref %q in %s: file %s not found", ref, file, target)
+	} else if err != nil {
+		return nil, err
+	}
+
+	v, err := specPointer(doc, pointer)
+	if err != nil {
+		return nil, fmt.Errorf("$ref %q in %s: %w", ref, file, err)
+	}
+
+	b.resolving[key] = true
+	defer delete(b.resolving, key)
+
+	return b.bundle(v, target)
+}
+
+// specPointer returns the value at the JSON pointer in doc.
+func specPointer(doc interface{}, pointer string) (interface{}, error) {
+	pointer = strings.TrimPrefix(pointer, "/")
+	if pointer == "" {
+		return doc, nil
+	}
+
+	v := doc
+	for _, token := range strings.Split(pointer, "/") {
+		if unescaped, err := url.PathUnescape(token); err == nil {
+			token = unescaped
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		switch t := v.(type) {
+		case map[string]interface{}:
+			child, ok := t[token]
+			if !ok {
+				return nil, fmt.Errorf("%q not found", pointer)
+			}
+			v = child
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil, fmt.Errorf("%q not found", pointer)
+			}
+			v = t[i]
+		default:
+			return nil, fmt.Errorf("%q not found", pointer)
+		}
+	}
+
+	return v, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func writeSpecFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestBundleOpenAPI(t *testing.T) {
+	dir := writeSpecFiles(t, map[string]string{
+		"openapi.yaml": `openapi: 3.0.0
+info:
+  title: Users
+  version: "1.0"
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: "schemas/user.json#/User"
+components:
+  schemas:
+    Node:
+      properties:
+        next:
+          $ref: "#/components/schemas/Node"
+`,
+		"schemas/user.json": `{"User":{"type":"object","properties":{"id":{"type":"integer"},"address":{"$ref":"#/Address"}}},"Address":{"type":"string"}}`,
+	})
+
+	spec, err := resources.BundleOpenAPI(filepath.Join(dir, "openapi.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Paths map[string]struct {
+			Get struct {
+				Responses map[string]struct {
+					Content map[string]struct {
+						Schema map[string]interface{} `json:"schema"`
+					} `json:"content"`
+				} `json:"responses"`
+			} `json:"get"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	schema := doc.Paths["/users"].Get.Responses["200"].Content["application/json"].Schema
+	if schema["type"] != "object" {
+		t.Errorf("External schema was not inlined, have: %s", spec)
+	}
+
+	if !strings.Contains(string(spec), `"address":{"type":"string"}`) {
+		t.Errorf("Reference within the external file was not inlined, have: %s", spec)
+	}
+
+	if !strings.Contains(string(spec), `"$ref":"#/components/schemas/Node"`) {
+		t.Errorf("Reference within the root spec was not kept, have: %s", spec)
+	}
+
+	if errs := resources.ValidateOpenAPI(spec); len(errs) > 0 {
+		t.Errorf("Bundled spec is invalid: %v", errs)
+	}
+}
+
+func TestBundleOpenAPIMissingRef(t *testing.T) {
+	dir := writeSpecFiles(t, map[string]string{
+		"openapi.json": `{"openapi":"3.0.0","info":{"title":"Users","version":"1.0"},"paths":{"/users":{"$ref":"paths/users.yaml"}}}`,
+	})
+
+	_, err := resources.BundleOpenAPI(filepath.Join(dir, "openapi.json"))
+	if err == nil || !strings.Contains(err.Error(), "users.yaml not found") {
+		t.Errorf("Expected missing file error, have: %v", err)
+	}
+}
+
+func TestBundleOpenAPICircularRef(t *testing.T) {
+	dir := writeSpecFiles(t, map[string]string{
+		"openapi.yaml": "openapi: 3.0.0\ncomponents:\n  schemas:\n    A:\n      $ref: a.yaml\n",
+		"a.yaml":       "properties:\n  b:\n    $ref: b.yaml\n",
+		"b.yaml":       "properties:\n  a:\n    $ref: a.yaml\n",
+	})
+
+	_, err := resources.BundleOpenAPI(filepath.Join(dir, "openapi.yaml"))
+	if err == nil || !strings.Contains(err.Error(), "circular $ref") {
+		t.Errorf("Expected circular reference error, have: %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+// Certificate represents a client certificate used for requests to the
+// URLs it matches.
+type Certificate struct {
+	*gen.Certificate
+}
+
+// AppliesTo reports whether the certificate applies to rawURL.  A certificate
+// without match patterns applies to every URL.
+func (c Certificate) AppliesTo(rawURL string) bool {
+	if c.Certificate == nil {
+		return false
+	}
+
+	if len(c.Certificate.Matches) == 0 {
+		return true
+	}
+
+	for _, m := range c.Certificate.Matches {
+		if matchURLPattern(fmt.Sprint(m), rawURL) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ProxyConfig represents a proxy used for requests to the URLs it matches.
+type ProxyConfig struct {
+	*gen.ProxyConfig
+}
+
+// AppliesTo reports whether the proxy is enabled and applies to rawURL.  A
+// proxy without a match pattern applies to every URL.
+func (p ProxyConfig) AppliesTo(rawURL string) bool {
+	if p.ProxyConfig == nil || p.Disabled {
+		return false
+	}
+
+	return p.Match == "" || matchURLPattern(p.Match, rawURL)
+}
+
+// matchURLPattern reports whether rawURL matches a Postman URL match
+// pattern, in which * matches any sequence of characters.
+func matchURLPattern(pattern, rawURL string) bool {
+	parts := strings.Split(pattern, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+
+	re, err := regexp.Compile("^" + strings.Join(parts, ".*") + "$")
+	if err != nil {
+		return false
+	}
+
+	return re.MatchString(rawURL)
+}
+
+// CertificateFor returns the certificate to use for r: its own certificate
+// when set, otherwise the first collection certificate matching its URL.
+// It returns nil when no certificate applies.
+func (c *Collection) CertificateFor(r *Request) *Certificate {
+	if r != nil && r.Certificate != nil && r.Certificate.Certificate != nil {
+		return r.Certificate
+	}
+
+	if c == nil || r == nil {
+		return nil
+	}
+
+	u := r.URL.String()
+	for i := range c.Certificates {
+		if c.Certificates[i].AppliesTo(u) {
+			return &c.Certificates[i]
+		}
+	}
+
+	return nil
+}
+
+// ProxyFor returns the proxy to use for r: its own proxy when set,
+// otherwise the collection proxy when it matches r's URL.  It returns nil
+// when no enabled proxy applies.
+func (c *Collection) ProxyFor(r *Request) *ProxyConfig {
+	if r == nil {
+		return nil
+	}
+
+	if r.Proxy != nil && r.Proxy.ProxyConfig != nil {
+		if r.Proxy.Disabled {
+			return nil
+		}
+		return r.Proxy
+	}
+
+	if c != nil && c.Proxy != nil && c.Proxy.AppliesTo(r.URL.String()) {
+		return c.Proxy
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+const certificateSubject = `{
+  "info": {"name": "certs", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "certificates": [
+    {"name": "internal", "matches": ["https://*.internal.example.com/*"], "cert": {"src": "/certs/client.pem"}, "key": {"src": "/certs/client.key"}, "passphrase": "s3cr3t"}
+  ],
+  "proxy": {"match": "https://*.internal.example.com/*", "host": "proxy.example.com", "port": 3128},
+  "item": [
+    {"name": "internal", "request": {"method": "GET", "url": "https://billing.internal.example.com/invoices"}},
+    {"name": "public", "request": {"method": "GET", "url": "https://api.example.com/users"}},
+    {"name": "pinned", "request": {
+      "method": "GET",
+      "url": "https://api.example.com/admin",
+      "certificate": {"name": "admin", "cert": {"src": "/certs/admin.pem"}},
+      "proxy": {"disabled": true}
+    }}
+  ]
+}`
+
+func collectionRequest(t *testing.T, c *resources.Collection, i int) *resources.Request {
+	t.Helper()
+
+	req, err := resources.ParseRequest((*c.Items.Root.Items)[i].Request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return req
+}
+
+func TestCollectionCertificateRoundTrip(t *testing.T) {
+	c := unmarshalCollection(t, certificateSubject)
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rt := unmarshalCollection(t, string(data))
+
+	if len(rt.Certificates) != 1 {
+		t.Fatalf("Incorrect number of certificates, have: %d, want: %d", len(rt.Certificates), 1)
+	}
+
+	cert := rt.Certificates[0]
+	if cert.Name != "internal" || cert.Cert.Src != "/certs/client.pem" || cert.Key.Src != "/certs/client.key" || cert.Passphrase != "s3cr3t" {
+		t.Errorf("Certificate is incorrect, have: %+v", cert.Certificate)
+	}
+
+	if rt.Proxy == nil || rt.Proxy.Host != "proxy.example.com" || rt.Proxy.Port != 3128 {
+		t.Errorf("Proxy is incorrect, have: %+v", rt.Proxy)
+	}
+
+	pinned := collectionRequest(t, rt, 2)
+	if pinned.Certificate == nil || pinned.Certificate.Name != "admin" {
+		t.Errorf("Request certificate is incorrect, have: %+v", pinned.Certificate)
+	}
+
+	if dup := c.Duplicate("copy"); len(dup.Certificates) != 1 || dup.Proxy == nil {
+		t.Error("Expected duplicate to keep certificate and proxy settings.")
+	}
+}
+
+func TestCollectionCertificateFor(t *testing.T) {
+	c := unmarshalCollection(t, certificateSubject)
+
+	if cert := c.CertificateFor(collectionRequest(t, c, 0)); cert == nil || cert.Name != "internal" {
+		t.Errorf("Expected the matching collection certificate, have: %+v", cert)
+	}
+
+	if cert := c.CertificateFor(collectionRequest(t, c, 1)); cert != nil {
+		t.Errorf("Expected no certificate, have: %+v", cert.Certificate)
+	}
+
+	if cert := c.CertificateFor(collectionRequest(t, c, 2)); cert == nil || cert.Name != "admin" {
+		t.Errorf("Expected the request certificate, have: %+v", cert)
+	}
+}
+
+func TestCollectionProxyFor(t *testing.T) {
+	c := unmarshalCollection(t, certificateSubject)
+
+	if p := c.ProxyFor(collectionRequest(t, c, 0)); p == nil || p.Host != "proxy.example.com" {
+		t.Errorf("Expected the matching collection proxy, have: %+v", p)
+	}
+
+	if p := c.ProxyFor(collectionRequest(t, c, 1)); p != nil {
+		t.Errorf("Expected no proxy, have: %+v", p.ProxyConfig)
+	}
+
+	if p := c.ProxyFor(collectionRequest(t, c, 2)); p != nil {
+		t.Errorf("Expected the disabled request proxy to override the collection proxy, have: %+v", p.ProxyConfig)
+	}
+}
@@ -17,7 +17,11 @@ limitations under the License.
 package resources
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
@@ -25,20 +29,134 @@ import (
 
 //go:generate sh -c "schema-generate -p gen ../../../schema/collection.schema.json  | sed 's/Id/ID/g' > ./gen/collection.go"
 
-// Collection represents a Postman Collection.
+// Collection represents a Postman Collection.  Certificates and Proxy are
+// collection-wide defaults for requests that don't set their own.  Fork is
+// set on forked collections.  Fields the SDK doesn't model are kept in
+// Extras.  SchemaVersion is the format version declared by the collection's
+// schema URL, which is canonicalized when the collection is decoded.
 type Collection struct {
 	*gen.Collection
-	Items *ItemTree
+	Items         *ItemTree
+	Certificates  []Certificate
+	Proxy         *ProxyConfig
+	Fork          *Fork
+	Extras        Extras
+	SchemaVersion CollectionSchemaVersion
 }
 
-// UnmarshalJSON converts JSON to a struct.
+// UnmarshalJSON converts JSON to a struct.  Auth blocks written in legacy
+// forms, such as the attribute maps of collection format v2.0.0, are
+// upgraded to the current structured form.
 func (c *Collection) UnmarshalJSON(b []byte) error {
+	if bytes.Contains(b, []byte(`"auth"`)) {
+		if upgraded, ok := upgradeLegacyAuthJSON(b); ok {
+			b = upgraded
+		}
+	}
+
 	var genC gen.Collection
 	if err := json.Unmarshal(b, &genC); err != nil {
 		return err
 	}
 
+	var settings struct {
+		Certificates []Certificate `json:"certificates"`
+		Proxy        *ProxyConfig  `json:"proxy"`
+		Fork         *Fork         `json:"fork"`
+		Info         struct {
+			Fork *Fork `json:"fork"`
+		} `json:"info"`
+	}
+	if err := json.Unmarshal(b, &settings); err != nil {
+		return err
+	}
+
+	extras, err := decodeExtras(b, collectionFields)
+	if err != nil {
+		return err
+	}
+
+	c.SchemaVersion = CollectionSchemaUnknown
+	if genC.Info != nil {
+		genC.Info.Schema, c.SchemaVersion = NormalizeCollectionSchema(genC.Info.Schema)
+	}
+
 	c.Collection = &genC
+	c.Extras = extras
+	c.Certificates = settings.Certificates
+	c.Proxy = settings.Proxy
+	c.Fork = settings.Info.Fork
+	if c.Fork == nil {
+		c.Fork = settings.Fork
+	}
+
+	return c.refreshItems()
+}
+
+// MarshalJSON converts the collection to JSON, including the collection-wide
+// certificate and proxy settings and the fork metadata, which is written to
+// the collection info.
+func (c Collection) MarshalJSON() ([]byte, error) {
+	genC := c.Collection
+	if genC == nil {
+		genC = &gen.Collection{}
+	}
+
+	b, err := genC.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.Certificates) == 0 && c.Proxy == nil && c.Fork == nil {
+		return encodeExtras(b, c.Extras)
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	if len(c.Certificates) > 0 {
+		if m["certificates"], err = json.Marshal(c.Certificates); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Proxy != nil {
+		if m["proxy"], err = json.Marshal(c.Proxy); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Fork != nil {
+		info := make(map[string]json.RawMessage)
+		if raw, ok := m["info"]; ok && string(raw) != "null" {
+			if err := json.Unmarshal(raw, &info); err != nil {
+				return nil, err
+			}
+		}
+
+		if info["fork"], err = json.Marshal(c.Fork); err != nil {
+			return nil, err
+		}
+
+		if m["info"], err = json.Marshal(info); err != nil {
+			return nil, err
+		}
+	}
+
+	for k, v := range c.Extras {
+		if _, ok := m[k]; !ok {
+			m[k] = v
+		}
+	}
+
+	return json.Marshal(m)
+}
+
+// refreshItems rebuilds the item tree from the raw collection items.  It
+// must be called after the raw items are modified.
+func (c *Collection) refreshItems() error {
 	node := ItemTreeNode{}
 	if err := populateItemGroup(&node, c.Collection.Item); err != nil {
 		return err
@@ -51,6 +169,328 @@ func (c *Collection) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// clone returns a deep copy of the collection.
+func (c *Collection) clone() *Collection {
+	if c.Collection == nil {
+		return &Collection{Items: NewItemTree()}
+	}
+
+	src := *c.Collection
+	missingInfo := src.Info == nil
+	if missingInfo {
+		src.Info = &gen.Info{}
+	}
+
+	full := Collection{Collection: &src, Certificates: c.Certificates, Proxy: c.Proxy, Fork: c.Fork, Extras: c.Extras}
+
+	var dup Collection
+	b, err := json.Marshal(full)
+	if err == nil {
+		err = json.Unmarshal(b, &dup)
+	}
+
+	if err != nil {
+		// Collections decoded from JSON always round trip; fall back to a
+		// shallow copy for anything else.
+		dup = Collection{Collection: &src, Items: c.Items, Certificates: c.Certificates, Proxy: c.Proxy, Fork: c.Fork, Extras: c.Extras, SchemaVersion: c.SchemaVersion}
+	}
+
+	if missingInfo {
+		dup.Info = nil
+	}
+
+	return &dup
+}
+
+// Duplicate returns an independent copy of the collection named name, with
+// the IDs of the collection, its folders, requests, events, and examples
+// cleared so the Postman API assigns new ones.  The copy is not a fork, so
+// its fork metadata is cleared too.  The receiver is not modified.
+func (c *Collection) Duplicate(name string) *Collection {
+	dup := c.clone()
+	if dup.Collection == nil {
+		return dup
+	}
+
+	if dup.Info != nil {
+		dup.Info.PostmanID = ""
+		dup.Info.Name = name
+	}
+	dup.Fork = nil
+
+	for _, e := range dup.Event {
+		stripEventID(e)
+	}
+
+	stripRawItemIDs(dup.Item)
+
+	// Only ID keys were removed from items that decoded before.
+	_ = dup.refreshItems()
+
+	return dup
+}
+
+// AddRawItem appends the request or folder described by the raw item JSON
+// to the folder at parentPath, given as folder names from the root of the
+// collection.  An empty parentPath adds the item at the root.
+func (c *Collection) AddRawItem(parentPath []string, raw []byte) error {
+	var item map[string]interface{}
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return fmt.Errorf("invalid item JSON: %w", err)
+	}
+
+	if err := validateRawItem(item); err != nil {
+		return err
+	}
+
+	if c.Collection == nil {
+		c.Collection = &gen.Collection{}
+	}
+
+	items, err := insertRawItem(c.Item, parentPath, parentPath, item)
+	if err != nil {
+		return err
+	}
+
+	c.Item = items
+
+	return c.refreshItems()
+}
+
+// Reorder orders the children of the folder at parentPath, given as folder
+// names from the root of the collection, to match orderedItemIDs.  Children
+// that are not listed keep their relative order after the listed ones.  An
+// ID that matches no child, or is listed more than once, is an error and
+// leaves the collection unchanged.
+func (c *Collection) Reorder(parentPath []string, orderedItemIDs []string) error {
+	if c.Collection == nil {
+		c.Collection = &gen.Collection{}
+	}
+
+	items := c.Item
+	for i, name := range parentPath {
+		folder := findRawItem(items, name)
+		sub, ok := folder["item"].([]interface{})
+		if !ok {
+			return fmt.Errorf("folder %q not found", strings.Join(parentPath[:i+1], "/"))
+		}
+		items = sub
+	}
+
+	byID := make(map[string]interface{}, len(items))
+	for _, v := range items {
+		if m, ok := v.(map[string]interface{}); ok {
+			if id, ok := m["id"].(string); ok && id != "" {
+				byID[id] = v
+			}
+		}
+	}
+
+	ordered := make([]interface{}, 0, len(items))
+	listed := make(map[string]bool, len(orderedItemIDs))
+	for _, id := range orderedItemIDs {
+		v, ok := byID[id]
+		if !ok {
+			return fmt.Errorf("item %q not found in folder %q", id, strings.Join(parentPath, "/"))
+		}
+
+		if listed[id] {
+			return fmt.Errorf("item %q is listed more than once", id)
+		}
+
+		listed[id] = true
+		ordered = append(ordered, v)
+	}
+
+	for _, v := range items {
+		if m, ok := v.(map[string]interface{}); ok {
+			if id, _ := m["id"].(string); listed[id] {
+				continue
+			}
+		}
+		ordered = append(ordered, v)
+	}
+
+	// The folder shares the backing array of items, so it is reordered in
+	// place.
+	copy(items, ordered)
+
+	return c.refreshItems()
+}
+
+// validateRawItem checks that a raw item is a request or a folder of valid
+// items.
+func validateRawItem(item map[string]interface{}) error {
+	if item == nil {
+		return errors.New("invalid item JSON: expected an object")
+	}
+
+	if name, ok := item["name"]; ok {
+		if _, ok := name.(string); !ok {
+			return errors.New("invalid item: name must be a string")
+		}
+	}
+
+	if sub, ok := item["item"]; ok {
+		children, ok := sub.([]interface{})
+		if !ok {
+			return errors.New("invalid folder: item must be an array")
+		}
+
+		for _, child := range children {
+			m, _ := child.(map[string]interface{})
+			if err := validateRawItem(m); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	switch item["request"].(type) {
+	case string, map[string]interface{}:
+		return nil
+	}
+
+	return errors.New("invalid item: a request or an item array is required")
+}
+
+// PruneEmptyFolders removes the folders that contain no requests, including
+// folders left empty once their empty subfolders are removed, and returns
+// how many folders were removed.
+func (c *Collection) PruneEmptyFolders() int {
+	if c.Collection == nil {
+		return 0
+	}
+
+	items, removed := pruneRawFolders(c.Item)
+	if removed == 0 {
+		return 0
+	}
+	c.Item = items
+
+	// Only folders were removed from items that decoded before.
+	_ = c.refreshItems()
+
+	return removed
+}
+
+func pruneRawFolders(items []interface{}) ([]interface{}, int) {
+	removed := 0
+	kept := items[:0]
+	for _, v := range items {
+		if m, ok := v.(map[string]interface{}); ok {
+			if sub, ok := m["item"].([]interface{}); ok {
+				sub, n := pruneRawFolders(sub)
+				removed += n
+				m["item"] = sub
+
+				if len(sub) == 0 {
+					removed++
+					continue
+				}
+			}
+		}
+
+		kept = append(kept, v)
+	}
+
+	return kept, removed
+}
+
+// insertRawItem returns items with item appended to the folder at path.
+func insertRawItem(items []interface{}, path, fullPath []string, item map[string]interface{}) ([]interface{}, error) {
+	if len(path) == 0 {
+		return append(items, item), nil
+	}
+
+	for _, v := range items {
+		folder, ok := v.(map[string]interface{})
+		if !ok || folder["name"] != path[0] {
+			continue
+		}
+
+		sub, ok := folder["item"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		sub, err := insertRawItem(sub, path[1:], fullPath, item)
+		if err != nil {
+			return nil, err
+		}
+		folder["item"] = sub
+
+		return items, nil
+	}
+
+	missing := fullPath[:len(fullPath)-len(path)+1]
+	return nil, fmt.Errorf("folder %q not found", strings.Join(missing, "/"))
+}
+
+func stripEventID(e *gen.Event) {
+	if e == nil {
+		return
+	}
+
+	e.ID = ""
+	if e.Script != nil {
+		e.Script.ID = ""
+	}
+}
+
+func stripRawItemIDs(items []interface{}) {
+	for _, v := range items {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		deleteRawIDs(m)
+
+		for _, key := range []string{"event", "response"} {
+			if list, ok := m[key].([]interface{}); ok {
+				for _, e := range list {
+					if em, ok := e.(map[string]interface{}); ok {
+						deleteRawIDs(em)
+						if script, ok := em["script"].(map[string]interface{}); ok {
+							deleteRawIDs(script)
+						}
+					}
+				}
+			}
+		}
+
+		if children, ok := m["item"].([]interface{}); ok {
+			stripRawItemIDs(children)
+		}
+	}
+}
+
+func deleteRawIDs(m map[string]interface{}) {
+	delete(m, "id")
+	delete(m, "uid")
+	delete(m, "_postman_id")
+}
+
+// forEachRawItem calls fn for every raw request item in items, recursing
+// into folders.
+func forEachRawItem(items []interface{}, fn func(item map[string]interface{})) {
+	for _, v := range items {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if children, ok := m["item"].([]interface{}); ok {
+			forEachRawItem(children, fn)
+			continue
+		}
+
+		fn(m)
+	}
+}
+
 // CollectionListResponse is the top-level struct representation of a collection
 // list response in the Postman API.
 type CollectionListResponse struct {
@@ -71,12 +511,61 @@ func (r CollectionListItems) Format() ([]string, []interface{}) {
 }
 
 // CollectionListItem represents a single item in a CollectionListResponse.
+// Shared is set on collections shared with the user from other workspaces,
+// along with the name of their owner and their workspace.
 type CollectionListItem struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Owner     string    `json:"owner"`
+	UID       string    `json:"uid"`
+	Fork      *Fork     `json:"fork,omitempty"`
+	Shared    bool      `json:"shared,omitempty"`
+	OwnerName string    `json:"ownerName,omitempty"`
+	Workspace string    `json:"workspace,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SharedCollectionListResponse is the top-level struct representation of a
+// shared collection list response in the Postman API.
+type SharedCollectionListResponse struct {
+	Data []SharedCollection `json:"data"`
+}
+
+// SharedCollection represents a single item in a
+// SharedCollectionListResponse.
+type SharedCollection struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
-	Owner string `json:"owner"`
 	UID   string `json:"uid"`
-	Fork  *Fork  `json:"fork,omitempty"`
+	Owner struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"owner"`
+	Workspace struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"workspace"`
+}
+
+// ListItem converts the shared collection into a CollectionListItem tagged
+// with its owner.
+func (c SharedCollection) ListItem() CollectionListItem {
+	return CollectionListItem{
+		ID:        c.ID,
+		Name:      c.Name,
+		Owner:     c.Owner.ID,
+		UID:       c.UID,
+		Shared:    true,
+		OwnerName: c.Owner.Name,
+		Workspace: c.Workspace.ID,
+	}
+}
+
+// ForkInfo returns the metadata of the collection it was forked from, and
+// whether the collection is a fork.
+func (c *Collection) ForkInfo() (*Fork, bool) {
+	return c.Fork, c.Fork != nil
 }
 
 // Fork represents fork metadata for a collection.
@@ -113,6 +602,28 @@ func (r CollectionSlice) Format() ([]string, []interface{}) {
 	return []string{"PostmanID", "Name"}, s
 }
 
+// CollectionItemResponse is the top-level struct representation of a
+// response to adding or removing a single collection item.
+type CollectionItemResponse struct {
+	Data CollectionItemData `json:"data"`
+	Meta CollectionItemMeta `json:"meta"`
+}
+
+// CollectionItemData identifies the collection item affected by a change.
+type CollectionItemData struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Owner      string `json:"owner"`
+	Collection string `json:"collection"`
+	Folder     string `json:"folder"`
+}
+
+// CollectionItemMeta describes the change made to a collection item.
+type CollectionItemMeta struct {
+	Model  string `json:"model"`
+	Action string `json:"action"`
+}
+
 // Item represents an item (request) in a Collection.
 type Item struct {
 	*gen.Item
@@ -138,13 +649,18 @@ func (item *Item) UnmarshalJSON(b []byte) error {
 	}
 
 	item.Item = &genItem
-	item.Events = make([]Event, len(item.Item.Event))
+	item.Events = wrapEvents(item.Item.Event)
+
+	return nil
+}
 
-	for i, genEvent := range item.Item.Event {
-		item.Events[i] = Event{Event: genEvent}
+func wrapEvents(events []*gen.Event) []Event {
+	ret := make([]Event, len(events))
+	for i, genEvent := range events {
+		ret[i] = Event{Event: genEvent}
 	}
 
-	return nil
+	return ret
 }
 
 func populateItemGroup(b *ItemTreeNode, item []interface{}) error {
@@ -171,6 +687,7 @@ func populateItemGroup(b *ItemTreeNode, item []interface{}) error {
 
 				branch.MakeGroup(ItemGroup{
 					ItemGroup: &ig,
+					Events:    wrapEvents(ig.Event),
 				})
 			}
 
@@ -180,7 +697,8 @@ func populateItemGroup(b *ItemTreeNode, item []interface{}) error {
 
 			b.AddBranch(branch)
 		} else {
-			it, err := populateItem(m["name"].(string), m)
+			name, _ := m["name"].(string)
+			it, err := populateItem(name, m)
 
 			if err != nil {
 				return err
@@ -0,0 +1,263 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+const rawItemSubject = `{
+  "info": {"name": "raw", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "item": [
+    {"name": "Users", "item": [{"name": "List Users", "request": "https://example.com/users"}]}
+  ]
+}`
+
+func TestAddRawItemRequest(t *testing.T) {
+	c := unmarshalCollection(t, rawItemSubject)
+
+	raw := `{"name": "Get User", "request": {"method": "GET", "url": "https://example.com/users/1"}}`
+	if err := c.AddRawItem([]string{"Users"}, []byte(raw)); err != nil {
+		t.Fatal(err)
+	}
+
+	var have []string
+	for _, r := range c.Flatten() {
+		have = append(have, strings.Join(r.Path(), "/"))
+	}
+
+	want := "Users/List Users,Users/Get User"
+	if strings.Join(have, ",") != want {
+		t.Errorf("Requests are incorrect, have: %s, want: %s", strings.Join(have, ","), want)
+	}
+}
+
+func TestAddRawItemFolder(t *testing.T) {
+	c := unmarshalCollection(t, rawItemSubject)
+
+	raw := `{"name": "Orders", "item": [
+		{"name": "List Orders", "request": "https://example.com/orders"},
+		{"name": "Get Order", "request": "https://example.com/orders/1"}
+	]}`
+	if err := c.AddRawItem(nil, []byte(raw)); err != nil {
+		t.Fatal(err)
+	}
+
+	branches := *c.Items.Root.Branches
+	if len(branches) != 2 || branches[1].ItemGroup.Name != "Orders" || len(*branches[1].Items) != 2 {
+		t.Errorf("Folder was not added correctly, have %d folders", len(branches))
+	}
+
+	if len(c.Item) != 2 {
+		t.Errorf("Incorrect number of raw items, have: %d, want: %d", len(c.Item), 2)
+	}
+}
+
+func TestAddRawItemErrors(t *testing.T) {
+	c := unmarshalCollection(t, rawItemSubject)
+
+	tests := []struct {
+		path []string
+		raw  string
+		want string
+	}{
+		{nil, `{"name": "Broken"`, "invalid item JSON"},
+		{nil, `{"name": "Nothing"}`, "a request or an item array is required"},
+		{nil, `{"name": "Folder", "item": [42]}`, "expected an object"},
+		{[]string{"Users", "Missing"}, `{"request": "https://example.com"}`, `folder "Users/Missing" not found`},
+	}
+
+	for _, tt := range tests {
+		err := c.AddRawItem(tt.path, []byte(tt.raw))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Error is incorrect, have: %v, want: %s", err, tt.want)
+		}
+	}
+
+	if len(c.Flatten()) != 1 {
+		t.Errorf("Expected failed additions to leave the collection unchanged, have %d requests", len(c.Flatten()))
+	}
+}
+
+const reorderSubject = `{
+  "info": {"name": "reorder", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "item": [
+    {"name": "Users", "id": "f-1", "item": [
+      {"name": "List Users", "id": "r-1", "request": "https://example.com/users"},
+      {"name": "Get User", "id": "r-2", "request": "https://example.com/users/1"},
+      {"name": "Delete User", "id": "r-3", "request": "https://example.com/users/1"}
+    ]}
+  ]
+}`
+
+func requestNames(c *resources.Collection) string {
+	var names []string
+	for _, r := range c.Flatten() {
+		names = append(names, r.Item.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+func TestCollectionReorder(t *testing.T) {
+	c := unmarshalCollection(t, reorderSubject)
+
+	if err := c.Reorder([]string{"Users"}, []string{"r-3", "r-1", "r-2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := requestNames(c), "Delete User,List Users,Get User"; have != want {
+		t.Errorf("Order is incorrect, have: %s, want: %s", have, want)
+	}
+
+	if err := c.Reorder([]string{"Users"}, []string{"r-2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := requestNames(c), "Get User,Delete User,List Users"; have != want {
+		t.Errorf("Expected unlisted items to follow in order, have: %s, want: %s", have, want)
+	}
+}
+
+func TestCollectionReorderErrors(t *testing.T) {
+	c := unmarshalCollection(t, reorderSubject)
+
+	tests := []struct {
+		path []string
+		ids  []string
+		want string
+	}{
+		{[]string{"Users"}, []string{"r-3", "r-9"}, `item "r-9" not found`},
+		{[]string{"Users"}, []string{"r-1", "r-1"}, `item "r-1" is listed more than once`},
+		{[]string{"Orders"}, []string{"r-1"}, `folder "Orders" not found`},
+	}
+
+	for _, tt := range tests {
+		err := c.Reorder(tt.path, tt.ids)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Error is incorrect, have: %v, want: %s", err, tt.want)
+		}
+	}
+
+	if have, want := requestNames(c), "List Users,Get User,Delete User"; have != want {
+		t.Errorf("Expected failed reorders to leave the collection unchanged, have: %s, want: %s", have, want)
+	}
+}
+
+func TestCollectionForkInfo(t *testing.T) {
+	c := unmarshalCollection(t, `{
+		"info": {
+			"name": "forked",
+			"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json",
+			"fork": {"label": "my-fork", "createdAt": "2021-04-12T08:30:00.000Z", "from": "12345-abcdef"}
+		},
+		"item": []
+	}`)
+
+	fork, ok := c.ForkInfo()
+	if !ok {
+		t.Fatal("Expected the collection to be a fork")
+	}
+
+	if fork.Label != "my-fork" || fork.From != "12345-abcdef" || fork.CreatedAt.Format(time.RFC3339) != "2021-04-12T08:30:00Z" {
+		t.Errorf("Fork is incorrect, have: %+v", fork)
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rt := unmarshalCollection(t, string(data))
+	if fork, ok := rt.ForkInfo(); !ok || fork.Label != "my-fork" || !fork.CreatedAt.Equal(c.Fork.CreatedAt) {
+		t.Errorf("Fork did not round trip, have: %+v in %s", fork, data)
+	}
+
+	if rt.Info.Name != "forked" {
+		t.Errorf("Info did not round trip, have: %+v", rt.Info)
+	}
+
+	if _, ok := c.Duplicate("copy").ForkInfo(); ok {
+		t.Error("Expected a duplicate not to be a fork")
+	}
+}
+
+func TestCollectionForkInfoNotForked(t *testing.T) {
+	c := unmarshalCollection(t, rawItemSubject)
+
+	if fork, ok := c.ForkInfo(); ok || fork != nil {
+		t.Errorf("Expected the collection not to be a fork, have: %+v", fork)
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(data), "fork") {
+		t.Errorf("Expected no fork metadata, have: %s", data)
+	}
+}
+
+func TestCollectionPruneEmptyFolders(t *testing.T) {
+	c := unmarshalCollection(t, `{"info":{"name":"prune","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[
+		{"name":"Empty","item":[]},
+		{"name":"Nested","item":[
+			{"name":"Leaf","item":[]},
+			{"name":"Middle","item":[{"name":"Deepest","item":[]}]}
+		]},
+		{"name":"Users","item":[
+			{"name":"List","request":{"method":"GET","url":"https://example.com/users"}},
+			{"name":"Drafts","item":[]}
+		]},
+		{"name":"Health","request":{"method":"GET","url":"https://example.com/health"}}
+	]}`)
+
+	if removed := c.PruneEmptyFolders(); removed != 6 {
+		t.Errorf("Removed folders are incorrect, have: %d, want: %d", removed, 6)
+	}
+
+	c = roundTripCollection(t, c)
+
+	if have, want := requestNames(c), "List,Health"; have != want {
+		t.Errorf("Requests are incorrect, have: %s, want: %s", have, want)
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"Empty", "Nested", "Leaf", "Middle", "Deepest", "Drafts"} {
+		if strings.Contains(string(b), `"`+name+`"`) {
+			t.Errorf("Folder %s was not pruned, have: %s", name, b)
+		}
+	}
+
+	if !strings.Contains(string(b), `"Users"`) {
+		t.Errorf("Folder with requests was pruned, have: %s", b)
+	}
+
+	if removed := c.PruneEmptyFolders(); removed != 0 {
+		t.Errorf("Removed folders are incorrect, have: %d, want: %d", removed, 0)
+	}
+}
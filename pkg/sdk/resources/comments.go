@@ -0,0 +1,152 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"time"
+)
+
+// CommentTarget identifies the collection, folder, or request a comment
+// belongs to.
+type CommentTarget struct {
+	Type         ResourceType
+	CollectionID string
+	ID           string
+}
+
+// CollectionComments targets the comments on a collection.
+func CollectionComments(collectionID string) CommentTarget {
+	return CommentTarget{Type: CollectionType, CollectionID: collectionID, ID: collectionID}
+}
+
+// FolderComments targets the comments on a folder in a collection.
+func FolderComments(collectionID, folderID string) CommentTarget {
+	return CommentTarget{Type: FolderType, CollectionID: collectionID, ID: folderID}
+}
+
+// RequestComments targets the comments on a request in a collection.
+func RequestComments(collectionID, requestID string) CommentTarget {
+	return CommentTarget{Type: RequestType, CollectionID: collectionID, ID: requestID}
+}
+
+// Path returns the Postman API path segments of the target's comments.
+func (t CommentTarget) Path() ([]string, error) {
+	if t.CollectionID == "" {
+		return nil, fmt.Errorf("a collection ID is required for %s comments", t.Type)
+	}
+
+	switch t.Type {
+	case CollectionType:
+		return []string{"collections", t.CollectionID, "comments"}, nil
+	case FolderType, RequestType:
+		if t.ID == "" {
+			return nil, fmt.Errorf("a %s ID is required for %s comments", t.Type, t.Type)
+		}
+
+		segment := "folders"
+		if t.Type == RequestType {
+			segment = "requests"
+		}
+
+		return []string{"collections", t.CollectionID, segment, t.ID, "comments"}, nil
+	}
+
+	return nil, fmt.Errorf("comments are not supported on resource type %q", t.Type)
+}
+
+// CommentListResponse represents the top-level comments response from the
+// Postman API.
+type CommentListResponse struct {
+	Data CommentListItems `json:"data"`
+}
+
+// CommentListItems is a slice of Comment.
+type CommentListItems []Comment
+
+// Format returns column headers and values for the resource.
+func (r CommentListItems) Format() ([]string, []interface{}) {
+	s := make([]interface{}, len(r))
+	for i, v := range r {
+		s[i] = v
+	}
+
+	return []string{"ID", "ThreadID", "CreatedBy", "Body"}, s
+}
+
+// CommentList is a list of comments along with its pagination details.
+type CommentList struct {
+	Items CommentListItems
+	PageInfo
+}
+
+// Format returns column headers and values for the resource.
+func (r CommentList) Format() ([]string, []interface{}) {
+	return r.Items.Format()
+}
+
+// Threads groups the comments into threads in order of first appearance.
+func (r CommentListItems) Threads() []CommentThread {
+	var threads []CommentThread
+	index := make(map[int]int)
+	for _, c := range r {
+		i, ok := index[c.ThreadID]
+		if !ok {
+			i = len(threads)
+			index[c.ThreadID] = i
+			threads = append(threads, CommentThread{ID: c.ThreadID})
+		}
+
+		threads[i].Comments = append(threads[i].Comments, c)
+		if c.Resolved {
+			threads[i].Resolved = true
+		}
+	}
+
+	return threads
+}
+
+// Comment represents a single comment on a collection, folder, or request.
+type Comment struct {
+	ID        int       `json:"id"`
+	ThreadID  int       `json:"threadId"`
+	CreatedBy int       `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Body      string    `json:"body"`
+	Resolved  bool      `json:"resolved"`
+}
+
+// CommentThread is a comment and its replies.
+type CommentThread struct {
+	ID       int
+	Resolved bool
+	Comments []Comment
+}
+
+// CommentResponse represents the top-level single comment response from the
+// Postman API.
+type CommentResponse struct {
+	Data Comment `json:"data"`
+}
+
+// NewComment is the request body for posting a comment.  A new thread is
+// started when ThreadID is zero.
+type NewComment struct {
+	Body     string `json:"body"`
+	ThreadID int    `json:"threadId,omitempty"`
+}
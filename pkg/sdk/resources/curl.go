@@ -0,0 +1,128 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// ToCurlOptions controls the rendering of curl commands.  Auth, when set,
+// authenticates every request in place of the auth stored in the
+// collection.
+type ToCurlOptions struct {
+	Auth AuthProvider
+}
+
+// ToCurl returns a curl command equivalent to the request with variables
+// resolved from the given scopes.
+func (r *Request) ToCurl(scopes ...VariableScope) (string, error) {
+	return r.ToCurlWithOptions(ToCurlOptions{}, scopes...)
+}
+
+// ToCurlWithOptions is like ToCurl, rendering the command with the given
+// options.
+func (r *Request) ToCurlWithOptions(options ToCurlOptions, scopes ...VariableScope) (string, error) {
+	req, err := r.httpRequest(context.Background(), options.Auth, scopes...)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := "curl "
+	if req.Method != "GET" {
+		cmd += "-X " + req.Method + " "
+	}
+	args := []string{cmd + shellQuote(req.URL.String())}
+
+	keys := make([]string, 0, len(req.Header))
+	for k := range req.Header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		for _, v := range req.Header[k] {
+			args = append(args, "-H "+shellQuote(k+": "+v))
+		}
+	}
+
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, "--data-raw "+shellQuote(string(body)))
+	}
+
+	return strings.Join(args, " \\\n  "), nil
+}
+
+// ToCurl returns a script of curl commands, one per request, each preceded
+// by a comment with its folder path.  Variables are resolved from the scopes
+// given by ItemScopes for each request, and requests without
+// auth of their own use that of their folder or the collection.
+func (c *Collection) ToCurl(scopes ...VariableScope) (string, error) {
+	return c.ToCurlWithOptions(ToCurlOptions{}, scopes...)
+}
+
+// ToCurlWithOptions is like ToCurl, rendering the commands with the given
+// options.
+func (c *Collection) ToCurlWithOptions(options ToCurlOptions, scopes ...VariableScope) (string, error) {
+	if c.Items == nil {
+		return "", nil
+	}
+
+	var (
+		commands []string
+		err      error
+	)
+
+	walkItemTree(&c.Items.Root, nil, func(path []string, item Item) {
+		if err != nil || item.Item == nil || item.Item.Request == nil {
+			return
+		}
+
+		var r *Request
+		if r, err = ParseRequest(item.Item.Request); err != nil {
+			return
+		}
+
+		if err = c.inheritAuth(r, path); err != nil {
+			return
+		}
+
+		var cmd string
+		if cmd, err = r.ToCurlWithOptions(options, c.requestScopes(path, scopes)...); err != nil {
+			return
+		}
+
+		commands = append(commands, "# "+strings.Join(path, " / ")+"\n"+cmd)
+	})
+
+	if err != nil || len(commands) == 0 {
+		return "", err
+	}
+
+	return strings.Join(commands, "\n\n") + "\n", nil
+}
+
+// shellQuote quotes s for use as a single POSIX shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
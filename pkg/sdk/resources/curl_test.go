@@ -0,0 +1,171 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestRequestToCurlGetWithHeaders(t *testing.T) {
+	req := &resources.Request{
+		Method: "GET",
+		URL:    resources.ParseURL("{{baseUrl}}/users?name=O'Brien"),
+		Header: []resources.Header{
+			{Key: "Accept", Value: "application/json"},
+			{Key: "X-Trace", Value: "{{trace}}"},
+		},
+	}
+
+	have, err := req.ToCurl(resources.VariableScope{"baseUrl": "https://api.example.com", "trace": "abc 123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `curl 'https://api.example.com/users?name=O'\''Brien' \
+  -H 'Accept: application/json' \
+  -H 'X-Trace: abc 123'`
+
+	if have != want {
+		t.Errorf("Curl command is incorrect, have:\n%s\nwant:\n%s", have, want)
+	}
+}
+
+func TestRequestToCurlPostWithJSONBody(t *testing.T) {
+	req := &resources.Request{
+		Method: "POST",
+		URL:    resources.ParseURL("https://api.example.com/users"),
+		Header: []resources.Header{{Key: "Content-Type", Value: "application/json"}},
+		Body:   &resources.Body{Mode: resources.BodyModeRaw, Raw: `{"name":"{{name}}"}`},
+	}
+
+	have, err := req.ToCurl(resources.VariableScope{"name": "ada"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `curl -X POST 'https://api.example.com/users' \
+  -H 'Content-Type: application/json' \
+  --data-raw '{"name":"ada"}'`
+
+	if have != want {
+		t.Errorf("Curl command is incorrect, have:\n%s\nwant:\n%s", have, want)
+	}
+}
+
+func TestCollectionToCurl(t *testing.T) {
+	c := unmarshalCollection(t, `{
+		"info": {"name": "curl", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"variable": [{"key": "baseUrl", "value": "https://api.example.com"}],
+		"item": [
+			{"name": "Users", "item": [{"name": "List Users", "request": {"method": "GET", "url": "{{baseUrl}}/users"}}]}
+		]
+	}`)
+
+	have, err := c.ToCurl()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "# Users / List Users\ncurl 'https://api.example.com/users'\n"
+	if have != want {
+		t.Errorf("Curl script is incorrect, have:\n%s\nwant:\n%s", have, want)
+	}
+}
+
+func TestCollectionToCurlWithRuntimeAuth(t *testing.T) {
+	c := unmarshalCollection(t, `{
+		"info": {"name": "curl", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"item": [
+			{"name": "Me", "request": {
+				"method": "GET",
+				"url": "https://api.example.com/me",
+				"auth": {"type": "bearer", "bearer": [{"key": "token", "value": "stored"}]}
+			}}
+		]
+	}`)
+
+	before, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := c.ToCurlWithOptions(resources.ToCurlOptions{Auth: resources.APIKeyAuth{Key: "api_key", Value: "secret", InQuery: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "# Me\ncurl 'https://api.example.com/me?api_key=secret'\n"
+	if have != want {
+		t.Errorf("Curl script is incorrect, have:\n%s\nwant:\n%s", have, want)
+	}
+
+	after, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(before) != string(after) {
+		t.Errorf("Collection was modified, have:\n%s\nwant:\n%s", after, before)
+	}
+}
+
+func TestCollectionToCurlInheritsFolderAuth(t *testing.T) {
+	c := unmarshalCollection(t, `{
+		"info": {"name": "curl", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"item": [
+			{"name": "Admin", "auth": {"type": "bearer", "bearer": [{"key": "token", "value": "folder"}]}, "item": [
+				{"name": "Me", "request": {"method": "GET", "url": "https://api.example.com/me"}}
+			]}
+		]
+	}`)
+
+	have, err := c.ToCurl()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "# Admin / Me\ncurl 'https://api.example.com/me' \\\n  -H 'Authorization: Bearer folder'\n"
+	if have != want {
+		t.Errorf("Curl script is incorrect, have:\n%s\nwant:\n%s", have, want)
+	}
+}
+
+func TestCollectionToCurlUsesFolderVariables(t *testing.T) {
+	c := unmarshalCollection(t, `{
+		"info": {"name": "curl", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"variable": [{"key": "baseUrl", "value": "https://api.example.com"}],
+		"item": [
+			{"name": "Admin", "variable": [{"key": "baseUrl", "value": "https://admin.example.com"}], "item": [
+				{"name": "Stats", "request": {"method": "GET", "url": "{{baseUrl}}/stats?user={{user}}"}}
+			]},
+			{"name": "Health", "request": {"method": "GET", "url": "{{baseUrl}}/health"}}
+		]
+	}`)
+
+	have, err := c.ToCurl(resources.VariableScope{"baseUrl": "https://env.example.com", "user": "ada"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "# Admin / Stats\ncurl 'https://admin.example.com/stats?user=ada'\n\n# Health\ncurl 'https://api.example.com/health'\n"
+	if have != want {
+		t.Errorf("Curl script is incorrect, have:\n%s\nwant:\n%s", have, want)
+	}
+}
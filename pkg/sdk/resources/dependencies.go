@@ -0,0 +1,107 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+// DependencyKind is the kind of resource in a dependency graph.
+type DependencyKind string
+
+// Kinds of resources in a dependency graph.
+const (
+	DependencyCollection  DependencyKind = "collection"
+	DependencyEnvironment DependencyKind = "environment"
+	DependencyMock        DependencyKind = "mock"
+	DependencyMonitor     DependencyKind = "monitor"
+)
+
+// DependencyNode is a resource in a dependency graph.
+type DependencyNode struct {
+	Kind DependencyKind `json:"kind"`
+	UID  string         `json:"uid"`
+	Name string         `json:"name"`
+}
+
+// DependencyEdge records that the resource From depends on the resource
+// To, both given by UID.
+type DependencyEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// DependencyGraph is the graph of resources that depend on a collection:
+// the mocks and monitors using it and the environments they use.
+type DependencyGraph struct {
+	Nodes []DependencyNode `json:"nodes"`
+	Edges []DependencyEdge `json:"edges"`
+}
+
+// Format returns column headers and values for the resource.
+func (g DependencyGraph) Format() ([]string, []interface{}) {
+	s := make([]interface{}, len(g.Nodes))
+	for i, v := range g.Nodes {
+		s[i] = v
+	}
+
+	return []string{"Kind", "UID", "Name"}, s
+}
+
+// AddNode adds a node to the graph unless one with the same UID exists.
+func (g *DependencyGraph) AddNode(node DependencyNode) {
+	if _, ok := g.Node(node.UID); ok {
+		return
+	}
+
+	g.Nodes = append(g.Nodes, node)
+}
+
+// AddEdge records that from depends on to unless the edge exists.
+func (g *DependencyGraph) AddEdge(from, to string) {
+	for _, e := range g.Edges {
+		if e.From == from && e.To == to {
+			return
+		}
+	}
+
+	g.Edges = append(g.Edges, DependencyEdge{From: from, To: to})
+}
+
+// Node returns the node with the given UID.
+func (g DependencyGraph) Node(uid string) (DependencyNode, bool) {
+	for _, n := range g.Nodes {
+		if n.UID == uid {
+			return n, true
+		}
+	}
+
+	return DependencyNode{}, false
+}
+
+// Dependents returns the nodes that depend directly on the node with the
+// given UID, in the order they were added.
+func (g DependencyGraph) Dependents(uid string) []DependencyNode {
+	var ret []DependencyNode
+	for _, e := range g.Edges {
+		if e.To != uid {
+			continue
+		}
+
+		if n, ok := g.Node(e.From); ok {
+			ret = append(ret, n)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,73 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import "github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+
+// DescriptionTypeMarkdown is the content type of markdown descriptions.
+const DescriptionTypeMarkdown = "text/markdown"
+
+// Description returns the markdown description of the collection, which
+// Postman stores either as a string or as an object with content and type.
+func (c *Collection) Description() string {
+	if c.Collection == nil || c.Info == nil {
+		return ""
+	}
+
+	return descriptionText(c.Info.Description)
+}
+
+// SetDescription sets the markdown description of the collection, always
+// stored in the object form.  Other fields of an existing description
+// object, such as its version, are kept.  An empty description removes it.
+func (c *Collection) SetDescription(md string) {
+	if c.Collection == nil {
+		c.Collection = &gen.Collection{}
+	}
+
+	if c.Info == nil {
+		c.Info = &gen.Info{}
+	}
+
+	if md == "" {
+		c.Info.Description = nil
+		return
+	}
+
+	d, ok := c.Info.Description.(map[string]interface{})
+	if !ok {
+		d = map[string]interface{}{}
+	}
+	d["content"] = md
+	d["type"] = DescriptionTypeMarkdown
+
+	c.Info.Description = d
+}
+
+// descriptionText returns the text of a description in either its string or
+// object form.
+func descriptionText(d interface{}) string {
+	switch v := d.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		s, _ := v["content"].(string)
+		return s
+	}
+
+	return ""
+}
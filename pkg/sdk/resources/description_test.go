@@ -0,0 +1,79 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestCollectionDescriptionString(t *testing.T) {
+	c := unmarshalCollection(t, `{"info":{"name":"docs","description":"# Users API\n\nManage *users*.","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[]}`)
+
+	if have, want := c.Description(), "# Users API\n\nManage *users*."; have != want {
+		t.Errorf("Description is incorrect, have: %q, want: %q", have, want)
+	}
+
+	c.SetDescription("# Users API v2")
+	rt := roundTripCollection(t, c)
+
+	if have, want := rt.Description(), "# Users API v2"; have != want {
+		t.Errorf("Description is incorrect, have: %q, want: %q", have, want)
+	}
+
+	d, ok := rt.Info.Description.(map[string]interface{})
+	if !ok || d["type"] != "text/markdown" {
+		t.Errorf("Expected the object form, have: %#v", rt.Info.Description)
+	}
+}
+
+func TestCollectionDescriptionObject(t *testing.T) {
+	c := unmarshalCollection(t, `{"info":{"name":"docs","description":{"content":"Manage **users**.","type":"text/markdown","version":"1.0.0"},"schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[]}`)
+
+	if have, want := c.Description(), "Manage **users**."; have != want {
+		t.Errorf("Description is incorrect, have: %q, want: %q", have, want)
+	}
+
+	c.SetDescription("Manage **accounts**.")
+	rt := roundTripCollection(t, c)
+
+	if have, want := rt.Description(), "Manage **accounts**."; have != want {
+		t.Errorf("Description is incorrect, have: %q, want: %q", have, want)
+	}
+
+	if d, _ := rt.Info.Description.(map[string]interface{}); d["version"] != "1.0.0" {
+		t.Errorf("Expected the description version to be kept, have: %#v", rt.Info.Description)
+	}
+
+	c.SetDescription("")
+	if rt := roundTripCollection(t, c); rt.Info.Description != nil || rt.Description() != "" {
+		t.Errorf("Expected the description to be removed, have: %#v", rt.Info.Description)
+	}
+}
+
+func roundTripCollection(t *testing.T, c *resources.Collection) *resources.Collection {
+	t.Helper()
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return unmarshalCollection(t, string(data))
+}
@@ -16,6 +16,15 @@ limitations under the License.
 
 package resources
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // EnvironmentListResponse represents the top-level environments response from the
 // Postman API.
 type EnvironmentListResponse struct {
@@ -43,6 +52,26 @@ type EnvironmentListItem struct {
 	UID   string `json:"uid"`
 }
 
+// TaggedEnvironmentListItems is a slice of TaggedEnvironmentListItem.
+type TaggedEnvironmentListItems []TaggedEnvironmentListItem
+
+// Format returns column headers and values for the resource.
+func (r TaggedEnvironmentListItems) Format() ([]string, []interface{}) {
+	s := make([]interface{}, len(r))
+	for i, v := range r {
+		s[i] = v
+	}
+
+	return []string{"UID", "Name", "WorkspaceID"}, s
+}
+
+// TaggedEnvironmentListItem is an EnvironmentListItem tagged with the
+// workspace it was listed in.
+type TaggedEnvironmentListItem struct {
+	EnvironmentListItem
+	WorkspaceID string `json:"workspaceId"`
+}
+
 // EnvironmentResponse is the top-level environment response from the
 // Postman API.
 type EnvironmentResponse struct {
@@ -50,11 +79,12 @@ type EnvironmentResponse struct {
 }
 
 // Environment represents the single environment response from the
-// Postman API
+// Postman API.  Fields the SDK doesn't model are kept in Extras.
 type Environment struct {
 	ID     string         `json:"id"`
 	Name   string         `json:"name"`
 	Values []KeyValuePair `json:"values"`
+	Extras Extras         `json:"-"`
 }
 
 // Format returns column headers and values for the resource.
@@ -78,9 +108,207 @@ func (r EnvironmentSlice) Format() ([]string, []interface{}) {
 	return []string{"ID", "Name"}, s
 }
 
+// Validate checks the environment's variable keys for problems Postman
+// doesn't report: empty keys, keys containing template braces, and
+// duplicate keys, which silently override earlier variables.  Findings are
+// located by the index of the variable.  Validate is not called by updates,
+// callers opt in before making one.
+func (r *Environment) Validate() []LintFinding {
+	var findings []LintFinding
+
+	first := make(map[string]int, len(r.Values))
+	for i, v := range r.Values {
+		path := []string{strconv.Itoa(i)}
+
+		switch {
+		case strings.TrimSpace(v.Key) == "":
+			findings = append(findings, LintFinding{
+				RuleID:   "variable-empty-key",
+				Severity: LintError,
+				Path:     path,
+				Message:  "variable key is empty",
+			})
+			continue
+		case strings.ContainsAny(v.Key, "{}"):
+			findings = append(findings, LintFinding{
+				RuleID:   "variable-key-braces",
+				Severity: LintError,
+				Path:     path,
+				Message:  fmt.Sprintf("variable key %q contains template braces", v.Key),
+			})
+		}
+
+		if j, ok := first[v.Key]; ok {
+			findings = append(findings, LintFinding{
+				RuleID:   "variable-duplicate-key",
+				Severity: LintError,
+				Path:     path,
+				Message:  fmt.Sprintf("variable key %q duplicates variable %d and overrides it", v.Key, j),
+			})
+			continue
+		}
+		first[v.Key] = i
+	}
+
+	return findings
+}
+
+// EnvironmentVariableScope is the variable scope marker of standalone
+// environment files.
+const EnvironmentVariableScope = "environment"
+
+// exportedEnvironment is the standalone environment file format of the
+// Postman app.
+type exportedEnvironment struct {
+	ID            string         `json:"id,omitempty"`
+	Name          string         `json:"name"`
+	Values        []exportedPair `json:"values"`
+	Scope         string         `json:"_postman_variable_scope"`
+	ExportedAt    string         `json:"_postman_exported_at,omitempty"`
+	ExportedUsing string         `json:"_postman_exported_using,omitempty"`
+}
+
+type exportedPair struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+// ExportJSON returns the environment in the standalone environment file
+// format, which the Postman app imports directly.
+func (r *Environment) ExportJSON() ([]byte, error) {
+	export := exportedEnvironment{
+		ID:            r.ID,
+		Name:          r.Name,
+		Values:        make([]exportedPair, len(r.Values)),
+		Scope:         EnvironmentVariableScope,
+		ExportedAt:    time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
+		ExportedUsing: "postmanctl",
+	}
+
+	for i, v := range r.Values {
+		t := v.Type
+		if t == "" {
+			t = "default"
+		}
+		export.Values[i] = exportedPair{Key: v.Key, Value: v.Value, Type: t, Enabled: v.Enabled}
+	}
+
+	return json.MarshalIndent(export, "", "\t")
+}
+
+// EnvironmentFromJSON parses an environment from a standalone environment
+// file exported by the Postman app, or from the Postman API's shape, which
+// may be wrapped in an environment key and list its variables under
+// variable.  Variables that don't say whether they're enabled are.  Files
+// with a scope marker other than environment, such as exported globals, are
+// rejected.
+func EnvironmentFromJSON(data []byte) (*Environment, error) {
+	var wrapper struct {
+		Environment json.RawMessage `json:"environment"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, fmt.Errorf("invalid environment JSON: %w", err)
+	}
+
+	if len(wrapper.Environment) > 0 && string(wrapper.Environment) != "null" {
+		data = wrapper.Environment
+	}
+
+	var raw struct {
+		ID       string         `json:"id"`
+		Name     string         `json:"name"`
+		Values   []importedPair `json:"values"`
+		Variable []importedPair `json:"variable"`
+		Scope    *string        `json:"_postman_variable_scope"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid environment JSON: %w", err)
+	}
+
+	if raw.Scope != nil && *raw.Scope != EnvironmentVariableScope {
+		return nil, fmt.Errorf("file has variable scope %q, not %q", *raw.Scope, EnvironmentVariableScope)
+	}
+
+	pairs := raw.Values
+	if pairs == nil {
+		pairs = raw.Variable
+	}
+
+	env := &Environment{ID: raw.ID, Name: raw.Name, Values: make([]KeyValuePair, len(pairs))}
+	for i, p := range pairs {
+		enabled := !p.Disabled
+		if p.Enabled != nil {
+			enabled = *p.Enabled
+		}
+
+		env.Values[i] = KeyValuePair{Key: p.Key, Value: fmt.Sprint(p.value()), Enabled: enabled, Type: p.Type}
+	}
+
+	return env, nil
+}
+
+// importedPair is a variable of an imported environment, as exported by the
+// Postman app or listed by the Postman API.
+type importedPair struct {
+	Key      string      `json:"key"`
+	Value    interface{} `json:"value"`
+	Type     string      `json:"type"`
+	Enabled  *bool       `json:"enabled"`
+	Disabled bool        `json:"disabled"`
+}
+
+// value returns the value of the variable, which isn't always a string in
+// the variable shape.
+func (p importedPair) value() interface{} {
+	if p.Value == nil {
+		return ""
+	}
+
+	return p.Value
+}
+
 // KeyValuePair represents a key and value in the Postman API.
 type KeyValuePair struct {
 	Key     string `json:"key"`
 	Value   string `json:"value"`
 	Enabled bool   `json:"enabled"`
+	Type    string `json:"type,omitempty"`
+}
+
+// CopyVariables copies variables from src into dst.  Variables whose keys
+// appear in keyMap are copied under the mapped key; the rest are copied
+// under their own key only when copyAll is set.  A copied variable replaces
+// any variable in dst with the same key.
+func CopyVariables(src, dst *Environment, keyMap map[string]string, copyAll bool) error {
+	if src == nil || dst == nil {
+		return errors.New("a source and destination environment are required for copying variables")
+	}
+
+	index := make(map[string]int, len(dst.Values))
+	for i, v := range dst.Values {
+		index[v.Key] = i
+	}
+
+	for _, v := range src.Values {
+		key, ok := keyMap[v.Key]
+		if !ok {
+			if !copyAll {
+				continue
+			}
+			key = v.Key
+		}
+
+		v.Key = key
+		if i, ok := index[key]; ok {
+			dst.Values[i] = v
+			continue
+		}
+
+		index[key] = len(dst.Values)
+		dst.Values = append(dst.Values, v)
+	}
+
+	return nil
 }
@@ -0,0 +1,265 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestCopyVariablesRenames(t *testing.T) {
+	src := &resources.Environment{
+		Values: []resources.KeyValuePair{
+			{Key: "host", Value: "https://api.example.com", Enabled: true, Type: "default"},
+			{Key: "apiKey", Value: "s3cr3t", Enabled: true, Type: "secret"},
+		},
+	}
+	dst := &resources.Environment{
+		Values: []resources.KeyValuePair{
+			{Key: "baseUrl", Value: "http://localhost", Enabled: true},
+		},
+	}
+
+	keyMap := map[string]string{"host": "baseUrl", "apiKey": "token"}
+	if err := resources.CopyVariables(src, dst, keyMap, false); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []resources.KeyValuePair{
+		{Key: "baseUrl", Value: "https://api.example.com", Enabled: true, Type: "default"},
+		{Key: "token", Value: "s3cr3t", Enabled: true, Type: "secret"},
+	}
+
+	if len(dst.Values) != len(want) {
+		t.Fatalf("Incorrect number of values, have: %d, want: %d", len(dst.Values), len(want))
+	}
+
+	for i, v := range want {
+		if dst.Values[i] != v {
+			t.Errorf("Value is incorrect, have: %+v, want: %+v", dst.Values[i], v)
+		}
+	}
+
+	if src.Values[0].Key != "host" {
+		t.Errorf("Source was modified, have: %s, want: %s", src.Values[0].Key, "host")
+	}
+}
+
+func TestCopyVariablesSelective(t *testing.T) {
+	src := &resources.Environment{
+		Values: []resources.KeyValuePair{
+			{Key: "host", Value: "https://api.example.com", Enabled: true},
+			{Key: "userId", Value: "42", Enabled: false},
+		},
+	}
+
+	selective := &resources.Environment{}
+	if err := resources.CopyVariables(src, selective, map[string]string{"host": "host"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(selective.Values) != 1 || selective.Values[0].Key != "host" {
+		t.Errorf("Expected only the mapped variable to be copied, have: %+v", selective.Values)
+	}
+
+	all := &resources.Environment{}
+	if err := resources.CopyVariables(src, all, map[string]string{"host": "baseUrl"}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(all.Values) != 2 || all.Values[0].Key != "baseUrl" || all.Values[1].Key != "userId" || all.Values[1].Enabled {
+		t.Errorf("Expected every variable to be copied, have: %+v", all.Values)
+	}
+}
+
+func TestCopyVariablesRequiresEnvironments(t *testing.T) {
+	if err := resources.CopyVariables(nil, &resources.Environment{}, nil, true); err == nil {
+		t.Error("Expected error.")
+	}
+}
+
+func TestEnvironmentValidate(t *testing.T) {
+	env := &resources.Environment{
+		Name: "Staging",
+		Values: []resources.KeyValuePair{
+			{Key: "baseUrl", Value: "https://staging.example.com", Enabled: true},
+			{Key: "token", Value: "a", Enabled: true},
+			{Key: "", Value: "orphan", Enabled: true},
+			{Key: "token", Value: "b", Enabled: true},
+			{Key: "{{host}}", Value: "example.com", Enabled: true},
+		},
+	}
+
+	var have []string
+	for _, f := range env.Validate() {
+		have = append(have, f.String())
+	}
+
+	want := []string{
+		"error [variable-empty-key] 2: variable key is empty",
+		`error [variable-duplicate-key] 3: variable key "token" duplicates variable 1 and overrides it`,
+		`error [variable-key-braces] 4: variable key "{{host}}" contains template braces`,
+	}
+
+	if strings.Join(have, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Findings are incorrect, have:\n%s\nwant:\n%s", strings.Join(have, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestEnvironmentValidateValid(t *testing.T) {
+	env := &resources.Environment{Values: []resources.KeyValuePair{{Key: "a"}, {Key: "b"}}}
+
+	if findings := env.Validate(); len(findings) != 0 {
+		t.Errorf("Expected no findings, have: %v", findings)
+	}
+}
+
+func TestEnvironmentExportJSON(t *testing.T) {
+	env := &resources.Environment{
+		ID:   "abcdef",
+		Name: "dev",
+		Values: []resources.KeyValuePair{
+			{Key: "host", Value: "example.com", Enabled: true},
+			{Key: "token", Value: "s3cret", Enabled: false, Type: "secret"},
+		},
+	}
+
+	b, err := env.ExportJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var export map[string]interface{}
+	if err := json.Unmarshal(b, &export); err != nil {
+		t.Fatal(err)
+	}
+
+	if have := export["_postman_variable_scope"]; have != "environment" {
+		t.Errorf("Scope is incorrect, have: %v, want: %s", have, "environment")
+	}
+
+	if export["name"] != "dev" || export["id"] != "abcdef" {
+		t.Errorf("Environment is incorrect, have: %s", b)
+	}
+
+	values, ok := export["values"].([]interface{})
+	if !ok || len(values) != 2 {
+		t.Fatalf("Values are incorrect, have: %s", b)
+	}
+
+	want := []map[string]interface{}{
+		{"key": "host", "value": "example.com", "type": "default", "enabled": true},
+		{"key": "token", "value": "s3cret", "type": "secret", "enabled": false},
+	}
+	for i, w := range want {
+		v := values[i].(map[string]interface{})
+		for k, wv := range w {
+			if v[k] != wv {
+				t.Errorf("Value %d field %s is incorrect, have: %v, want: %v", i, k, v[k], wv)
+			}
+		}
+	}
+}
+
+func TestEnvironmentFromJSONAppExport(t *testing.T) {
+	subject := `{
+	"id": "abcdef",
+	"name": "dev",
+	"values": [
+		{"key": "host", "value": "example.com", "type": "default", "enabled": true},
+		{"key": "token", "value": "s3cret", "type": "secret", "enabled": false}
+	],
+	"_postman_variable_scope": "environment",
+	"_postman_exported_at": "2020-06-01T10:00:00.000Z",
+	"_postman_exported_using": "Postman/7.25.0"
+}`
+
+	env, err := resources.EnvironmentFromJSON([]byte(subject))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if env.ID != "abcdef" || env.Name != "dev" {
+		t.Errorf("Environment is incorrect, have: %+v", env)
+	}
+
+	want := []resources.KeyValuePair{
+		{Key: "host", Value: "example.com", Enabled: true, Type: "default"},
+		{Key: "token", Value: "s3cret", Enabled: false, Type: "secret"},
+	}
+	if len(env.Values) != len(want) {
+		t.Fatalf("Values are incorrect, have: %+v", env.Values)
+	}
+
+	for i, w := range want {
+		if env.Values[i] != w {
+			t.Errorf("Value is incorrect, have: %+v, want: %+v", env.Values[i], w)
+		}
+	}
+}
+
+func TestEnvironmentFromJSONAPIShape(t *testing.T) {
+	subject := `{"environment":{"id":"abcdef","name":"dev","variable":[{"key":"port","value":8080},{"key":"debug","value":"true","disabled":true}]}}`
+
+	env, err := resources.EnvironmentFromJSON([]byte(subject))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []resources.KeyValuePair{
+		{Key: "port", Value: "8080", Enabled: true},
+		{Key: "debug", Value: "true", Enabled: false},
+	}
+	if env.Name != "dev" || len(env.Values) != len(want) {
+		t.Fatalf("Environment is incorrect, have: %+v", env)
+	}
+
+	for i, w := range want {
+		if env.Values[i] != w {
+			t.Errorf("Value is incorrect, have: %+v, want: %+v", env.Values[i], w)
+		}
+	}
+}
+
+func TestEnvironmentFromJSONRoundTrip(t *testing.T) {
+	env := &resources.Environment{Name: "dev", Values: []resources.KeyValuePair{{Key: "host", Value: "example.com", Enabled: true, Type: "default"}}}
+
+	b, err := env.ExportJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imported, err := resources.EnvironmentFromJSON(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if imported.Name != "dev" || len(imported.Values) != 1 || imported.Values[0] != env.Values[0] {
+		t.Errorf("Environment is incorrect, have: %+v", imported)
+	}
+}
+
+func TestEnvironmentFromJSONRejectsOtherScopes(t *testing.T) {
+	subject := `{"name":"globals","values":[],"_postman_variable_scope":"globals"}`
+
+	if _, err := resources.EnvironmentFromJSON([]byte(subject)); err == nil || !strings.Contains(err.Error(), "globals") {
+		t.Errorf("Expected scope error, have: %v", err)
+	}
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package resources
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // ErrorResponse is the struct representation of a Postman API error.
@@ -27,9 +30,110 @@ type ErrorResponse struct {
 
 // Error is a struct representation of error details from a Postman API error.
 type Error struct {
-	Name    string                 `json:"name"`
-	Message string                 `json:"message"`
-	Details map[string]interface{} `json:"details"`
+	Name        string                 `json:"name"`
+	Message     string                 `json:"message"`
+	Details     map[string]interface{} `json:"details"`
+	FieldErrors []FieldError           `json:"-"`
+}
+
+// FieldError represents a validation failure on a single field.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (f FieldError) String() string {
+	if f.Field == "" {
+		return f.Message
+	}
+
+	return fmt.Sprintf("%s: %s", f.Field, f.Message)
+}
+
+// UnmarshalJSON converts JSON to a struct.  Postman reports details either
+// as an object keyed by field name or as a list of messages, so both are
+// accepted and flattened into FieldErrors.
+func (e *Error) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Name    string      `json:"name"`
+		Message string      `json:"message"`
+		Details interface{} `json:"details"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	e.Name = v.Name
+	e.Message = v.Message
+	e.Details = nil
+	e.FieldErrors = nil
+
+	switch details := v.Details.(type) {
+	case map[string]interface{}:
+		e.Details = details
+
+		keys := make([]string, 0, len(details))
+		for k := range details {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			e.FieldErrors = append(e.FieldErrors, FieldError{
+				Field:   k,
+				Message: detailMessage(details[k]),
+			})
+		}
+	case []interface{}:
+		for _, d := range details {
+			e.FieldErrors = append(e.FieldErrors, parseFieldError(d))
+		}
+	}
+
+	return nil
+}
+
+func parseFieldError(d interface{}) FieldError {
+	switch v := d.(type) {
+	case string:
+		return FieldError{Message: v}
+	case map[string]interface{}:
+		var f FieldError
+		for _, k := range []string{"field", "path", "param", "key"} {
+			if s, ok := v[k].(string); ok {
+				f.Field = s
+				break
+			}
+		}
+		if s, ok := v["message"].(string); ok {
+			f.Message = s
+		} else {
+			f.Message = detailMessage(v)
+		}
+		return f
+	}
+
+	return FieldError{Message: detailMessage(d)}
+}
+
+func detailMessage(d interface{}) string {
+	switch v := d.(type) {
+	case string:
+		return v
+	case []interface{}:
+		msgs := make([]string, len(v))
+		for i, m := range v {
+			msgs[i] = detailMessage(m)
+		}
+		return strings.Join(msgs, ", ")
+	}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Sprint(d)
+	}
+
+	return string(b)
 }
 
 func (e *Error) String() string {
@@ -0,0 +1,115 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// AddExample appends a saved example response to the request at itemPath,
+// given as folder names followed by the request name.  The example records a
+// copy of the request as its original request, and headers are saved in key
+// order.
+func (c *Collection) AddExample(itemPath []string, name string, status int, headers http.Header, body []byte) error {
+	if status < 100 || status > 599 {
+		return fmt.Errorf("invalid example status code %d", status)
+	}
+
+	if c.Collection == nil {
+		return errors.New("the collection is empty")
+	}
+
+	item, err := findRawRequest(c.Item, itemPath)
+	if err != nil {
+		return err
+	}
+
+	example := map[string]interface{}{
+		"name":   name,
+		"status": http.StatusText(status),
+		"code":   status,
+		"header": exampleHeaders(headers),
+		"body":   string(body),
+	}
+
+	if request, ok := item["request"]; ok {
+		// Raw items always marshal, having been decoded from JSON.
+		b, _ := json.Marshal(request)
+
+		var original interface{}
+		if err := json.Unmarshal(b, &original); err == nil {
+			example["originalRequest"] = original
+		}
+	}
+
+	responses, _ := item["response"].([]interface{})
+	item["response"] = append(responses, example)
+
+	return c.refreshItems()
+}
+
+// findRawRequest returns the raw request item at itemPath.
+func findRawRequest(items []interface{}, itemPath []string) (map[string]interface{}, error) {
+	if len(itemPath) == 0 {
+		return nil, errors.New("an item path is required")
+	}
+
+	for i, name := range itemPath {
+		item := findRawItem(items, name)
+		if item == nil {
+			return nil, fmt.Errorf("item %q not found", strings.Join(itemPath[:i+1], "/"))
+		}
+
+		sub, isFolder := item["item"].([]interface{})
+		if i == len(itemPath)-1 {
+			if isFolder {
+				return nil, fmt.Errorf("item %q is a folder", strings.Join(itemPath, "/"))
+			}
+			return item, nil
+		}
+
+		if !isFolder {
+			return nil, fmt.Errorf("item %q is not a folder", strings.Join(itemPath[:i+1], "/"))
+		}
+		items = sub
+	}
+
+	return nil, nil
+}
+
+// exampleHeaders converts headers to raw collection headers, sorted by key.
+func exampleHeaders(headers http.Header) []interface{} {
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	ret := make([]interface{}, 0, len(headers))
+	for _, k := range keys {
+		for _, v := range headers[k] {
+			ret = append(ret, map[string]interface{}{"key": k, "value": v})
+		}
+	}
+
+	return ret
+}
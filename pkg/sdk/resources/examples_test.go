@@ -0,0 +1,87 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+const exampleSubject = `{"info":{"name":"examples","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[{"name":"Users","item":[{"name":"Create","request":{"method":"POST","url":"https://example.com/users"}}]}]}`
+
+func TestCollectionAddExample(t *testing.T) {
+	c := unmarshalCollection(t, exampleSubject)
+
+	headers := http.Header{}
+	headers.Set("Location", "/users/1")
+	headers.Set("Content-Type", "application/json")
+	if err := c.AddExample([]string{"Users", "Create"}, "Created", http.StatusCreated, headers, []byte(`{"id":1}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	c = roundTripCollection(t, c)
+
+	requests := c.Flatten()
+	if len(requests) != 1 || len(requests[0].Item.Response) != 1 {
+		t.Fatalf("Example was not added, have: %+v", requests)
+	}
+
+	example := requests[0].Item.Response[0]
+	if example.Code != http.StatusCreated || example.Status != "Created" {
+		t.Errorf("Example status is incorrect, have: %d %s", example.Code, example.Status)
+	}
+
+	if example.Body != `{"id":1}` {
+		t.Errorf("Example body is incorrect, have: %v", example.Body)
+	}
+
+	b, err := json.Marshal(example.Header)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := string(b), `[{"key":"Content-Type","value":"application/json"},{"key":"Location","value":"/users/1"}]`; have != want {
+		t.Errorf("Example headers are incorrect, have: %s, want: %s", have, want)
+	}
+
+	original, ok := example.OriginalRequest.(map[string]interface{})
+	if !ok || original["method"] != "POST" {
+		t.Errorf("Original request is incorrect, have: %v", example.OriginalRequest)
+	}
+}
+
+func TestCollectionAddExampleErrors(t *testing.T) {
+	c := unmarshalCollection(t, exampleSubject)
+
+	cases := []struct {
+		name   string
+		path   []string
+		status int
+	}{
+		{"invalid status", []string{"Users", "Create"}, 42},
+		{"missing item", []string{"Users", "Delete"}, http.StatusOK},
+		{"folder", []string{"Users"}, http.StatusOK},
+		{"empty path", nil, http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		if err := c.AddExample(tc.path, "Example", tc.status, nil, nil); err == nil {
+			t.Errorf("Expected error for %s.", tc.name)
+		}
+	}
+}
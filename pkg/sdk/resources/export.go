@@ -0,0 +1,47 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+// ExportManifest records the progress of a workspace export: the UIDs of
+// the collections and environments written so far, so an interrupted export
+// resumes without fetching them again.
+type ExportManifest struct {
+	WorkspaceID  string   `json:"workspaceId"`
+	Collections  []string `json:"collections"`
+	Environments []string `json:"environments"`
+	Complete     bool     `json:"complete"`
+}
+
+// HasCollection reports whether the collection was exported.
+func (m *ExportManifest) HasCollection(uid string) bool {
+	return containsString(m.Collections, uid)
+}
+
+// HasEnvironment reports whether the environment was exported.
+func (m *ExportManifest) HasEnvironment(uid string) bool {
+	return containsString(m.Environments, uid)
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,208 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+// Extras holds the top-level JSON fields of a resource that the SDK doesn't
+// model.  They are kept when the resource is decoded and written back when
+// it's encoded, so updating a fetched resource doesn't drop them.
+type Extras map[string]json.RawMessage
+
+var (
+	collectionFields  = jsonFields(gen.Collection{}, "certificates", "proxy", "fork")
+	environmentFields = jsonFields(Environment{})
+	mockFields        = jsonFields(Mock{})
+	monitorFields     = jsonFields(Monitor{})
+	apiFields         = jsonFields(API{})
+	workspaceFields   = jsonFields(Workspace{})
+)
+
+// jsonFields returns the JSON names of the fields of struct v, along with
+// names of fields handled outside of it.
+func jsonFields(v interface{}, names ...string) map[string]bool {
+	fields := make(map[string]bool)
+	for _, n := range names {
+		fields[n] = true
+	}
+
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		if name := strings.Split(tag, ",")[0]; name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+
+	return fields
+}
+
+// decodeExtras returns the fields of the JSON object b that aren't known.
+func decodeExtras(b []byte, known map[string]bool) (Extras, error) {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	var extras Extras
+	for k, v := range m {
+		if known[k] {
+			continue
+		}
+
+		if extras == nil {
+			extras = make(Extras)
+		}
+		extras[k] = v
+	}
+
+	return extras, nil
+}
+
+// encodeExtras adds extras to the JSON object b.  Fields b already has take
+// precedence.
+func encodeExtras(b []byte, extras Extras) ([]byte, error) {
+	if len(extras) == 0 {
+		return b, nil
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	for k, v := range extras {
+		if _, ok := m[k]; !ok {
+			m[k] = v
+		}
+	}
+
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON converts JSON to a struct.
+func (r *Environment) UnmarshalJSON(b []byte) error {
+	type environment Environment
+	if err := json.Unmarshal(b, (*environment)(r)); err != nil {
+		return err
+	}
+
+	var err error
+	r.Extras, err = decodeExtras(b, environmentFields)
+
+	return err
+}
+
+// MarshalJSON converts the environment to JSON, including its extras.
+func (r Environment) MarshalJSON() ([]byte, error) {
+	type environment Environment
+	b, err := json.Marshal(environment(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeExtras(b, r.Extras)
+}
+
+// UnmarshalJSON converts JSON to a struct.
+func (r *Mock) UnmarshalJSON(b []byte) error {
+	type mock Mock
+	if err := json.Unmarshal(b, (*mock)(r)); err != nil {
+		return err
+	}
+
+	var err error
+	r.Extras, err = decodeExtras(b, mockFields)
+
+	return err
+}
+
+// MarshalJSON converts the mock to JSON, including its extras.
+func (r Mock) MarshalJSON() ([]byte, error) {
+	type mock Mock
+	b, err := json.Marshal(mock(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeExtras(b, r.Extras)
+}
+
+// MarshalJSON converts the monitor to JSON, including its extras.
+func (r Monitor) MarshalJSON() ([]byte, error) {
+	type monitor Monitor
+	b, err := json.Marshal(monitor(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeExtras(b, r.Extras)
+}
+
+// UnmarshalJSON converts JSON to a struct.
+func (r *API) UnmarshalJSON(b []byte) error {
+	type api API
+	if err := json.Unmarshal(b, (*api)(r)); err != nil {
+		return err
+	}
+
+	var err error
+	r.Extras, err = decodeExtras(b, apiFields)
+
+	return err
+}
+
+// MarshalJSON converts the API to JSON, including its extras.
+func (r API) MarshalJSON() ([]byte, error) {
+	type api API
+	b, err := json.Marshal(api(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeExtras(b, r.Extras)
+}
+
+// UnmarshalJSON converts JSON to a struct.
+func (r *Workspace) UnmarshalJSON(b []byte) error {
+	type workspace Workspace
+	if err := json.Unmarshal(b, (*workspace)(r)); err != nil {
+		return err
+	}
+
+	var err error
+	r.Extras, err = decodeExtras(b, workspaceFields)
+
+	return err
+}
+
+// MarshalJSON converts the workspace to JSON, including its extras.
+func (r Workspace) MarshalJSON() ([]byte, error) {
+	type workspace Workspace
+	b, err := json.Marshal(workspace(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeExtras(b, r.Extras)
+}
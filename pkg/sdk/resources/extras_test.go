@@ -0,0 +1,92 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestCollectionExtrasRoundTrip(t *testing.T) {
+	c := unmarshalCollection(t, `{"info":{"name":"extras","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[],"x-owner":{"team":"payments"}}`)
+
+	if have := string(c.Extras["x-owner"]); have != `{"team":"payments"}` {
+		t.Errorf("Extra field is incorrect, have: %s", have)
+	}
+
+	c.Fork = &resources.Fork{Label: "mine"}
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	if have := string(m["x-owner"]); have != `{"team":"payments"}` {
+		t.Errorf("Extra field was not encoded, have: %s", b)
+	}
+
+	if _, ok := c.Extras["info"]; ok {
+		t.Errorf("Modelled field was kept as an extra.")
+	}
+}
+
+func TestResourceExtrasRoundTrip(t *testing.T) {
+	cases := []struct {
+		name    string
+		subject string
+		v       interface{}
+		want    string
+	}{
+		{"environment", `{"id":"1","name":"dev","values":[],"isPublic":true}`, &resources.Environment{}, "dev"},
+		{"mock", `{"id":"1","name":"mock","config":{},"isPublic":true}`, &resources.Mock{}, "mock"},
+		{"monitor", `{"id":"1","name":"monitor","owner":1,"isPublic":true}`, &resources.Monitor{}, "monitor"},
+		{"api", `{"id":"1","name":"api","isPublic":true}`, &resources.API{}, "api"},
+		{"workspace", `{"id":"1","name":"ws","isPublic":true}`, &resources.Workspace{}, "ws"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(c.subject), c.v); err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := json.Marshal(c.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var m map[string]interface{}
+			if err := json.Unmarshal(b, &m); err != nil {
+				t.Fatal(err)
+			}
+
+			if m["isPublic"] != true {
+				t.Errorf("Extra field did not survive, have: %s", b)
+			}
+
+			if m["name"] != c.want {
+				t.Errorf("Modelled field is incorrect, have: %s", b)
+			}
+		})
+	}
+}
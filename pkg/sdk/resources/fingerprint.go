@@ -0,0 +1,74 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// fingerprintIgnoredKeys are the keys assigned by the Postman API rather
+// than by the collection author, left out of fingerprints.
+var fingerprintIgnoredKeys = map[string]bool{
+	"id":           true,
+	"uid":          true,
+	"_postman_id":  true,
+	"_exporter_id": true,
+	"createdAt":    true,
+	"updatedAt":    true,
+	"fork":         true,
+}
+
+// Fingerprint returns a hex-encoded SHA-256 hash of the collection's
+// content.  IDs, timestamps, and fork metadata are ignored, so the same
+// collection fetched twice, or a structurally identical copy, produces the
+// same fingerprint.
+func (c *Collection) Fingerprint() string {
+	var doc interface{}
+
+	// Collections always marshal and unmarshal as JSON; an error leaves doc
+	// empty, which still hashes deterministically.
+	if b, err := json.Marshal(c); err == nil {
+		_ = json.Unmarshal(b, &doc)
+	}
+
+	// Maps are marshalled with sorted keys, which normalizes their order.
+	b, _ := json.Marshal(stripFingerprintKeys(doc))
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func stripFingerprintKeys(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if fingerprintIgnoredKeys[k] {
+				delete(t, k)
+				continue
+			}
+			t[k] = stripFingerprintKeys(child)
+		}
+	case []interface{}:
+		for i, child := range t {
+			t[i] = stripFingerprintKeys(child)
+		}
+	}
+
+	return v
+}
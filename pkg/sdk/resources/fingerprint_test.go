@@ -0,0 +1,45 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCollectionFingerprintIgnoresIDs(t *testing.T) {
+	a := unmarshalCollection(t, `{"info":{"_postman_id":"1111","name":"Users","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json","updatedAt":"2020-01-01T00:00:00.000Z"},"item":[{"id":"a1","name":"List","request":{"method":"GET","url":"https://example.com/users","header":[{"key":"Accept","value":"application/json"}]}}]}`)
+	b := unmarshalCollection(t, `{"item":[{"request":{"header":[{"value":"application/json","key":"Accept"}],"url":"https://example.com/users","method":"GET"},"name":"List","id":"b2"}],"info":{"schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json","name":"Users","_postman_id":"2222","updatedAt":"2021-06-01T00:00:00.000Z"}}`)
+
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Errorf("Fingerprints differ, have: %s, want: %s", b.Fingerprint(), a.Fingerprint())
+	}
+
+	if a.Fingerprint() != a.Fingerprint() {
+		t.Errorf("Fingerprint is not stable.")
+	}
+}
+
+func TestCollectionFingerprintContentChange(t *testing.T) {
+	subject := `{"info":{"_postman_id":"1111","name":"Users","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[{"id":"a1","name":"List","request":{"method":"GET","url":"https://example.com/users"}}]}`
+	a := unmarshalCollection(t, subject)
+	b := unmarshalCollection(t, strings.Replace(subject, "GET", "POST", 1))
+
+	if a.Fingerprint() == b.Fingerprint() {
+		t.Errorf("Fingerprint did not change with the content.")
+	}
+}
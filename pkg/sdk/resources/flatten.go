@@ -0,0 +1,86 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+// FlatRequest is a request item along with the names of the folders
+// containing it.
+type FlatRequest struct {
+	Folders []string
+	Item    Item
+}
+
+// Path returns the folder names followed by the item name.
+func (r FlatRequest) Path() []string {
+	name := ""
+	if r.Item.Item != nil {
+		name = r.Item.Name
+	}
+
+	return appendPath(r.Folders, name)
+}
+
+// Flatten returns every request in the collection in declared order.
+func (c *Collection) Flatten() []FlatRequest {
+	var ret []FlatRequest
+	if c.Items == nil {
+		return ret
+	}
+
+	flattenItemTree(&c.Items.Root, nil, false, func(r FlatRequest, _ bool) {
+		ret = append(ret, r)
+	})
+
+	return ret
+}
+
+// RequestsWithoutTests returns the requests not covered by a test script,
+// either their own or one inherited from a containing folder or the
+// collection.
+func (c *Collection) RequestsWithoutTests() []FlatRequest {
+	var ret []FlatRequest
+	if c.Items == nil {
+		return ret
+	}
+
+	covered := c.Collection != nil && hasTestScript(wrapEvents(c.Event))
+
+	flattenItemTree(&c.Items.Root, nil, covered, func(r FlatRequest, inherited bool) {
+		if !inherited && !hasTestScript(r.Item.Events) {
+			ret = append(ret, r)
+		}
+	})
+
+	return ret
+}
+
+// flattenItemTree calls fn for every request in the tree in declared order,
+// reporting whether
+// a test script is inherited from a containing folder.
+func flattenItemTree(node *ItemTreeNode, folders []string, tested bool, fn func(r FlatRequest, tested bool)) {
+	if node.ItemGroup != nil && node.ItemGroup.ItemGroup != nil {
+		folders = appendPath(folders, node.ItemGroup.Name)
+		tested = tested || hasTestScript(node.ItemGroup.Events)
+	}
+
+	node.eachChild(func(branch *ItemTreeNode, it *Item) {
+		if branch != nil {
+			flattenItemTree(branch, folders, tested, fn)
+		} else {
+			fn(FlatRequest{Folders: folders, Item: *it}, tested)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"strings"
+	"testing"
+)
+
+const coverageSubject = `{
+  "info": {"name": "coverage", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "item": [
+    {
+      "name": "Users",
+      "event": [{"listen": "test", "script": {"exec": ["pm.test('ok', () => pm.response.to.be.ok);"]}}],
+      "item": [
+        {"name": "List Users", "request": "https://example.com/users"},
+        {"name": "Admins", "item": [{"name": "List Admins", "request": "https://example.com/admins"}]}
+      ]
+    },
+    {
+      "name": "Orders",
+      "event": [{"listen": "prerequest", "script": {"exec": ["pm.variables.set('a', 1);"]}}],
+      "item": [
+        {"name": "List Orders", "request": "https://example.com/orders"},
+        {
+          "name": "Get Order",
+          "event": [{"listen": "test", "script": {"exec": ["pm.expect(1).to.eql(1);"]}}],
+          "request": "https://example.com/orders/1"
+        }
+      ]
+    },
+    {
+      "name": "Ping",
+      "event": [{"listen": "test", "script": {"exec": [""]}}],
+      "request": "https://example.com/ping"
+    }
+  ]
+}`
+
+func TestRequestsWithoutTests(t *testing.T) {
+	c := unmarshalCollection(t, coverageSubject)
+
+	untested := c.RequestsWithoutTests()
+
+	var have []string
+	for _, r := range untested {
+		have = append(have, strings.Join(r.Path(), "/"))
+	}
+
+	want := "Orders/List Orders,Ping"
+	if strings.Join(have, ",") != want {
+		t.Errorf("Untested requests are incorrect, have: %s, want: %s", strings.Join(have, ","), want)
+	}
+
+	var paths []string
+	for _, r := range c.Flatten() {
+		paths = append(paths, strings.Join(r.Path(), "/"))
+	}
+
+	want = "Users/List Users,Users/Admins/List Admins,Orders/List Orders,Orders/Get Order,Ping"
+	if strings.Join(paths, ",") != want {
+		t.Errorf("Flattened requests are incorrect, have: %s, want: %s", strings.Join(paths, ","), want)
+	}
+}
+
+func TestRequestsWithoutTestsCollectionScript(t *testing.T) {
+	c := unmarshalCollection(t, `{
+		"info": {"name": "coverage", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"event": [{"listen": "test", "script": {"exec": "pm.test('ok', () => {});"}}],
+		"item": [{"name": "Ping", "request": "https://example.com/ping"}]
+	}`)
+
+	if untested := c.RequestsWithoutTests(); len(untested) != 0 {
+		t.Errorf("Expected the collection test script to cover every request, have: %d untested", len(untested))
+	}
+}
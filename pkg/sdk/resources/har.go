@@ -0,0 +1,411 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// collectionSchemaV21 is the schema URL declared by generated collections.
+const collectionSchemaV21 = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+type harLog struct {
+	Log struct {
+		Entries []struct {
+			Request harRequest `json:"request"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *struct {
+		MimeType string         `json:"mimeType"`
+		Text     string         `json:"text"`
+		Params   []harNameValue `json:"params"`
+	} `json:"postData"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HAROptions controls how a HAR file is converted into a collection.
+type HAROptions struct {
+	// GroupByHost places requests in one folder per host.
+	GroupByHost bool
+}
+
+// CollectionFromHAR converts the HTTP requests captured in a HAR file into a
+// collection named name.
+func CollectionFromHAR(har []byte, name string) (*Collection, error) {
+	return CollectionFromHARWithOptions(har, name, HAROptions{})
+}
+
+// CollectionFromHARWithOptions converts the HTTP requests captured in a HAR
+// file into a collection named name.  Entries that are not HTTP or HTTPS
+// requests are skipped.
+func CollectionFromHARWithOptions(har []byte, name string, opts HAROptions) (*Collection, error) {
+	var h harLog
+	if err := json.Unmarshal(har, &h); err != nil {
+		return nil, err
+	}
+
+	if h.Log.Entries == nil {
+		return nil, errors.New("invalid HAR file: no log entries")
+	}
+
+	items := []interface{}{}
+	folders := make(map[string]map[string]interface{})
+	for _, e := range h.Log.Entries {
+		u, err := url.Parse(e.Request.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+
+		item := harItem(e.Request, u)
+		if !opts.GroupByHost {
+			items = append(items, item)
+			continue
+		}
+
+		folder, ok := folders[u.Host]
+		if !ok {
+			folder = map[string]interface{}{
+				"name": u.Host,
+				"item": []interface{}{},
+			}
+			folders[u.Host] = folder
+			items = append(items, folder)
+		}
+		folder["item"] = append(folder["item"].([]interface{}), item)
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"info": map[string]interface{}{
+			"name":   name,
+			"schema": collectionSchemaV21,
+		},
+		"item": items,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var c Collection
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// harItem converts a HAR request into a raw collection item.
+func harItem(r harRequest, u *url.URL) map[string]interface{} {
+	method := strings.ToUpper(r.Method)
+	if method == "" {
+		method = "GET"
+	}
+
+	rawURL := map[string]interface{}{
+		"raw":      r.URL,
+		"protocol": u.Scheme,
+		"host":     strings.Split(u.Hostname(), "."),
+	}
+
+	if u.Port() != "" {
+		rawURL["port"] = u.Port()
+	}
+
+	if p := strings.Trim(u.Path, "/"); p != "" {
+		rawURL["path"] = strings.Split(p, "/")
+	}
+
+	if len(r.QueryString) > 0 {
+		query := make([]interface{}, len(r.QueryString))
+		for i, q := range r.QueryString {
+			query[i] = map[string]interface{}{"key": q.Name, "value": q.Value}
+		}
+		rawURL["query"] = query
+	}
+
+	headers := []interface{}{}
+	for _, h := range r.Headers {
+		// HTTP/2 pseudo-headers such as :authority are not real headers.
+		if strings.HasPrefix(h.Name, ":") {
+			continue
+		}
+		headers = append(headers, map[string]interface{}{"key": h.Name, "value": h.Value})
+	}
+
+	request := map[string]interface{}{
+		"method": method,
+		"url":    rawURL,
+		"header": headers,
+	}
+
+	if body := harBody(r); body != nil {
+		request["body"] = body
+	}
+
+	name := method + " " + u.Path
+	if u.Path == "" {
+		name = method + " /"
+	}
+
+	return map[string]interface{}{
+		"name":     name,
+		"request":  request,
+		"response": []interface{}{},
+	}
+}
+
+// harBody converts HAR post data into a raw request body, returning nil when
+// the request has none.
+func harBody(r harRequest) map[string]interface{} {
+	if r.PostData == nil {
+		return nil
+	}
+
+	mime := strings.ToLower(r.PostData.MimeType)
+	if strings.HasPrefix(mime, "application/x-www-form-urlencoded") && len(r.PostData.Params) > 0 {
+		params := make([]interface{}, len(r.PostData.Params))
+		for i, p := range r.PostData.Params {
+			params[i] = map[string]interface{}{"key": p.Name, "value": p.Value}
+		}
+		return map[string]interface{}{"mode": BodyModeURLEncoded, "urlencoded": params}
+	}
+
+	if r.PostData.Text == "" {
+		return nil
+	}
+
+	body := map[string]interface{}{"mode": BodyModeRaw, "raw": r.PostData.Text}
+	for _, lang := range []string{"json", "xml", "html", "javascript"} {
+		if strings.Contains(mime, lang) {
+			body["options"] = map[string]interface{}{"raw": map[string]interface{}{"language": lang}}
+			break
+		}
+	}
+
+	return body
+}
+
+// harVersion is the HAR format version written by ToHAR.
+const harVersion = "1.2"
+
+type harExportLog struct {
+	Log struct {
+		Version string `json:"version"`
+		Creator struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"creator"`
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harEntry struct {
+	StartedDateTime string            `json:"startedDateTime"`
+	Time            int               `json:"time"`
+	Request         harExportRequest  `json:"request"`
+	Response        harExportResponse `json:"response"`
+	Cache           struct{}          `json:"cache"`
+	Timings         harTimings        `json:"timings"`
+	Error           string            `json:"_error,omitempty"`
+}
+
+type harExportRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harExportResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    int `json:"send"`
+	Wait    int `json:"wait"`
+	Receive int `json:"receive"`
+}
+
+// ToHAR converts the executions of a run into a HAR file.  Executions that
+// failed without a response are written with a status of 0 and the failure
+// in the _error field.
+func (r *RunSummary) ToHAR() ([]byte, error) {
+	var h harExportLog
+	h.Log.Version = harVersion
+	h.Log.Creator.Name = "postmanctl"
+	h.Log.Entries = make([]harEntry, len(r.Executions))
+
+	for i, e := range r.Executions {
+		h.Log.Entries[i] = harExecution(e)
+	}
+
+	return json.MarshalIndent(h, "", "  ")
+}
+
+// harExecution converts a single run execution into a HAR entry.
+func harExecution(e RunExecution) harEntry {
+	method := strings.ToUpper(e.Request.Method)
+	if method == "" {
+		method = "GET"
+	}
+
+	entry := harEntry{
+		StartedDateTime: e.Request.Timestamp.UTC().Format(time.RFC3339Nano),
+		Request: harExportRequest{
+			Method:      method,
+			URL:         e.Request.URL,
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(e.Request.Headers),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    0,
+		},
+		Response: harExportResponse{
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			Content:     harContent{MimeType: "x-unknown"},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+	}
+
+	if u, err := url.Parse(e.Request.URL); err == nil {
+		for k, vs := range u.Query() {
+			for _, v := range vs {
+				entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: k, Value: v})
+			}
+		}
+		sort.Slice(entry.Request.QueryString, func(i, j int) bool {
+			return entry.Request.QueryString[i].Name < entry.Request.QueryString[j].Name
+		})
+	}
+
+	if text := runBodyText(e.Request.Body); text != "" {
+		entry.Request.PostData = &harPostData{
+			MimeType: headerValue(e.Request.Headers, "Content-Type"),
+			Text:     text,
+		}
+		entry.Request.BodySize = len(text)
+	}
+
+	if e.Response == nil {
+		entry.Error = "no response"
+		if e.Error != nil && e.Error.Message != "" {
+			entry.Error = e.Error.Message
+		}
+		return entry
+	}
+
+	text := runBodyText(e.Response.Body)
+	entry.Time = e.Response.ResponseTime
+	entry.Timings.Wait = e.Response.ResponseTime
+	entry.Response.Status = e.Response.Code
+	entry.Response.StatusText = http.StatusText(e.Response.Code)
+	entry.Response.Headers = harHeaders(e.Response.Headers)
+	entry.Response.BodySize = e.Response.ResponseSize
+	entry.Response.Content = harContent{
+		Size:     len(text),
+		MimeType: headerValue(e.Response.Headers, "Content-Type"),
+		Text:     text,
+	}
+	if entry.Response.Content.MimeType == "" {
+		entry.Response.Content.MimeType = "x-unknown"
+	}
+
+	return entry
+}
+
+// harHeaders converts a header map into HAR name/value pairs sorted by name.
+func harHeaders(headers map[string]string) []harNameValue {
+	ret := make([]harNameValue, 0, len(headers))
+	for k, v := range headers {
+		ret = append(ret, harNameValue{Name: k, Value: v})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+
+	return ret
+}
+
+// headerValue returns the value of a header, ignoring the case of its name.
+func headerValue(headers map[string]string, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// runBodyText returns the text of a run request or response body, which is
+// either a JSON string or a JSON value.  Empty objects are treated as no
+// body.
+func runBodyText(b json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		return s
+	}
+
+	switch strings.TrimSpace(string(b)) {
+	case "", "null", "{}":
+		return ""
+	}
+
+	return string(b)
+}
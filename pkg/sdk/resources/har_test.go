@@ -0,0 +1,233 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+const harSubject = `{
+  "log": {
+    "version": "1.2",
+    "entries": [
+      {
+        "request": {
+          "method": "GET",
+          "url": "https://api.example.com/users?page=2",
+          "headers": [{"name": ":authority", "value": "api.example.com"}, {"name": "Accept", "value": "application/json"}],
+          "queryString": [{"name": "page", "value": "2"}]
+        }
+      },
+      {
+        "request": {
+          "method": "POST",
+          "url": "https://api.example.com/users",
+          "headers": [{"name": "Content-Type", "value": "application/json"}],
+          "queryString": [],
+          "postData": {"mimeType": "application/json", "text": "{\"name\":\"ada\"}"}
+        }
+      },
+      {
+        "request": {"method": "GET", "url": "wss://api.example.com/socket", "headers": []}
+      },
+      {
+        "request": {"method": "GET", "url": "https://cdn.example.com/logo.png", "headers": []}
+      }
+    ]
+  }
+}`
+
+func TestCollectionFromHAR(t *testing.T) {
+	c, err := resources.CollectionFromHAR([]byte(harSubject), "Captured")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Info.Name != "Captured" {
+		t.Errorf("Name is incorrect, have: %s, want: %s", c.Info.Name, "Captured")
+	}
+
+	items := *c.Items.Root.Items
+	if len(items) != 3 {
+		t.Fatalf("Incorrect number of items, have: %d, want: %d", len(items), 3)
+	}
+
+	get, err := resources.ParseRequest(items[0].Item.Request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if items[0].Name != "GET /users" || get.Method != "GET" || get.URL.String() != "https://api.example.com/users?page=2" {
+		t.Errorf("GET request is incorrect, have: %s %+v", items[0].Name, get)
+	}
+
+	if len(get.Header) != 1 || get.Header[0].Key != "Accept" {
+		t.Errorf("Headers are incorrect, have: %+v", get.Header)
+	}
+
+	if get.Body != nil {
+		t.Errorf("Expected no body, have: %+v", get.Body)
+	}
+
+	query := items[0].Item.Request.(map[string]interface{})["url"].(map[string]interface{})["query"].([]interface{})
+	if len(query) != 1 || query[0].(map[string]interface{})["key"] != "page" {
+		t.Errorf("Query is incorrect, have: %v", query)
+	}
+
+	post, err := resources.ParseRequest(items[1].Item.Request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if post.Method != "POST" || post.Body == nil || post.Body.Raw != `{"name":"ada"}` || post.Body.Language() != "json" {
+		t.Errorf("POST request is incorrect, have: %+v", post)
+	}
+}
+
+func TestCollectionFromHARGroupByHost(t *testing.T) {
+	c, err := resources.CollectionFromHARWithOptions([]byte(harSubject), "Captured", resources.HAROptions{GroupByHost: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	folders := *c.Items.Root.Branches
+	if len(folders) != 2 {
+		t.Fatalf("Incorrect number of folders, have: %d, want: %d", len(folders), 2)
+	}
+
+	if folders[0].ItemGroup.Name != "api.example.com" || len(*folders[0].Items) != 2 {
+		t.Errorf("Folder is incorrect, have: %s with %d items", folders[0].ItemGroup.Name, len(*folders[0].Items))
+	}
+
+	if folders[1].ItemGroup.Name != "cdn.example.com" || len(*folders[1].Items) != 1 {
+		t.Errorf("Folder is incorrect, have: %s with %d items", folders[1].ItemGroup.Name, len(*folders[1].Items))
+	}
+}
+
+func TestCollectionFromHARInvalid(t *testing.T) {
+	if _, err := resources.CollectionFromHAR([]byte(`{"entries":[]}`), "Captured"); err == nil {
+		t.Error("Expected error for HAR without a log.")
+	}
+}
+
+const runSubject = `{
+  "run": {
+    "info": {"jobId": "1ecee76a-e14e-47c0-bddc-256bf690c407", "monitorId": "1e6b6cc1-c760-48e0-968f-4bfaeeae9af1", "name": "Health", "status": "failed", "startedAt": "2021-03-08T09:00:00.000Z", "finishedAt": "2021-03-08T09:00:02.000Z"},
+    "stats": {"assertions": {"total": 2, "failed": 1}, "requests": {"total": 2, "failed": 1}},
+    "executions": [
+      {
+        "id": 1,
+        "item": {"id": "b5e8d7dd-909c-4ba4-aa0f-3bac5b8de1fb", "name": "Create user"},
+        "request": {"method": "POST", "url": "https://api.example.com/users?notify=true", "headers": {"Content-Type": "application/json", "User-Agent": "PostmanRuntime/7.26.8"}, "body": "{\"name\":\"ada\"}", "timestamp": "2021-03-08T09:00:00.500Z"},
+        "response": {"code": 201, "headers": {"Content-Type": "application/json"}, "body": "{\"id\":42}", "responseSize": 9, "responseTime": 87}
+      },
+      {
+        "id": 2,
+        "item": {"id": "2c4a6b8e-1f3d-4e5a-9b7c-8d6e5f4a3b2c", "name": "Health"},
+        "request": {"method": "GET", "url": "https://down.example.com/health", "headers": {}, "body": {}, "timestamp": "2021-03-08T09:00:01.000Z"},
+        "error": {"name": "Error", "message": "getaddrinfo ENOTFOUND down.example.com"}
+      }
+    ]
+  }
+}`
+
+func TestRunSummaryToHAR(t *testing.T) {
+	var resp resources.MonitorRunResponse
+	if err := json.Unmarshal([]byte(runSubject), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := resp.Run.ToHAR()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var har struct {
+		Log struct {
+			Version string `json:"version"`
+			Entries []struct {
+				StartedDateTime string `json:"startedDateTime"`
+				Time            int    `json:"time"`
+				Request         struct {
+					Method      string `json:"method"`
+					QueryString []struct {
+						Name  string `json:"name"`
+						Value string `json:"value"`
+					} `json:"queryString"`
+					PostData *struct {
+						Text string `json:"text"`
+					} `json:"postData"`
+				} `json:"request"`
+				Response *struct {
+					Status  int `json:"status"`
+					Content struct {
+						Text string `json:"text"`
+					} `json:"content"`
+				} `json:"response"`
+				Timings *struct {
+					Wait int `json:"wait"`
+				} `json:"timings"`
+				Error string `json:"_error"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	if err := json.Unmarshal(data, &har); err != nil {
+		t.Fatal(err)
+	}
+
+	if har.Log.Version != "1.2" || len(har.Log.Entries) != 2 {
+		t.Fatalf("HAR log is incorrect, have: %s", data)
+	}
+
+	ok := har.Log.Entries[0]
+	if ok.StartedDateTime != "2021-03-08T09:00:00.5Z" || ok.Time != 87 || ok.Timings == nil || ok.Timings.Wait != 87 {
+		t.Errorf("Entry timings are incorrect, have: %+v", ok)
+	}
+
+	if ok.Request.PostData == nil || ok.Request.PostData.Text != `{"name":"ada"}` {
+		t.Errorf("Request body is incorrect, have: %+v", ok.Request.PostData)
+	}
+
+	if len(ok.Request.QueryString) != 1 || ok.Request.QueryString[0].Name != "notify" {
+		t.Errorf("Query string is incorrect, have: %+v", ok.Request.QueryString)
+	}
+
+	if ok.Response == nil || ok.Response.Status != 201 || ok.Response.Content.Text != `{"id":42}` {
+		t.Errorf("Response is incorrect, have: %+v", ok.Response)
+	}
+
+	failed := har.Log.Entries[1]
+	if failed.Response == nil || failed.Response.Status != 0 || failed.Request.PostData != nil {
+		t.Errorf("Errored entry is incorrect, have: %+v", failed)
+	}
+
+	if failed.Error != "getaddrinfo ENOTFOUND down.example.com" {
+		t.Errorf("Entry error is incorrect, have: %s", failed.Error)
+	}
+
+	c, err := resources.CollectionFromHAR(data, "Run")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(*c.Items.Root.Items); n != 2 {
+		t.Errorf("Expected the HAR to be readable, have %d items, want: %d", n, 2)
+	}
+}
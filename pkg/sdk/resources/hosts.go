@@ -0,0 +1,78 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Hosts returns the sorted, deduplicated hostnames contacted by the
+// requests in the collection, with variables resolved from the scopes given
+// by ItemScopes for each request.  Requests whose host can't be
+// resolved are skipped and counted in skipped.
+func (c *Collection) Hosts(scopes ...VariableScope) (hosts []string, skipped int) {
+	seen := make(map[string]bool)
+	for _, flat := range c.Flatten() {
+		if flat.Item.Item == nil || flat.Item.Request == nil {
+			continue
+		}
+
+		r, err := ParseRequest(flat.Item.Request)
+		if err != nil {
+			skipped++
+			continue
+		}
+
+		host := requestHost(r.ResolvedURL(c.requestScopes(flat.Path(), scopes)...))
+		if host == "" {
+			skipped++
+			continue
+		}
+
+		if !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+
+	sort.Strings(hosts)
+
+	return hosts, skipped
+}
+
+// requestHost returns the lowercased hostname of a resolved request URL, or
+// "" when it has none or it still contains variable references.  URLs
+// without a scheme are taken as http, as Postman does.
+func requestHost(raw string) string {
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if strings.ContainsAny(host, "{}") {
+		return ""
+	}
+
+	return host
+}
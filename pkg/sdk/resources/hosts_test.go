@@ -0,0 +1,57 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestCollectionHosts(t *testing.T) {
+	c := unmarshalCollection(t, `{
+		"info": {"name": "hosts", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"variable": [{"key": "baseUrl", "value": "https://API.example.com"}],
+		"item": [
+			{"name": "Admin", "variable": [{"key": "baseUrl", "value": "https://admin.example.com"}], "item": [
+				{"name": "Stats", "request": {"method": "GET", "url": "{{baseUrl}}/stats"}}
+			]},
+			{"name": "Users", "item": [
+				{"name": "List users", "request": {"method": "GET", "url": "{{baseUrl}}/users"}},
+				{"name": "Get user", "request": {"method": "GET", "url": "{{baseUrl}}/users/1"}}
+			]},
+			{"name": "Auth", "request": {"method": "POST", "url": "auth.example.net:8443/token"}},
+			{"name": "Unresolved", "request": {"method": "GET", "url": "https://{{host}}/health"}}
+		]
+	}`)
+
+	hosts, skipped := c.Hosts()
+
+	if have, want := strings.Join(hosts, ","), "admin.example.com,api.example.com,auth.example.net"; have != want {
+		t.Errorf("Hosts are incorrect, have: %s, want: %s", have, want)
+	}
+
+	if skipped != 1 {
+		t.Errorf("Skipped count is incorrect, have: %d, want: %d", skipped, 1)
+	}
+
+	hosts, skipped = c.Hosts(resources.VariableScope{"host": "status.example.org"})
+	if len(hosts) != 4 || skipped != 0 {
+		t.Errorf("Expected the host to resolve from the scope, have: %v, skipped %d", hosts, skipped)
+	}
+}
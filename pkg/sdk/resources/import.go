@@ -0,0 +1,124 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Limits applied when downloading a collection from a URL.
+const (
+	MaxCollectionDownloadSize = 20 << 20
+	CollectionDownloadTimeout = 30 * time.Second
+)
+
+// collectionSchemaHosts are the hosts of the schema URLs declared by Postman
+// collections.
+var collectionSchemaHosts = []string{"schema.getpostman.com/json/collection/", "schema.postman.com/json/collection/"}
+
+// CollectionFromURL downloads a collection, such as one shared through a
+// public link, using client, or http.DefaultClient when client is nil.  The
+// download is limited to MaxCollectionDownloadSize bytes and
+// CollectionDownloadTimeout.  Collections wrapped in a "collection" key, as
+// returned by the Postman API, are accepted.
+func CollectionFromURL(ctx context.Context, client *http.Client, rawURL string) (*Collection, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, CollectionDownloadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("downloading collection: status code: %d", resp.StatusCode)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, MaxCollectionDownloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > MaxCollectionDownloadSize {
+		return nil, fmt.Errorf("collection exceeds the %d byte download limit", MaxCollectionDownloadSize)
+	}
+
+	return parseDownloadedCollection(data)
+}
+
+// parseDownloadedCollection checks that data is a Postman collection before
+// decoding it.
+func parseDownloadedCollection(data []byte) (*Collection, error) {
+	var probe struct {
+		Info *struct {
+			Schema string `json:"schema"`
+		} `json:"info"`
+		Collection json.RawMessage `json:"collection"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("not a Postman collection: %w", err)
+	}
+
+	if probe.Info == nil && len(probe.Collection) > 0 {
+		return parseDownloadedCollection(probe.Collection)
+	}
+
+	if probe.Info == nil || !isCollectionSchema(probe.Info.Schema) {
+		return nil, fmt.Errorf("not a Postman collection: missing or unknown info.schema")
+	}
+
+	var c Collection
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+func isCollectionSchema(schema string) bool {
+	for _, host := range collectionSchemaHosts {
+		if strings.Contains(schema, host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ImportResponse is the top-level response of importing a spec into the
+// Postman API, listing the collections created.
+type ImportResponse struct {
+	Collections CollectionListItems `json:"collections"`
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func serveJSON(body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestCollectionFromURL(t *testing.T) {
+	server := serveJSON(lintSubject)
+	defer server.Close()
+
+	c, err := resources.CollectionFromURL(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Info.Name != "lint" {
+		t.Errorf("Collection name is incorrect, have: %s, want: %s", c.Info.Name, "lint")
+	}
+}
+
+func TestCollectionFromURLWrapped(t *testing.T) {
+	server := serveJSON(`{"collection":` + lintSubject + `}`)
+	defer server.Close()
+
+	if _, err := resources.CollectionFromURL(context.Background(), server.Client(), server.URL); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCollectionFromURLNotACollection(t *testing.T) {
+	for _, body := range []string{
+		`{"info":{"name":"spec","schema":"https://json-schema.org/draft-07/schema"},"item":[]}`,
+		`{"openapi":"3.0.0","info":{"title":"Users"}}`,
+		`<html></html>`,
+	} {
+		server := serveJSON(body)
+
+		if _, err := resources.CollectionFromURL(context.Background(), server.Client(), server.URL); err == nil || !strings.Contains(err.Error(), "not a Postman collection") {
+			t.Errorf("Expected not a collection error for %s, have: %v", body, err)
+		}
+
+		server.Close()
+	}
+}
+
+func TestCollectionFromURLTooLarge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(strings.Repeat(" ", resources.MaxCollectionDownloadSize+1)))
+	}))
+	defer server.Close()
+
+	if _, err := resources.CollectionFromURL(context.Background(), server.Client(), server.URL); err == nil {
+		t.Error("Expected error.")
+	}
+}
@@ -29,6 +29,7 @@ func (b *ItemTreeNode) AddBranch(br ItemTreeNode) ItemTreeNode {
 		branch := append(*b.Branches, br)
 		b.Branches = &branch
 	}
+	b.order = append(b.order, true)
 
 	return br
 }
@@ -41,6 +42,7 @@ func (b *ItemTreeNode) AddItem(item Item) Item {
 		items := append(*b.Items, item)
 		b.Items = &items
 	}
+	b.order = append(b.order, false)
 
 	return item
 }
@@ -62,4 +64,46 @@ type ItemTreeNode struct {
 	*ItemGroup
 	Branches *[]ItemTreeNode
 	Items    *[]Item
+
+	// order records whether each child added was a branch, so children can
+	// be visited in the order they were declared.
+	order []bool
+}
+
+// eachChild calls fn for every branch and item of the node in the order
+// they were added, passing exactly one of them as non-nil.  Nodes whose
+// children were not all added with AddBranch and AddItem visit their items
+// before their branches.
+func (b *ItemTreeNode) eachChild(fn func(branch *ItemTreeNode, item *Item)) {
+	var branches []ItemTreeNode
+	if b.Branches != nil {
+		branches = *b.Branches
+	}
+
+	var items []Item
+	if b.Items != nil {
+		items = *b.Items
+	}
+
+	order := b.order
+	if len(order) != len(branches)+len(items) {
+		order = make([]bool, 0, len(branches)+len(items))
+		for range items {
+			order = append(order, false)
+		}
+		for range branches {
+			order = append(order, true)
+		}
+	}
+
+	var nb, ni int
+	for _, isBranch := range order {
+		if isBranch {
+			fn(&branches[nb], nil)
+			nb++
+		} else {
+			fn(nil, &items[ni])
+			ni++
+		}
+	}
 }
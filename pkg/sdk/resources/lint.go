@@ -0,0 +1,231 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LintSeverity describes how serious a LintFinding is.
+type LintSeverity string
+
+// Lint severities.
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+	LintInfo    LintSeverity = "info"
+)
+
+// LintFinding is a single best-practice violation found in a collection.
+type LintFinding struct {
+	RuleID   string
+	Severity LintSeverity
+	Path     []string
+	Message  string
+}
+
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s [%s] %s: %s", f.Severity, f.RuleID, strings.Join(f.Path, "/"), f.Message)
+}
+
+// LintRule checks a single request item, identified by the folder and item
+// names leading to it, and returns any findings.
+type LintRule func(path []string, item Item) []LintFinding
+
+// DefaultLintRules returns the built-in best-practice rules.
+func DefaultLintRules() []LintRule {
+	return []LintRule{
+		LintRequestName,
+		LintURLHostVariable,
+		LintRequestExamples,
+		LintRequestTests,
+	}
+}
+
+// Lint runs rules against every request in the collection.  The default rule
+// set is used when no rules are provided.
+func (c *Collection) Lint(rules ...LintRule) []LintFinding {
+	if len(rules) == 0 {
+		rules = DefaultLintRules()
+	}
+
+	var findings []LintFinding
+	if c.Items == nil {
+		return findings
+	}
+
+	walkItemTree(&c.Items.Root, nil, func(path []string, item Item) {
+		for _, rule := range rules {
+			findings = append(findings, rule(path, item)...)
+		}
+	})
+
+	return findings
+}
+
+// LintRequestName reports requests without a name.
+func LintRequestName(path []string, item Item) []LintFinding {
+	if strings.TrimSpace(item.Name) != "" {
+		return nil
+	}
+
+	return []LintFinding{{
+		RuleID:   "request-name",
+		Severity: LintError,
+		Path:     path,
+		Message:  "request has no name",
+	}}
+}
+
+// LintURLHostVariable reports requests whose URL host is hardcoded rather
+// than supplied by a variable.
+func LintURLHostVariable(path []string, item Item) []LintFinding {
+	host := urlHost(rawRequestURL(item.Request))
+	if host == "" || strings.Contains(host, "{{") {
+		return nil
+	}
+
+	return []LintFinding{{
+		RuleID:   "url-host-variable",
+		Severity: LintWarning,
+		Path:     path,
+		Message:  fmt.Sprintf("URL host %q is hardcoded, use a variable instead", host),
+	}}
+}
+
+// LintRequestExamples reports requests without any saved example responses.
+func LintRequestExamples(path []string, item Item) []LintFinding {
+	if len(item.Response) > 0 {
+		return nil
+	}
+
+	return []LintFinding{{
+		RuleID:   "request-examples",
+		Severity: LintInfo,
+		Path:     path,
+		Message:  "request has no examples",
+	}}
+}
+
+// LintRequestTests reports requests without a test script.
+func LintRequestTests(path []string, item Item) []LintFinding {
+	if hasTestScript(item.Events) {
+		return nil
+	}
+
+	return []LintFinding{{
+		RuleID:   "request-tests",
+		Severity: LintWarning,
+		Path:     path,
+		Message:  "request has no tests",
+	}}
+}
+
+// hasTestScript reports whether any enabled event is a non-empty test
+// script.
+func hasTestScript(events []Event) bool {
+	for _, e := range events {
+		if e.Event != nil && e.Listen == "test" && !e.Disabled && hasScript(e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasScript(e Event) bool {
+	if e.Script == nil {
+		return false
+	}
+
+	switch exec := e.Script.Exec.(type) {
+	case string:
+		return strings.TrimSpace(exec) != ""
+	case []interface{}:
+		for _, line := range exec {
+			if s, ok := line.(string); ok && strings.TrimSpace(s) != "" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// walkItemTree calls fn for every request in the tree in declared order,
+// passing the folder names and the item name leading to it.
+func walkItemTree(node *ItemTreeNode, path []string, fn func(path []string, item Item)) {
+	if node.ItemGroup != nil && node.ItemGroup.ItemGroup != nil {
+		path = appendPath(path, node.ItemGroup.Name)
+	}
+
+	node.eachChild(func(branch *ItemTreeNode, it *Item) {
+		if branch != nil {
+			walkItemTree(branch, path, fn)
+			return
+		}
+
+		name := ""
+		if it.Item != nil {
+			name = it.Name
+		}
+		fn(appendPath(path, name), *it)
+	})
+}
+
+func appendPath(path []string, name string) []string {
+	p := make([]string, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, name)
+}
+
+// rawRequestURL returns the raw URL of a request, which may be a string or
+// an object.
+func rawRequestURL(request interface{}) string {
+	var u interface{}
+	switch r := request.(type) {
+	case string:
+		return r
+	case map[string]interface{}:
+		u = r["url"]
+	}
+
+	switch v := u.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if raw, ok := v["raw"].(string); ok {
+			return raw
+		}
+	}
+
+	return ""
+}
+
+// urlHost returns the host portion of a raw, possibly templated, URL.
+func urlHost(raw string) string {
+	if i := strings.Index(raw, "://"); i >= 0 {
+		raw = raw[i+3:]
+	}
+
+	if i := strings.IndexAny(raw, "/?#"); i >= 0 {
+		raw = raw[:i]
+	}
+
+	return raw
+}
@@ -0,0 +1,113 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func unmarshalCollection(t *testing.T, subject string) *resources.Collection {
+	t.Helper()
+
+	var c resources.Collection
+	if err := json.Unmarshal([]byte(subject), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	return &c
+}
+
+const lintSubject = `{
+  "info": {"name": "lint", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "item": [
+    {
+      "name": "Users",
+      "item": [
+        {
+          "name": "List Users",
+          "request": {"method": "GET", "url": {"raw": "{{baseUrl}}/users"}},
+          "response": [{"name": "OK", "code": 200}],
+          "event": [{"listen": "test", "script": {"exec": ["pm.test('ok', () => {});"]}}]
+        },
+        {
+          "request": {"method": "POST", "url": "https://api.example.com/users"}
+        }
+      ]
+    }
+  ]
+}`
+
+func findingsFor(findings []resources.LintFinding, ruleID string) []resources.LintFinding {
+	var ret []resources.LintFinding
+	for _, f := range findings {
+		if f.RuleID == ruleID {
+			ret = append(ret, f)
+		}
+	}
+
+	return ret
+}
+
+func TestLintDefaultRules(t *testing.T) {
+	c := unmarshalCollection(t, lintSubject)
+	findings := c.Lint()
+
+	if len(findings) != 4 {
+		t.Fatalf("Incorrect number of findings, have: %d, want: %d (%v)", len(findings), 4, findings)
+	}
+
+	for _, ruleID := range []string{"request-name", "url-host-variable", "request-examples", "request-tests"} {
+		f := findingsFor(findings, ruleID)
+		if len(f) != 1 {
+			t.Errorf("Expected one finding for rule %s, have: %d", ruleID, len(f))
+			continue
+		}
+
+		if strings.Join(f[0].Path, "/") != "Users/" {
+			t.Errorf("Incorrect finding path, have: %s, want: %s", strings.Join(f[0].Path, "/"), "Users/")
+		}
+	}
+
+	if f := findingsFor(findings, "request-name"); len(f) == 1 && f[0].Severity != resources.LintError {
+		t.Errorf("Incorrect severity, have: %s, want: %s", f[0].Severity, resources.LintError)
+	}
+
+	if f := findingsFor(findings, "url-host-variable"); len(f) == 1 && !strings.Contains(f[0].Message, "api.example.com") {
+		t.Errorf("Expected message to contain host, have: %s", f[0].Message)
+	}
+}
+
+func TestLintCustomRule(t *testing.T) {
+	c := unmarshalCollection(t, lintSubject)
+
+	rule := func(path []string, item resources.Item) []resources.LintFinding {
+		return []resources.LintFinding{{RuleID: "custom", Severity: resources.LintInfo, Path: path}}
+	}
+
+	findings := c.Lint(rule)
+	if len(findings) != 2 {
+		t.Fatalf("Incorrect number of findings, have: %d, want: %d", len(findings), 2)
+	}
+
+	if strings.Join(findings[0].Path, "/") != "Users/List Users" {
+		t.Errorf("Incorrect finding path, have: %s, want: %s", strings.Join(findings[0].Path, "/"), "Users/List Users")
+	}
+}
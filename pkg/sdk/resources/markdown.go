@@ -0,0 +1,171 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+// ToMarkdown renders the collection as a Markdown document with a section
+// per folder and request.  Requests show their method, URL, headers, body,
+// description, and example responses, with variables resolved from the
+// scopes given by ItemScopes for each request.
+func (c *Collection) ToMarkdown(scopes ...VariableScope) string {
+	var b strings.Builder
+
+	name := ""
+	if c.Collection != nil && c.Info != nil {
+		name = c.Info.Name
+	}
+	fmt.Fprintf(&b, "# %s\n", name)
+	writeMarkdownText(&b, c.Description())
+
+	if c.Items != nil {
+		c.writeMarkdownNode(&b, &c.Items.Root, 2, nil, scopes)
+	}
+
+	return b.String()
+}
+
+func (c *Collection) writeMarkdownNode(b *strings.Builder, node *ItemTreeNode, level int, folders []string, scopes []VariableScope) {
+	node.eachChild(func(branch *ItemTreeNode, it *Item) {
+		switch {
+		case branch == nil:
+			if it.Item != nil {
+				writeMarkdownItem(b, it.Item, level, c.requestScopes(appendPath(folders, it.Name), scopes))
+			}
+		case branch.ItemGroup == nil || branch.ItemGroup.ItemGroup == nil:
+			c.writeMarkdownNode(b, branch, level, folders, scopes)
+		default:
+			fmt.Fprintf(b, "\n%s %s\n", markdownHeading(level), branch.ItemGroup.Name)
+			writeMarkdownText(b, descriptionText(branch.ItemGroup.Description))
+			c.writeMarkdownNode(b, branch, level+1, appendPath(folders, branch.ItemGroup.Name), scopes)
+		}
+	})
+}
+
+func writeMarkdownItem(b *strings.Builder, item *gen.Item, level int, scopes []VariableScope) {
+	fmt.Fprintf(b, "\n%s %s\n", markdownHeading(level), item.Name)
+
+	r, err := ParseRequest(item.Request)
+	if err != nil || r == nil {
+		return
+	}
+
+	method := r.Method
+	if method == "" {
+		method = "GET"
+	}
+	fmt.Fprintf(b, "\n`%s %s`\n", strings.ToUpper(method), r.ResolvedURL(scopes...))
+
+	description := descriptionText(item.Description)
+	if m, ok := item.Request.(map[string]interface{}); ok && description == "" {
+		description = descriptionText(m["description"])
+	}
+	writeMarkdownText(b, description)
+
+	if headers := enabledHeaders(r.Header); len(headers) > 0 {
+		b.WriteString("\n**Headers**\n\n")
+		for _, h := range headers {
+			fmt.Fprintf(b, "- `%s: %s`\n", ResolveVariables(h.Key, scopes...), ResolveVariables(h.Value, scopes...))
+		}
+	}
+
+	writeMarkdownBody(b, r.Body, scopes)
+
+	if len(item.Response) > 0 {
+		b.WriteString("\n**Example responses**\n")
+		for _, res := range item.Response {
+			if res == nil {
+				continue
+			}
+
+			status := strings.TrimSpace(fmt.Sprintf("%d %s", res.Code, res.Status))
+			if res.Code == 0 {
+				status = res.Status
+			}
+			fmt.Fprintf(b, "\n%s %s\n", markdownHeading(level+1), status)
+
+			if body, ok := res.Body.(string); ok && body != "" {
+				writeMarkdownCode(b, "", body)
+			}
+		}
+	}
+}
+
+func writeMarkdownBody(b *strings.Builder, body *Body, scopes []VariableScope) {
+	if body == nil || body.Disabled {
+		return
+	}
+
+	switch body.Mode {
+	case BodyModeRaw:
+		if body.Raw == "" {
+			return
+		}
+		b.WriteString("\n**Body**\n")
+		writeMarkdownCode(b, body.Language(), ResolveVariables(body.Raw, scopes...))
+	case BodyModeURLEncoded:
+		b.WriteString("\n**Body**\n\n")
+		for _, p := range body.URLEncoded {
+			if !p.Disabled {
+				fmt.Fprintf(b, "- `%s=%s`\n", ResolveVariables(p.Key, scopes...), ResolveVariables(p.Value, scopes...))
+			}
+		}
+	case BodyModeFormData:
+		b.WriteString("\n**Body**\n\n")
+		for _, p := range body.FormData {
+			if p.Disabled {
+				continue
+			}
+			if p.Type == "file" {
+				fmt.Fprintf(b, "- `%s` (file)\n", ResolveVariables(p.Key, scopes...))
+			} else {
+				fmt.Fprintf(b, "- `%s=%s`\n", ResolveVariables(p.Key, scopes...), ResolveVariables(p.Value, scopes...))
+			}
+		}
+	case BodyModeGraphQL:
+		if body.GraphQL == nil {
+			return
+		}
+		b.WriteString("\n**Body**\n")
+		writeMarkdownCode(b, "graphql", ResolveVariables(body.GraphQL.Query, scopes...))
+	}
+}
+
+func writeMarkdownText(b *strings.Builder, s string) {
+	if s = strings.TrimSpace(s); s != "" {
+		fmt.Fprintf(b, "\n%s\n", s)
+	}
+}
+
+func writeMarkdownCode(b *strings.Builder, language, s string) {
+	fmt.Fprintf(b, "\n```%s\n%s\n```\n", language, strings.TrimRight(s, "\n"))
+}
+
+// markdownHeading returns the heading marker for level, capped at the six
+// levels Markdown supports.
+func markdownHeading(level int) string {
+	if level > 6 {
+		level = 6
+	}
+
+	return strings.Repeat("#", level)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+const markdownSubject = `{
+	"info": {"name": "Users API", "description": "Manage *users*.", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+	"variable": [{"key": "baseUrl", "value": "https://api.example.com"}],
+	"item": [
+		{"name": "Users", "description": {"content": "User endpoints.", "type": "text/markdown"}, "item": [
+			{
+				"name": "Create User",
+				"description": "Creates a **user**.",
+				"request": {
+					"method": "POST",
+					"url": "{{baseUrl}}/users",
+					"header": [{"key": "Authorization", "value": "Bearer {{token}}"}],
+					"body": {"mode": "raw", "raw": "{\"name\":\"ada\"}", "options": {"raw": {"language": "json"}}}
+				},
+				"response": [{"name": "Created", "code": 201, "status": "Created", "body": "{\"id\":1}"}]
+			}
+		]},
+		{"name": "Health", "request": "{{baseUrl}}/health"}
+	]
+}`
+
+func TestCollectionToMarkdown(t *testing.T) {
+	c := unmarshalCollection(t, markdownSubject)
+
+	have := c.ToMarkdown(resources.VariableScope{"token": "secret"})
+
+	for _, want := range []string{
+		"# Users API\n\nManage *users*.\n",
+		"\n## Users\n\nUser endpoints.\n",
+		"\n### Create User\n\n`POST https://api.example.com/users`\n\nCreates a **user**.\n",
+		"- `Authorization: Bearer secret`\n",
+		"\n**Body**\n\n```json\n{\"name\":\"ada\"}\n```\n",
+		"\n**Example responses**\n\n#### 201 Created\n\n```\n{\"id\":1}\n```\n",
+		"\n## Health\n\n`GET https://api.example.com/health`\n",
+	} {
+		if !strings.Contains(have, want) {
+			t.Errorf("Expected markdown to contain %q, have:\n%s", want, have)
+		}
+	}
+}
+
+func TestCollectionToMarkdownKeepsUnresolvedVariables(t *testing.T) {
+	c := unmarshalCollection(t, markdownSubject)
+
+	if have := c.ToMarkdown(); !strings.Contains(have, "- `Authorization: Bearer {{token}}`\n") {
+		t.Errorf("Expected unresolved variables to be kept, have:\n%s", have)
+	}
+}
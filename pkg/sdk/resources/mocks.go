@@ -47,6 +47,28 @@ type MockListItem struct {
 	Environment string     `json:"environment"`
 }
 
+// MockCollectionListItems is a slice of MockCollectionListItem.
+type MockCollectionListItems []MockCollectionListItem
+
+// Format returns column headers and values for the resource.
+func (r MockCollectionListItems) Format() ([]string, []interface{}) {
+	s := make([]interface{}, len(r))
+	for i, v := range r {
+		s[i] = v
+	}
+
+	return []string{"UID", "Name", "Collection", "CollectionName"}, s
+}
+
+// MockCollectionListItem is a MockListItem along with the name of the
+// collection backing the mock.  CollectionMissing is set when the
+// collection no longer exists.
+type MockCollectionListItem struct {
+	MockListItem
+	CollectionName    string `json:"collectionName"`
+	CollectionMissing bool   `json:"collectionMissing,omitempty"`
+}
+
 // MockResponse is the top-level mock response from the
 // Postman API.
 type MockResponse struct {
@@ -54,6 +76,7 @@ type MockResponse struct {
 }
 
 // Mock represents a representation of a mock server from the Postman API.
+// Fields the SDK doesn't model are kept in Extras.
 type Mock struct {
 	ID          string     `json:"id"`
 	Owner       string     `json:"owner"`
@@ -63,6 +86,7 @@ type Mock struct {
 	Name        string     `json:"name"`
 	Config      MockConfig `json:"config"`
 	Environment string     `json:"environment"`
+	Extras      Extras     `json:"-"`
 }
 
 // MockConfig represents the configuration of a mock server.
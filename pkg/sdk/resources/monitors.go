@@ -18,7 +18,9 @@ package resources
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -82,7 +84,7 @@ type MonitorResponse struct {
 }
 
 // Monitor represents the single monitor response from the
-// Postman API
+// Postman API.  Fields the SDK doesn't model are kept in Extras.
 type Monitor struct {
 	ID             string         `json:"id"`
 	Name           string         `json:"name"`
@@ -94,6 +96,7 @@ type Monitor struct {
 	Notifications  Notifications  `json:"notifications"`
 	Distribution   []interface{}  `json:"distribution"`
 	Schedule       Schedule       `json:"schedule"`
+	Extras         Extras         `json:"-"`
 }
 
 type monitor struct {
@@ -135,7 +138,10 @@ func (r *Monitor) UnmarshalJSON(data []byte) error {
 	r.Distribution = m.Distribution
 	r.Schedule = m.Schedule
 
-	return nil
+	var err error
+	r.Extras, err = decodeExtras(data, monitorFields)
+
+	return err
 }
 
 // MonitorSlice is a slice of Monitor.
@@ -181,3 +187,223 @@ type Schedule struct {
 	Timezone string    `json:"timezone"`
 	NextRun  time.Time `json:"nextRun"`
 }
+
+// cronFields holds the name and allowed range of each field in a five-field
+// cron expression.
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ValidateCron checks that expr is a five-field cron expression usable as a
+// monitor schedule.
+func ValidateCron(expr string) error {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("invalid cron expression %q: expected %d fields, got %d", expr, len(cronFields), len(fields))
+	}
+
+	for i, f := range fields {
+		spec := cronFields[i]
+		for _, part := range strings.Split(f, ",") {
+			if err := validateCronPart(part, spec.min, spec.max); err != nil {
+				return fmt.Errorf("invalid cron expression %q: %s field: %s", expr, spec.name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateCronPart(part string, min, max int) error {
+	rng := part
+	if i := strings.Index(part, "/"); i >= 0 {
+		rng = part[:i]
+		step, err := strconv.Atoi(part[i+1:])
+		if err != nil || step < 1 {
+			return fmt.Errorf("invalid step %q", part[i+1:])
+		}
+	}
+
+	if rng == "*" {
+		return nil
+	}
+
+	bounds := strings.SplitN(rng, "-", 2)
+	values := make([]int, len(bounds))
+	for i, b := range bounds {
+		v, err := strconv.Atoi(b)
+		if err != nil {
+			return fmt.Errorf("invalid value %q", b)
+		}
+		if v < min || v > max {
+			return fmt.Errorf("value %d out of range %d-%d", v, min, max)
+		}
+		values[i] = v
+	}
+
+	if len(values) == 2 && values[0] > values[1] {
+		return fmt.Errorf("invalid range %q", rng)
+	}
+
+	return nil
+}
+
+// MonitorRunListResponse represents a page of the monitor run history
+// response from the Postman API.
+type MonitorRunListResponse struct {
+	Runs MonitorRuns `json:"runs"`
+	Meta struct {
+		NextCursor string `json:"nextCursor"`
+	} `json:"meta"`
+}
+
+// MonitorRuns is a slice of MonitorRun.
+type MonitorRuns []MonitorRun
+
+// Format returns column headers and values for the resource.
+func (r MonitorRuns) Format() ([]string, []interface{}) {
+	s := make([]interface{}, len(r))
+	for i, v := range r {
+		s[i] = v
+	}
+
+	return []string{"ID", "Status", "StartedAt"}, s
+}
+
+// MonitorRunList is a list of monitor runs along with its pagination
+// details.
+type MonitorRunList struct {
+	Items MonitorRuns
+	PageInfo
+}
+
+// Format returns column headers and values for the resource.
+func (r MonitorRunList) Format() ([]string, []interface{}) {
+	return r.Items.Format()
+}
+
+// MonitorRun represents a single run in the history of a monitor.
+type MonitorRun struct {
+	ID         string          `json:"id"`
+	Status     string          `json:"status"`
+	StartedAt  time.Time       `json:"startedAt"`
+	FinishedAt time.Time       `json:"finishedAt"`
+	Stats      MonitorRunStats `json:"stats"`
+}
+
+// Failed reports whether the run failed or had failed requests or
+// assertions.
+func (r MonitorRun) Failed() bool {
+	return r.Status == RunStatusFailed || r.Stats.Requests.Failed > 0 || r.Stats.Assertions.Failed > 0
+}
+
+// MonitorRunStats holds totals for the requests and assertions of a run.
+type MonitorRunStats struct {
+	Assertions MonitorRunCount `json:"assertions"`
+	Requests   MonitorRunCount `json:"requests"`
+}
+
+// MonitorRunCount is a total and failed count.
+type MonitorRunCount struct {
+	Total  int `json:"total"`
+	Failed int `json:"failed"`
+}
+
+// MonitorRunResponse represents the top-level response of running a monitor
+// from the Postman API.
+type MonitorRunResponse struct {
+	Run RunSummary `json:"run"`
+}
+
+// RunSummary represents the results of a monitor or collection run.
+// Environment holds the state of the run's environment after execution,
+// including variables set by scripts, when the run reports it.
+type RunSummary struct {
+	Info        RunInfo         `json:"info"`
+	Stats       MonitorRunStats `json:"stats"`
+	Executions  []RunExecution  `json:"executions"`
+	Environment *Environment    `json:"environment,omitempty"`
+}
+
+// ResultingEnvironment returns a copy of the environment as it was left by
+// the run, so it can be used as the starting state of a later run.  It
+// returns nil when the run reports no environment.
+func (r *RunSummary) ResultingEnvironment() *Environment {
+	if r.Environment == nil {
+		return nil
+	}
+
+	env := *r.Environment
+	env.Values = append([]KeyValuePair(nil), r.Environment.Values...)
+
+	return &env
+}
+
+// RunInfo describes a run.
+type RunInfo struct {
+	JobID          string    `json:"jobId"`
+	MonitorID      string    `json:"monitorId"`
+	Name           string    `json:"name"`
+	CollectionUID  string    `json:"collectionUid"`
+	EnvironmentUID string    `json:"environmentUid"`
+	Status         string    `json:"status"`
+	StartedAt      time.Time `json:"startedAt"`
+	FinishedAt     time.Time `json:"finishedAt"`
+}
+
+// RunExecution is a single request sent during a run.  Response is nil when
+// the request failed without a response.
+type RunExecution struct {
+	ID         int            `json:"id"`
+	Item       RunItem        `json:"item"`
+	Request    RunRequest     `json:"request"`
+	Response   *RunResponse   `json:"response,omitempty"`
+	Error      *RunError      `json:"error,omitempty"`
+	Assertions []RunAssertion `json:"assertions,omitempty"`
+}
+
+// RunAssertion is the result of a single test assertion of an execution.
+// Error is nil when the assertion passed.
+type RunAssertion struct {
+	Assertion string    `json:"assertion"`
+	Skipped   bool      `json:"skipped,omitempty"`
+	Error     *RunError `json:"error,omitempty"`
+}
+
+// RunItem identifies the collection item an execution ran.
+type RunItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// RunRequest is the request sent by an execution.
+type RunRequest struct {
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers"`
+	Body      json.RawMessage   `json:"body,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// RunResponse is the response received by an execution.  ResponseTime is in
+// milliseconds.
+type RunResponse struct {
+	Code         int               `json:"code"`
+	Headers      map[string]string `json:"headers"`
+	Body         json.RawMessage   `json:"body,omitempty"`
+	ResponseSize int               `json:"responseSize"`
+	ResponseTime int               `json:"responseTime"`
+}
+
+// RunError describes why an execution failed without a response.
+type RunError struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,70 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestRunSummaryResultingEnvironment(t *testing.T) {
+	subject := `{
+	  "run": {
+	    "info": {"name": "Login flow", "status": "success"},
+	    "executions": [
+	      {"id": 1, "item": {"name": "Login"}, "request": {"method": "POST", "url": "https://api.example.com/login"}, "response": {"code": 200}}
+	    ],
+	    "environment": {
+	      "id": "5daabc50-8451-43f6-922d-96b403b4f28e",
+	      "name": "Staging",
+	      "values": [
+	        {"key": "baseUrl", "value": "https://api.example.com", "enabled": true, "type": "default"},
+	        {"key": "sessionToken", "value": "eyJhbGciOi", "enabled": true, "type": "secret"}
+	      ]
+	    }
+	  }
+	}`
+
+	var resp resources.MonitorRunResponse
+	if err := json.Unmarshal([]byte(subject), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	env := resp.Run.ResultingEnvironment()
+	if env == nil {
+		t.Fatal("Expected a resulting environment.")
+	}
+
+	scope := resources.EnvironmentScope(env)
+	if scope["sessionToken"] != "eyJhbGciOi" {
+		t.Errorf("Variable set by the run is missing, have: %v", scope)
+	}
+
+	env.Values[0].Value = "changed"
+	if resp.Run.Environment.Values[0].Value != "https://api.example.com" {
+		t.Error("Expected the resulting environment to be a copy.")
+	}
+}
+
+func TestRunSummaryWithoutEnvironment(t *testing.T) {
+	var run resources.RunSummary
+	if env := run.ResultingEnvironment(); env != nil {
+		t.Errorf("Expected no resulting environment, have: %+v", env)
+	}
+}
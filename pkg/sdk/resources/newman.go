@@ -0,0 +1,285 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+type newmanReport struct {
+	Collection  newmanCollection   `json:"collection"`
+	Environment *newmanEnvironment `json:"environment,omitempty"`
+	Run         newmanRun          `json:"run"`
+}
+
+type newmanCollection struct {
+	Info newmanCollectionInfo `json:"info"`
+}
+
+type newmanCollectionInfo struct {
+	ID   string `json:"_postman_id,omitempty"`
+	Name string `json:"name"`
+}
+
+type newmanEnvironment struct {
+	ID     string         `json:"id,omitempty"`
+	Name   string         `json:"name"`
+	Values []KeyValuePair `json:"values"`
+}
+
+type newmanRun struct {
+	Stats      newmanStats       `json:"stats"`
+	Timings    newmanTimings     `json:"timings"`
+	Executions []newmanExecution `json:"executions"`
+	Transfers  newmanTransfers   `json:"transfers"`
+	Failures   []newmanFailure   `json:"failures"`
+	Error      interface{}       `json:"error"`
+}
+
+type newmanStats struct {
+	Iterations        newmanCount `json:"iterations"`
+	Items             newmanCount `json:"items"`
+	Scripts           newmanCount `json:"scripts"`
+	Prerequests       newmanCount `json:"prerequests"`
+	Requests          newmanCount `json:"requests"`
+	Tests             newmanCount `json:"tests"`
+	Assertions        newmanCount `json:"assertions"`
+	TestScripts       newmanCount `json:"testScripts"`
+	PrerequestScripts newmanCount `json:"prerequestScripts"`
+}
+
+type newmanCount struct {
+	Total   int `json:"total"`
+	Pending int `json:"pending"`
+	Failed  int `json:"failed"`
+}
+
+type newmanTimings struct {
+	ResponseAverage float64 `json:"responseAverage"`
+	ResponseMin     int     `json:"responseMin"`
+	ResponseMax     int     `json:"responseMax"`
+	Started         int64   `json:"started"`
+	Completed       int64   `json:"completed"`
+}
+
+type newmanTransfers struct {
+	ResponseTotal int `json:"responseTotal"`
+}
+
+type newmanExecution struct {
+	ID         string          `json:"id"`
+	Cursor     newmanCursor    `json:"cursor"`
+	Item       newmanItem      `json:"item"`
+	Request    newmanRequest   `json:"request"`
+	Response   *newmanResponse `json:"response,omitempty"`
+	Assertions []interface{}   `json:"assertions"`
+	RequestErr *RunError       `json:"requestError,omitempty"`
+}
+
+type newmanCursor struct {
+	Position  int    `json:"position"`
+	Iteration int    `json:"iteration"`
+	Length    int    `json:"length"`
+	Cycles    int    `json:"cycles"`
+	Ref       string `json:"ref"`
+}
+
+type newmanItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type newmanRequest struct {
+	URL    string           `json:"url"`
+	Method string           `json:"method"`
+	Header []newmanKeyValue `json:"header"`
+	Body   *newmanBody      `json:"body,omitempty"`
+}
+
+type newmanBody struct {
+	Mode string `json:"mode"`
+	Raw  string `json:"raw"`
+}
+
+type newmanResponse struct {
+	Status       string           `json:"status"`
+	Code         int              `json:"code"`
+	Header       []newmanKeyValue `json:"header"`
+	Stream       newmanStream     `json:"stream"`
+	ResponseTime int              `json:"responseTime"`
+	ResponseSize int              `json:"responseSize"`
+}
+
+// newmanStream is a response body in the form newman serializes a Node.js
+// Buffer.
+type newmanStream struct {
+	Type string `json:"type"`
+	Data []int  `json:"data"`
+}
+
+type newmanKeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type newmanFailure struct {
+	Error  newmanError  `json:"error"`
+	At     string       `json:"at"`
+	Source newmanItem   `json:"source"`
+	Cursor newmanCursor `json:"cursor"`
+}
+
+type newmanError struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// ToNewmanJSON returns the run in the format written by newman's json
+// reporter, so it can be consumed by tools that ingest newman reports.  A
+// run is reported as a single iteration, and failed requests are reported
+// as failures.
+func (r *RunSummary) ToNewmanJSON() ([]byte, error) {
+	report := newmanReport{
+		Collection: newmanCollection{Info: newmanCollectionInfo{ID: r.Info.CollectionUID, Name: r.Info.Name}},
+		Run: newmanRun{
+			Stats: newmanStats{
+				Iterations: newmanCount{Total: 1},
+				Items:      newmanCount{Total: len(r.Executions)},
+				Requests:   newmanCount{Total: r.Stats.Requests.Total, Failed: r.Stats.Requests.Failed},
+				Assertions: newmanCount{Total: r.Stats.Assertions.Total, Failed: r.Stats.Assertions.Failed},
+			},
+			Executions: make([]newmanExecution, len(r.Executions)),
+			Failures:   []newmanFailure{},
+		},
+	}
+
+	if !r.Info.StartedAt.IsZero() {
+		report.Run.Timings.Started = r.Info.StartedAt.UnixNano() / 1e6
+	}
+	if !r.Info.FinishedAt.IsZero() {
+		report.Run.Timings.Completed = r.Info.FinishedAt.UnixNano() / 1e6
+	}
+
+	if r.Environment != nil {
+		report.Environment = &newmanEnvironment{
+			ID:     r.Environment.ID,
+			Name:   r.Environment.Name,
+			Values: append([]KeyValuePair{}, r.Environment.Values...),
+		}
+	}
+
+	var responses, responseTotal int
+	for i, e := range r.Executions {
+		cursor := newmanCursor{Position: i, Length: len(r.Executions), Ref: e.Item.ID}
+		execution := newmanRunExecution(e, cursor)
+		report.Run.Executions[i] = execution
+
+		if e.Response != nil {
+			t := e.Response.ResponseTime
+			if responses == 0 || t < report.Run.Timings.ResponseMin {
+				report.Run.Timings.ResponseMin = t
+			}
+			if t > report.Run.Timings.ResponseMax {
+				report.Run.Timings.ResponseMax = t
+			}
+			responses++
+			responseTotal += t
+			report.Run.Transfers.ResponseTotal += e.Response.ResponseSize
+		}
+
+		if e.Error != nil {
+			report.Run.Stats.Items.Failed++
+			report.Run.Failures = append(report.Run.Failures, newmanFailure{
+				Error:  newmanError{Name: e.Error.Name, Message: e.Error.Message},
+				At:     "request",
+				Source: execution.Item,
+				Cursor: cursor,
+			})
+		}
+	}
+
+	if responses > 0 {
+		report.Run.Timings.ResponseAverage = float64(responseTotal) / float64(responses)
+	}
+
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// newmanRunExecution converts a single run execution into a newman
+// execution.
+func newmanRunExecution(e RunExecution, cursor newmanCursor) newmanExecution {
+	method := strings.ToUpper(e.Request.Method)
+	if method == "" {
+		method = "GET"
+	}
+
+	execution := newmanExecution{
+		ID:     e.Item.ID,
+		Cursor: cursor,
+		Item:   newmanItem{ID: e.Item.ID, Name: e.Item.Name},
+		Request: newmanRequest{
+			URL:    e.Request.URL,
+			Method: method,
+			Header: newmanHeaders(e.Request.Headers),
+		},
+		Assertions: []interface{}{},
+		RequestErr: e.Error,
+	}
+
+	for _, a := range e.Assertions {
+		execution.Assertions = append(execution.Assertions, a)
+	}
+
+	if text := runBodyText(e.Request.Body); text != "" {
+		execution.Request.Body = &newmanBody{Mode: BodyModeRaw, Raw: text}
+	}
+
+	if e.Response == nil {
+		return execution
+	}
+
+	text := runBodyText(e.Response.Body)
+	data := make([]int, len(text))
+	for i := 0; i < len(text); i++ {
+		data[i] = int(text[i])
+	}
+
+	execution.Response = &newmanResponse{
+		Status:       http.StatusText(e.Response.Code),
+		Code:         e.Response.Code,
+		Header:       newmanHeaders(e.Response.Headers),
+		Stream:       newmanStream{Type: "Buffer", Data: data},
+		ResponseTime: e.Response.ResponseTime,
+		ResponseSize: e.Response.ResponseSize,
+	}
+
+	return execution
+}
+
+// newmanHeaders converts a header map into key/value pairs sorted by key.
+func newmanHeaders(headers map[string]string) []newmanKeyValue {
+	ret := make([]newmanKeyValue, 0, len(headers))
+	for k, v := range headers {
+		ret = append(ret, newmanKeyValue{Key: k, Value: v})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Key < ret[j].Key })
+
+	return ret
+}
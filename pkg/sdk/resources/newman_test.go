@@ -0,0 +1,134 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestRunSummaryToNewmanJSON(t *testing.T) {
+	var resp resources.MonitorRunResponse
+	if err := json.Unmarshal([]byte(runSubject), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := resp.Run.ToNewmanJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type count struct {
+		Total   int `json:"total"`
+		Pending int `json:"pending"`
+		Failed  int `json:"failed"`
+	}
+
+	var report struct {
+		Collection struct {
+			Info struct {
+				Name string `json:"name"`
+			} `json:"info"`
+		} `json:"collection"`
+		Run struct {
+			Stats struct {
+				Iterations count `json:"iterations"`
+				Items      count `json:"items"`
+				Requests   count `json:"requests"`
+				Assertions count `json:"assertions"`
+			} `json:"stats"`
+			Timings struct {
+				Started   int64 `json:"started"`
+				Completed int64 `json:"completed"`
+			} `json:"timings"`
+			Executions []struct {
+				Item struct {
+					Name string `json:"name"`
+				} `json:"item"`
+				Request struct {
+					Method string `json:"method"`
+					URL    string `json:"url"`
+				} `json:"request"`
+				Response *struct {
+					Code   int    `json:"code"`
+					Status string `json:"status"`
+					Stream struct {
+						Type string `json:"type"`
+						Data []int  `json:"data"`
+					} `json:"stream"`
+				} `json:"response"`
+			} `json:"executions"`
+			Failures []struct {
+				Error struct {
+					Message string `json:"message"`
+				} `json:"error"`
+				At     string `json:"at"`
+				Source struct {
+					Name string `json:"name"`
+				} `json:"source"`
+			} `json:"failures"`
+		} `json:"run"`
+	}
+
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Collection.Info.Name != "Health" {
+		t.Errorf("Collection name is incorrect, have: %s, want: %s", report.Collection.Info.Name, "Health")
+	}
+
+	stats := report.Run.Stats
+	if stats.Iterations.Total != 1 || stats.Items.Total != 2 || stats.Items.Failed != 1 {
+		t.Errorf("Iteration and item stats are incorrect, have: %+v", stats)
+	}
+
+	if stats.Requests.Total != 2 || stats.Requests.Failed != 1 || stats.Assertions.Total != 2 || stats.Assertions.Failed != 1 {
+		t.Errorf("Request and assertion stats are incorrect, have: %+v", stats)
+	}
+
+	if have, want := report.Run.Timings.Completed-report.Run.Timings.Started, int64(2000); have != want {
+		t.Errorf("Run duration is incorrect, have: %d, want: %d", have, want)
+	}
+
+	if len(report.Run.Executions) != 2 {
+		t.Fatalf("Expected 2 executions, have: %d", len(report.Run.Executions))
+	}
+
+	created := report.Run.Executions[0]
+	if created.Item.Name != "Create user" || created.Request.Method != "POST" || created.Response == nil || created.Response.Code != 201 || created.Response.Status != "Created" {
+		t.Errorf("Execution is incorrect, have: %+v", created)
+	}
+
+	if created.Response != nil && (created.Response.Stream.Type != "Buffer" || len(created.Response.Stream.Data) != len(`{"id":42}`)) {
+		t.Errorf("Response stream is incorrect, have: %+v", created.Response.Stream)
+	}
+
+	if report.Run.Executions[1].Response != nil {
+		t.Errorf("Expected no response for the failed execution, have: %+v", report.Run.Executions[1].Response)
+	}
+
+	if len(report.Run.Failures) != 1 {
+		t.Fatalf("Expected 1 failure, have: %d", len(report.Run.Failures))
+	}
+
+	if f := report.Run.Failures[0]; f.Source.Name != "Health" || f.At != "request" || f.Error.Message != "getaddrinfo ENOTFOUND down.example.com" {
+		t.Errorf("Failure is incorrect, have: %+v", f)
+	}
+}
@@ -0,0 +1,426 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// SpecError is a single structural problem found in an OpenAPI spec.  Line
+// and Column are 1-based and point at the offending key, or at the closest
+// enclosing key when the problem is a missing field.  They are zero when the
+// position is unknown.
+type SpecError struct {
+	Line    int
+	Column  int
+	Path    []string
+	Message string
+}
+
+func (e SpecError) String() string {
+	location := strings.Join(e.Path, ".")
+	if location == "" {
+		location = "(root)"
+	}
+
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", location, e.Message)
+	}
+
+	return fmt.Sprintf("line %d, column %d: %s: %s", e.Line, e.Column, location, e.Message)
+}
+
+var (
+	openAPIVersionPattern = regexp.MustCompile(`^3\.[01]\.\d+$`)
+	yamlErrorLinePattern  = regexp.MustCompile(`line (\d+)`)
+	yamlKeyPattern        = regexp.MustCompile(`^(\s*)((?:-\s+)*)("[^"]*"|'[^']*'|[^\s#'"][^:#]*?)\s*:(?:\s|$)`)
+)
+
+var openAPIOperations = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// ValidateOpenAPI checks the structure of an OpenAPI 3.0 or 3.1 spec, given
+// as JSON or YAML, before it is imported.  It returns nil when no problems
+// are found.
+func ValidateOpenAPI(spec []byte) []SpecError {
+	var (
+		doc       interface{}
+		positions map[string]specPosition
+		err       error
+	)
+
+	if isJSONSpec(spec) {
+		doc, positions, err = parseJSONSpec(spec)
+	} else {
+		doc, positions, err = parseYAMLSpec(spec)
+	}
+	if err != nil {
+		return []SpecError{specSyntaxError(spec, err)}
+	}
+
+	v := specValidator{positions: positions}
+	v.validate(doc)
+
+	return v.errors
+}
+
+// Content types of OpenAPI specs.
+const (
+	SpecContentTypeJSON = "application/json"
+	SpecContentTypeYAML = "text/yaml"
+)
+
+// SpecContentType returns the content type of an OpenAPI spec, detected from
+// whether it is a JSON object or YAML.
+func SpecContentType(spec []byte) string {
+	if isJSONSpec(spec) {
+		return SpecContentTypeJSON
+	}
+
+	return SpecContentTypeYAML
+}
+
+func isJSONSpec(spec []byte) bool {
+	trimmed := bytes.TrimSpace(spec)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+type specPosition struct {
+	line, column int
+}
+
+type specValidator struct {
+	positions map[string]specPosition
+	errors    []SpecError
+}
+
+// report records a problem at path, positioned at the deepest key of path
+// that was found in the spec.
+func (v *specValidator) report(path []string, format string, args ...interface{}) {
+	e := SpecError{Path: path, Message: fmt.Sprintf(format, args...)}
+
+	for i := len(path); i >= 0; i-- {
+		if pos, ok := v.positions[specPathKey(path[:i])]; ok {
+			e.Line, e.Column = pos.line, pos.column
+			break
+		}
+	}
+
+	v.errors = append(v.errors, e)
+}
+
+func (v *specValidator) validate(doc interface{}) {
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		v.report(nil, "spec must be an object")
+		return
+	}
+
+	if _, ok := root["swagger"]; ok {
+		v.report([]string{"swagger"}, "swagger 2.0 specs are not supported, convert to OpenAPI 3")
+		return
+	}
+
+	version, ok := root["openapi"].(string)
+	switch {
+	case root["openapi"] == nil:
+		v.report(nil, "openapi is required")
+	case !ok || !openAPIVersionPattern.MatchString(version):
+		v.report([]string{"openapi"}, "unsupported OpenAPI version %v, want 3.0.x or 3.1.x", root["openapi"])
+		return
+	}
+
+	v.validateInfo(root["info"])
+
+	if _, ok := root["paths"]; !ok {
+		if !strings.HasPrefix(version, "3.1.") {
+			v.report(nil, "paths is required")
+		} else if root["components"] == nil && root["webhooks"] == nil {
+			v.report(nil, "one of paths, components, or webhooks is required")
+		}
+		return
+	}
+
+	v.validatePaths(root["paths"], strings.HasPrefix(version, "3.0."))
+}
+
+func (v *specValidator) validateInfo(value interface{}) {
+	path := []string{"info"}
+	if value == nil {
+		v.report(nil, "info is required")
+		return
+	}
+
+	info, ok := value.(map[string]interface{})
+	if !ok {
+		v.report(path, "info must be an object")
+		return
+	}
+
+	for _, field := range []string{"title", "version"} {
+		if _, ok := info[field].(string); !ok {
+			v.report(append(path, field), "%s is required and must be a string", field)
+		}
+	}
+}
+
+func (v *specValidator) validatePaths(value interface{}, requireResponses bool) {
+	paths, ok := value.(map[string]interface{})
+	if !ok {
+		v.report([]string{"paths"}, "paths must be an object")
+		return
+	}
+
+	for _, p := range sortedKeys(paths) {
+		path := []string{"paths", p}
+		if strings.HasPrefix(p, "x-") {
+			continue
+		}
+
+		if !strings.HasPrefix(p, "/") {
+			v.report(path, "path must begin with a slash")
+		}
+
+		item, ok := paths[p].(map[string]interface{})
+		if !ok {
+			v.report(path, "path item must be an object")
+			continue
+		}
+
+		for _, method := range openAPIOperations {
+			op, ok := item[method]
+			if !ok {
+				continue
+			}
+
+			opPath := append(path[:len(path):len(path)], method)
+			operation, ok := op.(map[string]interface{})
+			if !ok {
+				v.report(opPath, "operation must be an object")
+				continue
+			}
+
+			if _, ok := operation["responses"]; !ok && requireResponses {
+				v.report(opPath, "responses is required")
+			}
+		}
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func specPathKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
+
+// specSyntaxError converts a JSON or YAML parse error into a SpecError.
+func specSyntaxError(spec []byte, err error) SpecError {
+	e := SpecError{Message: err.Error()}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		e.Line, e.Column = offsetPosition(spec, int(syntaxErr.Offset))
+	} else if m := yamlErrorLinePattern.FindStringSubmatch(err.Error()); m != nil {
+		e.Line, _ = strconv.Atoi(m[1])
+		e.Column = 1
+	}
+
+	return e
+}
+
+// offsetPosition returns the 1-based line and column of a byte offset.
+func offsetPosition(data []byte, offset int) (int, int) {
+	if offset > len(data) {
+		offset = len(data)
+	}
+
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := offset - bytes.LastIndexByte(before, '\n')
+
+	return line, column
+}
+
+// parseJSONSpec decodes a JSON spec, recording the position of every object
+// key.  Entries of arrays share a single "-" path element.
+func parseJSONSpec(spec []byte) (interface{}, map[string]specPosition, error) {
+	var doc interface{}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, nil, err
+	}
+
+	positions := map[string]specPosition{}
+	dec := json.NewDecoder(bytes.NewReader(spec))
+	if err := recordJSONPositions(dec, spec, nil, positions); err != nil {
+		return nil, nil, err
+	}
+
+	return doc, positions, nil
+}
+
+func recordJSONPositions(dec *json.Decoder, spec []byte, path []string, positions map[string]specPosition) error {
+	start := int(dec.InputOffset())
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	if path == nil {
+		line, column := offsetPosition(spec, skipJSONSpace(spec, start))
+		positions[specPathKey(nil)] = specPosition{line, column}
+	}
+
+	switch delim {
+	case '{':
+		for dec.More() {
+			keyStart := skipJSONSpace(spec, int(dec.InputOffset()))
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+
+			key, _ := tok.(string)
+			keyPath := append(path[:len(path):len(path)], key)
+			line, column := offsetPosition(spec, keyStart)
+			positions[specPathKey(keyPath)] = specPosition{line, column}
+
+			if err := recordJSONPositions(dec, spec, keyPath, positions); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for dec.More() {
+			if err := recordJSONPositions(dec, spec, append(path[:len(path):len(path)], "-"), positions); err != nil {
+				return err
+			}
+		}
+	}
+
+	// consume the closing delimiter
+	_, err = dec.Token()
+	return err
+}
+
+// skipJSONSpace returns the offset of the first token byte at or after
+// offset, skipping whitespace and separators.
+func skipJSONSpace(data []byte, offset int) int {
+	for offset < len(data) && strings.IndexByte(" \t\r\n,:", data[offset]) >= 0 {
+		offset++
+	}
+
+	return offset
+}
+
+// parseYAMLSpec decodes a YAML spec, recording the position of block mapping
+// keys.  Keys of flow mappings are not positioned, and entries of sequences
+// share a single "-" path element, as they do for JSON specs.
+func parseYAMLSpec(spec []byte) (interface{}, map[string]specPosition, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(spec, &raw); err != nil {
+		return nil, nil, err
+	}
+
+	type frame struct {
+		indent int
+		key    string
+	}
+
+	positions := map[string]specPosition{specPathKey(nil): {1, 1}}
+	var stack []frame
+	blockIndent := -1
+
+	for i, line := range strings.Split(string(spec), "\n") {
+		// Skip the content of block scalars, which may look like keys.
+		if blockIndent >= 0 {
+			if strings.TrimSpace(line) == "" || len(line)-len(strings.TrimLeft(line, " ")) > blockIndent {
+				continue
+			}
+			blockIndent = -1
+		}
+
+		m := yamlKeyPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		indent := len(m[1])
+		if value := strings.TrimSpace(line[len(m[0]):]); strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+			blockIndent = indent
+		}
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		// Each sequence entry marker opens a level of its own.
+		for _, dash := range strings.Fields(m[2]) {
+			stack = append(stack, frame{indent: indent, key: dash})
+			indent += len(dash) + 1
+		}
+
+		key := strings.Trim(m[3], `"'`)
+		path := make([]string, 0, len(stack)+1)
+		for _, f := range stack {
+			path = append(path, f.key)
+		}
+		path = append(path, key)
+
+		positions[specPathKey(path)] = specPosition{i + 1, indent + 1}
+		stack = append(stack, frame{indent: indent, key: key})
+	}
+
+	return normalizeYAML(raw), positions, nil
+}
+
+// normalizeYAML converts the maps decoded from YAML to the string keyed maps
+// decoded from JSON.
+func normalizeYAML(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			m[fmt.Sprint(k)] = normalizeYAML(v)
+		}
+		return m
+	case []interface{}:
+		for i := range t {
+			t[i] = normalizeYAML(t[i])
+		}
+	}
+
+	return v
+}
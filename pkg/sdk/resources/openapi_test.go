@@ -0,0 +1,144 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+const validOpenAPIYAML = `openapi: 3.0.3
+info:
+  title: Users API
+  description: |
+    Manage users.
+    note: not a key
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      parameters:
+        - name: limit
+          in: query
+      responses:
+        "200":
+          description: OK
+`
+
+func TestValidateOpenAPIValid(t *testing.T) {
+	if errs := resources.ValidateOpenAPI([]byte(validOpenAPIYAML)); len(errs) != 0 {
+		t.Errorf("Expected no errors, have: %v", errs)
+	}
+
+	json := `{"openapi":"3.1.0","info":{"title":"Users API","version":"1.0.0"},"paths":{"/users":{"get":{}}}}`
+	if errs := resources.ValidateOpenAPI([]byte(json)); len(errs) != 0 {
+		t.Errorf("Expected no errors, have: %v", errs)
+	}
+}
+
+func TestValidateOpenAPIMissingPaths(t *testing.T) {
+	errs := resources.ValidateOpenAPI([]byte("openapi: 3.0.3\ninfo:\n  title: Users API\n  version: 1.0.0\n"))
+
+	if len(errs) != 1 {
+		t.Fatalf("Expected 1 error, have: %v", errs)
+	}
+
+	if errs[0].Line != 1 || errs[0].Column != 1 || !strings.Contains(errs[0].Message, "paths") {
+		t.Errorf("Error is incorrect, have: %s", errs[0])
+	}
+}
+
+func TestValidateOpenAPI31WithoutPaths(t *testing.T) {
+	spec := `{"openapi":"3.1.0","info":{"title":"Hooks","version":"1.0.0"},"webhooks":{}}`
+	if errs := resources.ValidateOpenAPI([]byte(spec)); len(errs) != 0 {
+		t.Errorf("Expected no errors, have: %v", errs)
+	}
+}
+
+func TestValidateOpenAPILinePositions(t *testing.T) {
+	yaml := strings.Replace(validOpenAPIYAML, "  version: 1.0.0\n", "", 1)
+	yaml = strings.Replace(yaml, "  /users:", "  users:", 1)
+	yaml = strings.Replace(yaml, "      responses:\n        \"200\":\n          description: OK\n", "", 1)
+
+	json := "{\n  \"openapi\": \"3.0.3\",\n  \"info\": {\"title\": \"Users API\"},\n  \"paths\": {\n    \"users\": {\"get\": {}}\n  }\n}"
+
+	tests := []struct {
+		name string
+		spec string
+		want []string
+	}{
+		{"yaml", yaml, []string{
+			"line 2, column 1: info.version: version is required and must be a string",
+			"line 8, column 3: paths.users: path must begin with a slash",
+			"line 9, column 5: paths.users.get: responses is required",
+		}},
+		{"json", json, []string{
+			"line 3, column 3: info.version: version is required and must be a string",
+			"line 5, column 5: paths.users: path must begin with a slash",
+			"line 5, column 15: paths.users.get: responses is required",
+		}},
+	}
+
+	for _, tt := range tests {
+		errs := resources.ValidateOpenAPI([]byte(tt.spec))
+
+		have := make([]string, len(errs))
+		for i, e := range errs {
+			have[i] = e.String()
+		}
+
+		if strings.Join(have, "\n") != strings.Join(tt.want, "\n") {
+			t.Errorf("%s: errors are incorrect, have:\n%s\nwant:\n%s", tt.name, strings.Join(have, "\n"), strings.Join(tt.want, "\n"))
+		}
+	}
+}
+
+func TestValidateOpenAPIUnsupportedVersion(t *testing.T) {
+	errs := resources.ValidateOpenAPI([]byte("swagger: \"2.0\"\ninfo:\n  title: Old\n"))
+	if len(errs) != 1 || errs[0].Line != 1 || !strings.Contains(errs[0].Message, "swagger 2.0") {
+		t.Errorf("Expected a swagger 2.0 error, have: %v", errs)
+	}
+
+	errs = resources.ValidateOpenAPI([]byte("info:\n  title: Old\nopenapi: 4.0.0\n"))
+	if len(errs) != 1 || errs[0].Line != 3 {
+		t.Errorf("Expected an unsupported version error on line 3, have: %v", errs)
+	}
+}
+
+func TestValidateOpenAPISyntaxError(t *testing.T) {
+	errs := resources.ValidateOpenAPI([]byte("{\n  \"openapi\": \"3.0.3\",\n  \"info\": }\n"))
+	if len(errs) != 1 || errs[0].Line != 3 {
+		t.Errorf("Expected a syntax error on line 3, have: %v", errs)
+	}
+
+	errs = resources.ValidateOpenAPI([]byte("openapi: 3.0.3\ninfo:\n  title: [Users\n"))
+	if len(errs) != 1 || errs[0].Line == 0 {
+		t.Errorf("Expected a syntax error with a line, have: %v", errs)
+	}
+}
+
+func TestSpecContentType(t *testing.T) {
+	if have := resources.SpecContentType([]byte("  {\"openapi\":\"3.0.0\"}")); have != resources.SpecContentTypeJSON {
+		t.Errorf("Content type is incorrect, have: %s, want: %s", have, resources.SpecContentTypeJSON)
+	}
+
+	if have := resources.SpecContentType([]byte("openapi: 3.0.0\n")); have != resources.SpecContentTypeYAML {
+		t.Errorf("Content type is incorrect, have: %s, want: %s", have, resources.SpecContentTypeYAML)
+	}
+}
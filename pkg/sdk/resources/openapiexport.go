@@ -0,0 +1,441 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+type openAPIDocument struct {
+	OpenAPI string                                  `json:"openapi"`
+	Info    openAPIInfo                             `json:"info"`
+	Servers []openAPIServer                         `json:"servers,omitempty"`
+	Paths   map[string]map[string]*openAPIOperation `json:"paths"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+type openAPIOperation struct {
+	Summary     string                     `json:"summary,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *openAPISchema `json:"schema"`
+	Example     interface{}    `json:"example,omitempty"`
+}
+
+type openAPIRequestBody struct {
+	Content map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema  *openAPISchema `json:"schema,omitempty"`
+	Example interface{}    `json:"example,omitempty"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPISchema struct {
+	Type       string                    `json:"type,omitempty"`
+	Format     string                    `json:"format,omitempty"`
+	Properties map[string]*openAPISchema `json:"properties,omitempty"`
+	Items      *openAPISchema            `json:"items,omitempty"`
+}
+
+// openAPIIgnoredHeaders are the headers OpenAPI describes by other means
+// than header parameters.
+var openAPIIgnoredHeaders = map[string]bool{"accept": true, "authorization": true, "content-type": true}
+
+// ToOpenAPI returns an OpenAPI 3.0 document describing the requests of the
+// collection.  Requests are grouped by path and method, with :name and
+// {{name}} path segments becoming path parameters.  Request bodies and
+// saved example responses are included as examples, with schemas inferred
+// loosely from their values.  Requests without examples get a default
+// response.
+func (c *Collection) ToOpenAPI() ([]byte, error) {
+	doc := openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info:    openAPIInfo{Version: "1.0.0"},
+		Paths:   make(map[string]map[string]*openAPIOperation),
+	}
+
+	if c.Collection != nil && c.Info != nil {
+		doc.Info.Title = c.Info.Name
+		doc.Info.Description = c.Description()
+		if s, ok := c.Info.Version.(string); ok && s != "" {
+			doc.Info.Version = s
+		}
+	}
+
+	servers := make(map[string]bool)
+	for _, flat := range c.Flatten() {
+		if flat.Item.Item == nil || flat.Item.Request == nil {
+			continue
+		}
+
+		r, err := ParseRequest(flat.Item.Request)
+		if err != nil {
+			return nil, err
+		}
+
+		if origin := openAPIOrigin(r.URL); origin != "" && !servers[origin] {
+			servers[origin] = true
+			doc.Servers = append(doc.Servers, openAPIServer{URL: origin})
+		}
+
+		path, params := openAPIPath(r.URL)
+		method := strings.ToLower(r.Method)
+		if method == "" {
+			method = "get"
+		}
+
+		operations, ok := doc.Paths[path]
+		if !ok {
+			operations = make(map[string]*openAPIOperation)
+			doc.Paths[path] = operations
+		}
+
+		op, ok := operations[method]
+		if !ok {
+			op = &openAPIOperation{Summary: flat.Item.Name, Responses: make(map[string]openAPIResponse)}
+			if len(flat.Folders) > 0 {
+				op.Tags = []string{flat.Folders[0]}
+			}
+			operations[method] = op
+		}
+
+		for _, p := range append(params, openAPIRequestParameters(r)...) {
+			op.addParameter(p)
+		}
+
+		if op.RequestBody == nil {
+			op.RequestBody = openAPIBody(r.Body)
+		}
+
+		for _, res := range flat.Item.Response {
+			if res == nil {
+				continue
+			}
+
+			code := "default"
+			if res.Code > 0 {
+				code = strconv.Itoa(res.Code)
+			}
+			if _, ok := op.Responses[code]; !ok {
+				op.Responses[code] = openAPIExampleResponse(res)
+			}
+		}
+	}
+
+	for _, operations := range doc.Paths {
+		for _, op := range operations {
+			if len(op.Responses) == 0 {
+				op.Responses["default"] = openAPIResponse{Description: "Default response"}
+			}
+		}
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// addParameter adds p unless the operation has a parameter with the same
+// name and location.
+func (op *openAPIOperation) addParameter(p openAPIParameter) {
+	for _, existing := range op.Parameters {
+		if existing.In == p.In && existing.Name == p.Name {
+			return
+		}
+	}
+
+	op.Parameters = append(op.Parameters, p)
+}
+
+// openAPIOrigin returns the scheme, host, and port of a URL, or "" when
+// the URL has no literal host.
+func openAPIOrigin(u URL) string {
+	host := strings.Join(u.Host, ".")
+	if host == "" || strings.Contains(host, "{{") {
+		return ""
+	}
+
+	origin := host
+	if u.Protocol != "" {
+		origin = u.Protocol + "://" + host
+	}
+	if u.Port != "" {
+		origin += ":" + u.Port
+	}
+
+	return origin
+}
+
+// openAPIPath returns the templated OpenAPI path of a URL along with its
+// path parameters.
+func openAPIPath(u URL) (string, []openAPIParameter) {
+	var (
+		segments []string
+		params   []openAPIParameter
+	)
+
+	addParam := func(name string) {
+		p := openAPIParameter{Name: name, In: "path", Required: true, Schema: &openAPISchema{Type: "string"}}
+		for _, v := range u.Variable {
+			if v.Key == name {
+				p.Description = v.Description
+				p.Schema = openAPIValueSchema(v.Value)
+				if v.Value != "" {
+					p.Example = v.Value
+				}
+			}
+		}
+		params = append(params, p)
+	}
+
+	for _, s := range u.Path {
+		switch {
+		case s == "":
+			continue
+		case strings.HasPrefix(s, ":") && len(s) > 1:
+			addParam(s[1:])
+			s = "{" + s[1:] + "}"
+		default:
+			for _, m := range variablePattern.FindAllStringSubmatch(s, -1) {
+				addParam(m[1])
+			}
+			s = variablePattern.ReplaceAllString(s, "{$1}")
+		}
+		segments = append(segments, s)
+	}
+
+	return "/" + strings.Join(segments, "/"), params
+}
+
+// openAPIRequestParameters returns the query and header parameters of a
+// request.
+func openAPIRequestParameters(r *Request) []openAPIParameter {
+	var params []openAPIParameter
+	for _, q := range r.URL.Query {
+		if q.Disabled || q.Key == "" {
+			continue
+		}
+
+		p := openAPIParameter{Name: q.Key, In: "query", Description: q.Description, Schema: openAPIValueSchema(q.Value)}
+		if q.Value != "" {
+			p.Example = q.Value
+		}
+		params = append(params, p)
+	}
+
+	for _, h := range enabledHeaders(r.Header) {
+		if h.Key == "" || openAPIIgnoredHeaders[strings.ToLower(h.Key)] {
+			continue
+		}
+
+		p := openAPIParameter{Name: h.Key, In: "header", Schema: openAPIValueSchema(h.Value)}
+		if h.Value != "" {
+			p.Example = h.Value
+		}
+		params = append(params, p)
+	}
+
+	return params
+}
+
+// openAPIBody describes a request body, or returns nil when there is none.
+func openAPIBody(b *Body) *openAPIRequestBody {
+	if b == nil || b.Disabled {
+		return nil
+	}
+
+	switch b.Mode {
+	case BodyModeRaw:
+		if b.Raw == "" {
+			return nil
+		}
+		contentType, media := openAPIRawMedia(b.Raw, b.Language() == "json")
+		return &openAPIRequestBody{Content: map[string]openAPIMediaType{contentType: media}}
+	case BodyModeURLEncoded:
+		schema := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+		for _, p := range b.URLEncoded {
+			if !p.Disabled {
+				schema.Properties[p.Key] = openAPIValueSchema(p.Value)
+			}
+		}
+		return &openAPIRequestBody{Content: map[string]openAPIMediaType{"application/x-www-form-urlencoded": {Schema: schema}}}
+	case BodyModeFormData:
+		schema := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+		for _, p := range b.FormData {
+			if p.Disabled {
+				continue
+			}
+			if p.Type == "file" {
+				schema.Properties[p.Key] = &openAPISchema{Type: "string", Format: "binary"}
+			} else {
+				schema.Properties[p.Key] = openAPIValueSchema(p.Value)
+			}
+		}
+		return &openAPIRequestBody{Content: map[string]openAPIMediaType{"multipart/form-data": {Schema: schema}}}
+	case BodyModeFile:
+		return &openAPIRequestBody{Content: map[string]openAPIMediaType{"application/octet-stream": {Schema: &openAPISchema{Type: "string", Format: "binary"}}}}
+	case BodyModeGraphQL:
+		schema := &openAPISchema{Type: "object", Properties: map[string]*openAPISchema{
+			"query":     {Type: "string"},
+			"variables": {Type: "object"},
+		}}
+		return &openAPIRequestBody{Content: map[string]openAPIMediaType{"application/json": {Schema: schema}}}
+	}
+
+	return nil
+}
+
+// openAPIExampleResponse describes a saved example response.
+func openAPIExampleResponse(res *gen.Response) openAPIResponse {
+	ret := openAPIResponse{Description: res.Status}
+	if ret.Description == "" {
+		ret.Description = http.StatusText(res.Code)
+	}
+	if ret.Description == "" {
+		ret.Description = "Example response"
+	}
+
+	body, ok := res.Body.(string)
+	if !ok || body == "" {
+		return ret
+	}
+
+	contentType := openAPIHeader(res.Header, "Content-Type")
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	contentType = strings.TrimSpace(contentType)
+
+	var (
+		mediaType string
+		media     openAPIMediaType
+	)
+	if contentType != "" && !strings.Contains(contentType, "json") {
+		mediaType, media = contentType, openAPIMediaType{Schema: &openAPISchema{Type: "string"}, Example: body}
+	} else {
+		mediaType, media = openAPIRawMedia(body, contentType != "")
+		if contentType != "" && mediaType == "application/json" {
+			mediaType = contentType
+		}
+	}
+	ret.Content = map[string]openAPIMediaType{mediaType: media}
+
+	return ret
+}
+
+// openAPIRawMedia describes a raw body as JSON when it parses as JSON and
+// either isJSON is set or the body is an object or array, and as plain text
+// otherwise.
+func openAPIRawMedia(raw string, isJSON bool) (string, openAPIMediaType) {
+	trimmed := strings.TrimSpace(raw)
+	if isJSON || strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var v interface{}
+		if err := json.Unmarshal([]byte(trimmed), &v); err == nil {
+			return "application/json", openAPIMediaType{Schema: openAPIJSONSchema(v), Example: v}
+		}
+	}
+
+	return "text/plain", openAPIMediaType{Schema: &openAPISchema{Type: "string"}, Example: raw}
+}
+
+// openAPIHeader returns the value of a header of a saved example, which
+// stores its headers as a list of key/value objects.
+func openAPIHeader(headers interface{}, key string) string {
+	list, _ := headers.([]interface{})
+	for _, h := range list {
+		m, _ := h.(map[string]interface{})
+		if k, _ := m["key"].(string); strings.EqualFold(k, key) {
+			v, _ := m["value"].(string)
+			return v
+		}
+	}
+
+	return ""
+}
+
+// openAPIJSONSchema infers a schema from a decoded JSON value.  Arrays are
+// described by their first element.
+func openAPIJSONSchema(v interface{}) *openAPISchema {
+	switch v := v.(type) {
+	case bool:
+		return &openAPISchema{Type: "boolean"}
+	case float64:
+		if v == float64(int64(v)) {
+			return &openAPISchema{Type: "integer"}
+		}
+		return &openAPISchema{Type: "number"}
+	case string:
+		return &openAPISchema{Type: "string"}
+	case []interface{}:
+		schema := &openAPISchema{Type: "array", Items: &openAPISchema{}}
+		if len(v) > 0 {
+			schema.Items = openAPIJSONSchema(v[0])
+		}
+		return schema
+	case map[string]interface{}:
+		schema := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema, len(v))}
+		for k, p := range v {
+			schema.Properties[k] = openAPIJSONSchema(p)
+		}
+		return schema
+	}
+
+	return &openAPISchema{}
+}
+
+// openAPIValueSchema infers a schema from a parameter value, which is
+// always written as text.
+func openAPIValueSchema(value string) *openAPISchema {
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return &openAPISchema{Type: "integer"}
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return &openAPISchema{Type: "number"}
+	}
+	if value == "true" || value == "false" {
+		return &openAPISchema{Type: "boolean"}
+	}
+
+	return &openAPISchema{Type: "string"}
+}
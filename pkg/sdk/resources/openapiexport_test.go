@@ -0,0 +1,179 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+const openAPIExportSubject = `{
+  "info": {
+    "name": "Users",
+    "description": {"content": "Manage users.", "type": "text/markdown"},
+    "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+  },
+  "item": [
+    {
+      "name": "Users",
+      "item": [
+        {
+          "name": "Get user",
+          "request": {
+            "method": "GET",
+            "url": {
+              "raw": "https://api.example.com/users/:id?fields=name",
+              "protocol": "https",
+              "host": ["api", "example", "com"],
+              "path": ["users", ":id"],
+              "query": [{"key": "fields", "value": "name"}],
+              "variable": [{"key": "id", "value": "42", "description": "The user ID"}]
+            }
+          },
+          "response": [
+            {
+              "name": "Found",
+              "code": 200,
+              "status": "OK",
+              "header": [{"key": "Content-Type", "value": "application/json; charset=utf-8"}],
+              "body": "{\"id\": 42, \"name\": \"Ada\"}"
+            },
+            {"name": "Missing", "code": 404, "status": "Not Found"}
+          ]
+        },
+        {
+          "name": "Create user",
+          "request": {
+            "method": "POST",
+            "url": "{{baseUrl}}/users",
+            "body": {"mode": "raw", "raw": "{\"name\": \"Ada\", \"admin\": false}", "options": {"raw": {"language": "json"}}}
+          }
+        }
+      ]
+    }
+  ]
+}`
+
+func TestCollectionToOpenAPI(t *testing.T) {
+	c := unmarshalCollection(t, openAPIExportSubject)
+
+	data, err := c.ToOpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if errs := resources.ValidateOpenAPI(data); len(errs) > 0 {
+		t.Errorf("Spec is invalid: %v", errs)
+	}
+
+	type schema struct {
+		Type       string            `json:"type"`
+		Properties map[string]schema `json:"properties"`
+	}
+
+	type media struct {
+		Schema  schema      `json:"schema"`
+		Example interface{} `json:"example"`
+	}
+
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Info    struct {
+			Title       string `json:"title"`
+			Description string `json:"description"`
+		} `json:"info"`
+		Servers []struct {
+			URL string `json:"url"`
+		} `json:"servers"`
+		Paths map[string]map[string]struct {
+			Summary    string   `json:"summary"`
+			Tags       []string `json:"tags"`
+			Parameters []struct {
+				Name        string `json:"name"`
+				In          string `json:"in"`
+				Required    bool   `json:"required"`
+				Description string `json:"description"`
+				Schema      schema `json:"schema"`
+			} `json:"parameters"`
+			RequestBody *struct {
+				Content map[string]media `json:"content"`
+			} `json:"requestBody"`
+			Responses map[string]struct {
+				Description string           `json:"description"`
+				Content     map[string]media `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.OpenAPI != "3.0.3" || doc.Info.Title != "Users" || doc.Info.Description != "Manage users." {
+		t.Errorf("Document header is incorrect, have: %s %+v", doc.OpenAPI, doc.Info)
+	}
+
+	if len(doc.Servers) != 1 || doc.Servers[0].URL != "https://api.example.com" {
+		t.Errorf("Servers are incorrect, have: %+v", doc.Servers)
+	}
+
+	get, ok := doc.Paths["/users/{id}"]["get"]
+	if !ok {
+		t.Fatalf("Expected a get operation on /users/{id}, have paths: %s", string(data))
+	}
+
+	if get.Summary != "Get user" || len(get.Tags) != 1 || get.Tags[0] != "Users" {
+		t.Errorf("Operation is incorrect, have: %+v", get)
+	}
+
+	if len(get.Parameters) != 2 {
+		t.Fatalf("Parameters are incorrect, have: %+v", get.Parameters)
+	}
+
+	id := get.Parameters[0]
+	if id.Name != "id" || id.In != "path" || !id.Required || id.Description != "The user ID" || id.Schema.Type != "integer" {
+		t.Errorf("Path parameter is incorrect, have: %+v", id)
+	}
+
+	if fields := get.Parameters[1]; fields.Name != "fields" || fields.In != "query" || fields.Schema.Type != "string" {
+		t.Errorf("Query parameter is incorrect, have: %+v", fields)
+	}
+
+	ok200 := get.Responses["200"].Content["application/json"]
+	if ok200.Schema.Type != "object" || ok200.Schema.Properties["id"].Type != "integer" {
+		t.Errorf("200 response is incorrect, have: %+v", get.Responses["200"])
+	}
+
+	if get.Responses["404"].Description != "Not Found" {
+		t.Errorf("404 response is incorrect, have: %+v", get.Responses["404"])
+	}
+
+	post, ok := doc.Paths["/users"]["post"]
+	if !ok {
+		t.Fatalf("Expected a post operation on /users, have paths: %s", string(data))
+	}
+
+	body := post.RequestBody.Content["application/json"]
+	if body.Schema.Properties["admin"].Type != "boolean" || body.Schema.Properties["name"].Type != "string" {
+		t.Errorf("Request body is incorrect, have: %+v", post.RequestBody)
+	}
+
+	if _, ok := post.Responses["default"]; !ok {
+		t.Errorf("Expected a default response, have: %+v", post.Responses)
+	}
+}
@@ -0,0 +1,26 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+// PageInfo describes how a list returned by the Postman API was paged.
+// Total is the number of entries reported by the API, or zero when it
+// doesn't report one.  HasMore reports whether entries were left out of the
+// list, such as when it was cut off at a limit.
+type PageInfo struct {
+	Total   int
+	HasMore bool
+}
@@ -0,0 +1,77 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"net/http"
+	"strings"
+)
+
+// HeaderPreset is a set of standard headers, such as a correlation ID or a
+// tenant, to add to every request.  Values may reference variables.
+type HeaderPreset []Header
+
+// ApplyHeaderPreset adds each header of preset to every request in the
+// collection that lacks it.  Header names are compared ignoring case, and
+// existing headers, including disabled ones, are never overwritten.
+func (c *Collection) ApplyHeaderPreset(preset HeaderPreset) error {
+	if c.Collection == nil || len(preset) == 0 {
+		return nil
+	}
+
+	forEachRawItem(c.Item, func(item map[string]interface{}) {
+		r, ok := item["request"].(map[string]interface{})
+		if !ok {
+			s, isURL := item["request"].(string)
+			if !isURL {
+				return
+			}
+
+			r = map[string]interface{}{"url": s, "method": http.MethodGet}
+			item["request"] = r
+		}
+
+		headers, ok := r["header"].([]interface{})
+		if !ok && r["header"] != nil {
+			// Headers given as a single string are left as they are.
+			return
+		}
+
+		for _, h := range preset {
+			if !hasRawHeader(headers, h.Key) {
+				headers = append(headers, map[string]interface{}{"key": h.Key, "value": h.Value})
+			}
+		}
+
+		r["header"] = headers
+	})
+
+	return c.refreshItems()
+}
+
+// hasRawHeader reports whether the raw headers include key, ignoring case.
+func hasRawHeader(headers []interface{}, key string) bool {
+	for _, v := range headers {
+		if m, ok := v.(map[string]interface{}); ok {
+			if k, _ := m["key"].(string); strings.EqualFold(k, key) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
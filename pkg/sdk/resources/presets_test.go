@@ -0,0 +1,80 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestCollectionApplyHeaderPreset(t *testing.T) {
+	c := unmarshalCollection(t, `{
+		"info": {"name": "presets", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"item": [
+			{"name": "Plain", "request": "https://api.example.com/health"},
+			{"name": "Users", "item": [
+				{"name": "List Users", "request": {"method": "GET", "url": "https://api.example.com/users"}},
+				{"name": "Tenant Users", "request": {"method": "GET", "url": "https://api.example.com/users", "header": [
+					{"key": "x-tenant-id", "value": "acme"},
+					{"key": "Accept", "value": "application/json"}
+				]}}
+			]}
+		]
+	}`)
+
+	preset := resources.HeaderPreset{
+		{Key: "X-Correlation-ID", Value: "{{$guid}}"},
+		{Key: "X-Tenant-ID", Value: "{{tenant}}"},
+	}
+
+	if err := c.ApplyHeaderPreset(preset); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"Plain":        "X-Correlation-ID: {{$guid}}, X-Tenant-ID: {{tenant}}",
+		"List Users":   "X-Correlation-ID: {{$guid}}, X-Tenant-ID: {{tenant}}",
+		"Tenant Users": "x-tenant-id: acme, Accept: application/json, X-Correlation-ID: {{$guid}}",
+	}
+
+	requests := c.Flatten()
+	if len(requests) != len(want) {
+		t.Fatalf("Expected %d requests, have: %d", len(want), len(requests))
+	}
+
+	for _, fr := range requests {
+		r, err := resources.ParseRequest(fr.Item.Request)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var have []string
+		for _, h := range r.Header {
+			have = append(have, h.Key+": "+h.Value)
+		}
+
+		if strings.Join(have, ", ") != want[fr.Item.Name] {
+			t.Errorf("Headers of %s are incorrect, have: %s, want: %s", fr.Item.Name, strings.Join(have, ", "), want[fr.Item.Name])
+		}
+
+		if fr.Item.Name == "Plain" && (r.Method != "GET" || r.URL.String() != "https://api.example.com/health") {
+			t.Errorf("Expected the plain request to keep its URL, have: %s %s", r.Method, r.URL.String())
+		}
+	}
+}
@@ -0,0 +1,335 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+// Request represents the request of a collection item.
+type Request struct {
+	Method      string       `json:"method,omitempty"`
+	URL         URL          `json:"url"`
+	Header      []Header     `json:"header,omitempty"`
+	Body        *Body        `json:"body,omitempty"`
+	Auth        *gen.Auth    `json:"auth,omitempty"`
+	Certificate *Certificate `json:"certificate,omitempty"`
+	Proxy       *ProxyConfig `json:"proxy,omitempty"`
+}
+
+// Header represents a single request header.  Disabled headers are kept in
+// the collection but not sent.
+type Header struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// enabledHeaders returns the headers that aren't disabled.
+func enabledHeaders(headers []Header) []Header {
+	var ret []Header
+	for _, h := range headers {
+		if !h.Disabled {
+			ret = append(ret, h)
+		}
+	}
+
+	return ret
+}
+
+// Request body modes.
+const (
+	BodyModeRaw        = "raw"
+	BodyModeURLEncoded = "urlencoded"
+	BodyModeFormData   = "formdata"
+	BodyModeFile       = "file"
+	BodyModeGraphQL    = "graphql"
+)
+
+// Body represents the body of a request.  Only the payload field matching
+// Mode is used.
+type Body struct {
+	Mode       string            `json:"mode"`
+	Raw        string            `json:"raw,omitempty"`
+	URLEncoded []URLEncodedParam `json:"urlencoded,omitempty"`
+	FormData   []FormDataParam   `json:"formdata,omitempty"`
+	File       *BodyFile         `json:"file,omitempty"`
+	GraphQL    *GraphQLBody      `json:"graphql,omitempty"`
+	Options    *BodyOptions      `json:"options,omitempty"`
+	Disabled   bool              `json:"disabled,omitempty"`
+}
+
+// Language returns the language of a raw body, such as json or xml.
+func (b *Body) Language() string {
+	if b == nil || b.Options == nil || b.Options.Raw == nil {
+		return ""
+	}
+
+	return b.Options.Raw.Language
+}
+
+// URLEncodedParam is a single parameter of a urlencoded body.
+type URLEncodedParam struct {
+	Key         string `json:"key"`
+	Value       string `json:"value,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// FormDataParam is a single part of a formdata body.  Type is text or file;
+// file parts reference their content by Src.
+type FormDataParam struct {
+	Key         string      `json:"key"`
+	Value       string      `json:"value,omitempty"`
+	Src         interface{} `json:"src,omitempty"`
+	Type        string      `json:"type,omitempty"`
+	ContentType string      `json:"contentType,omitempty"`
+	Disabled    bool        `json:"disabled,omitempty"`
+	Description string      `json:"description,omitempty"`
+}
+
+// BodyFile is the payload of a file body.
+type BodyFile struct {
+	Src     string `json:"src,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// GraphQLBody is the payload of a graphql body.  Variables holds the
+// variables as a JSON encoded string, as stored by Postman.
+type GraphQLBody struct {
+	Query     string `json:"query"`
+	Variables string `json:"variables,omitempty"`
+}
+
+// BodyOptions holds additional settings for a body.
+type BodyOptions struct {
+	Raw *RawBodyOptions `json:"raw,omitempty"`
+}
+
+// RawBodyOptions holds settings for a raw body.
+type RawBodyOptions struct {
+	Language string `json:"language,omitempty"`
+}
+
+// ParseRequest converts the raw request of a collection item, which may be a
+// URL string or an object, into a Request.
+func ParseRequest(raw interface{}) (*Request, error) {
+	if s, ok := raw.(string); ok {
+		return &Request{Method: http.MethodGet, URL: ParseURL(s)}, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var r Request
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// GraphQL returns the query and variables of a request with a graphql body.
+// ok is false when the request has no graphql body.  Variables that are not
+// a JSON object are returned as nil.
+func (r *Request) GraphQL() (query string, variables map[string]interface{}, ok bool) {
+	if r.Body == nil || r.Body.Mode != BodyModeGraphQL || r.Body.GraphQL == nil {
+		return "", nil, false
+	}
+
+	if r.Body.GraphQL.Variables != "" {
+		if err := json.Unmarshal([]byte(r.Body.GraphQL.Variables), &variables); err != nil {
+			variables = nil
+		}
+	}
+
+	return r.Body.GraphQL.Query, variables, true
+}
+
+// SetGraphQL replaces the body of the request with a graphql body.
+func (r *Request) SetGraphQL(query string, variables map[string]interface{}) error {
+	body := &GraphQLBody{Query: query}
+	if len(variables) > 0 {
+		data, err := json.Marshal(variables)
+		if err != nil {
+			return err
+		}
+		body.Variables = string(data)
+	}
+
+	r.Body = &Body{Mode: BodyModeGraphQL, GraphQL: body}
+
+	return nil
+}
+
+// Execute resolves variables in the request from the given scopes and sends
+// it with client, or http.DefaultClient when client is nil.  The request is
+// sent directly to its URL, not through the Postman API.
+func (r *Request) Execute(ctx context.Context, client *http.Client, scopes ...VariableScope) (*http.Response, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := r.httpRequest(ctx, nil, scopes...)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Do(req)
+}
+
+// ResolvedURL returns the URL of the request with variables resolved from
+// the given scopes, leaving out disabled query parameters.
+func (r *Request) ResolvedURL(scopes ...VariableScope) string {
+	return ResolveVariables(r.URL.String(), scopes...)
+}
+
+// httpRequest builds the HTTP request described by r with variables
+// resolved from the given scopes.  When auth is given, it's used in place of
+// the request's own auth.
+func (r *Request) httpRequest(ctx context.Context, auth AuthProvider, scopes ...VariableScope) (*http.Request, error) {
+	method := r.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	body, contentType, err := r.Body.encode(scopes...)
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), r.ResolvedURL(scopes...), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range enabledHeaders(r.Header) {
+		req.Header.Add(ResolveVariables(h.Key, scopes...), ResolveVariables(h.Value, scopes...))
+	}
+
+	if contentType != "" && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if auth != nil {
+		err = auth.Authenticate(req)
+	} else {
+		err = applyAuth(req, r.Auth, scopes...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// encode returns the payload of a raw, urlencoded, or graphql body with
+// variables resolved, along with the content type it implies, if any.
+func (b *Body) encode(scopes ...VariableScope) ([]byte, string, error) {
+	if b == nil || b.Disabled {
+		return nil, "", nil
+	}
+
+	switch b.Mode {
+	case BodyModeRaw:
+		if b.Raw == "" {
+			return nil, "", nil
+		}
+		return []byte(ResolveVariables(b.Raw, scopes...)), "", nil
+	case BodyModeURLEncoded:
+		values := url.Values{}
+		for _, p := range b.URLEncoded {
+			if !p.Disabled {
+				values.Add(ResolveVariables(p.Key, scopes...), ResolveVariables(p.Value, scopes...))
+			}
+		}
+		return []byte(values.Encode()), "application/x-www-form-urlencoded", nil
+	case BodyModeGraphQL:
+		if b.GraphQL == nil {
+			return nil, "", nil
+		}
+
+		payload := map[string]interface{}{"query": ResolveVariables(b.GraphQL.Query, scopes...)}
+		if v := ResolveVariables(b.GraphQL.Variables, scopes...); v != "" {
+			payload["variables"] = json.RawMessage(v)
+		}
+
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid graphql variables: %s", err)
+		}
+		return data, "application/json", nil
+	case "":
+		return nil, "", nil
+	}
+
+	return nil, "", fmt.Errorf("unsupported body mode %q", b.Mode)
+}
+
+// applyAuth adds the credentials of a basic, bearer, or API key auth block
+// to req.
+func applyAuth(req *http.Request, auth *gen.Auth, scopes ...VariableScope) error {
+	if auth == nil {
+		return nil
+	}
+
+	value := func(attrs []*gen.AuthAttribute, key string) string {
+		for _, a := range attrs {
+			if a != nil && a.Key == key && a.Value != nil {
+				return ResolveVariables(fmt.Sprint(a.Value), scopes...)
+			}
+		}
+		return ""
+	}
+
+	switch auth.Type {
+	case "", "noauth":
+	case "basic":
+		req.SetBasicAuth(value(auth.Basic, "username"), value(auth.Basic, "password"))
+	case "bearer":
+		req.Header.Set("Authorization", "Bearer "+value(auth.Bearer, "token"))
+	case "apikey":
+		key, v := value(auth.Apikey, "key"), value(auth.Apikey, "value")
+		if value(auth.Apikey, "in") == "query" {
+			q := req.URL.Query()
+			q.Set(key, v)
+			req.URL.RawQuery = q.Encode()
+		} else {
+			req.Header.Set(key, v)
+		}
+	default:
+		return fmt.Errorf("unsupported auth type %q", auth.Type)
+	}
+
+	return nil
+}
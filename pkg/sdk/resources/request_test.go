@@ -0,0 +1,338 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+func TestRequestExecuteGetWithQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodGet)
+		}
+
+		if r.URL.Path != "/v1/users" {
+			t.Errorf("Path is incorrect, have: %s, want: %s", r.URL.Path, "/v1/users")
+		}
+
+		if q := r.URL.Query().Get("name"); q != "ada" {
+			t.Errorf("Query param is incorrect, have: %s, want: %s", q, "ada")
+		}
+
+		if h := r.Header.Get("Authorization"); h != "Bearer secret" {
+			t.Errorf("Authorization header is incorrect, have: %s, want: %s", h, "Bearer secret")
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	raw := map[string]interface{}{}
+	subject := `{
+		"method": "GET",
+		"url": {"raw": "{{baseUrl}}/{{version}}/users?name={{name}}", "host": ["{{baseUrl}}"]},
+		"auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{token}}", "type": "string"}]}
+	}`
+	if err := json.Unmarshal([]byte(subject), &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := resources.ParseRequest(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scope := resources.VariableScope{"baseUrl": server.URL, "version": "v1", "name": "ada", "token": "secret"}
+	resp, err := req.Execute(context.Background(), server.Client(), scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Status code is incorrect, have: %d, want: %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestRequestExecutePostWithJSONBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPost)
+		}
+
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type is incorrect, have: %s, want: %s", ct, "application/json")
+		}
+
+		if user, pass, ok := r.BasicAuth(); !ok || user != "ada" || pass != "lovelace" {
+			t.Errorf("Basic auth is incorrect, have: %s:%s", user, pass)
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if string(body) != `{"name":"ada"}` {
+			t.Errorf("Body is incorrect, have: %s, want: %s", body, `{"name":"ada"}`)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	req := &resources.Request{
+		Method: http.MethodPost,
+		URL:    resources.ParseURL(server.URL + "/users"),
+		Header: []resources.Header{{Key: "Content-Type", Value: "application/json"}},
+		Body:   &resources.Body{Mode: "raw", Raw: `{"name":"{{name}}"}`},
+		Auth: &gen.Auth{
+			Type: "basic",
+			Basic: []*gen.AuthAttribute{
+				{Key: "username", Value: "{{name}}"},
+				{Key: "password", Value: "lovelace"},
+			},
+		},
+	}
+
+	resp, err := req.Execute(context.Background(), nil, resources.VariableScope{"name": "ada"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("Status code is incorrect, have: %d, want: %d", resp.StatusCode, http.StatusCreated)
+	}
+}
+
+func TestRequestExecuteAPIKeyQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if k := r.URL.Query().Get("api_key"); k != "secret" {
+			t.Errorf("API key is incorrect, have: %s, want: %s", k, "secret")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := resources.ParseRequest(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"url":"`+server.URL+`","auth":{"type":"apikey","apikey":[{"key":"key","value":"api_key"},{"key":"value","value":"secret"},{"key":"in","value":"query"}]}}`), req); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := req.Execute(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+}
+
+func TestBodyModesRoundTrip(t *testing.T) {
+	subjects := map[string]string{
+		"raw":        `{"mode":"raw","raw":"{\"name\":\"ada\"}","options":{"raw":{"language":"json"}}}`,
+		"urlencoded": `{"mode":"urlencoded","urlencoded":[{"key":"name","value":"ada"},{"key":"debug","value":"true","disabled":true,"description":"verbose"}]}`,
+		"formdata":   `{"mode":"formdata","formdata":[{"key":"name","value":"ada","type":"text"},{"key":"avatar","src":"/tmp/ada.png","type":"file","contentType":"image/png"}]}`,
+		"file":       `{"mode":"file","file":{"src":"/tmp/body.json"}}`,
+		"graphql":    `{"mode":"graphql","graphql":{"query":"query { user(id: $id) { name } }","variables":"{\"id\":1}"}}`,
+	}
+
+	for mode, subject := range subjects {
+		var body resources.Body
+		if err := json.Unmarshal([]byte(subject), &body); err != nil {
+			t.Fatalf("%s: %s", mode, err)
+		}
+
+		if body.Mode != mode {
+			t.Errorf("Mode is incorrect, have: %s, want: %s", body.Mode, mode)
+		}
+
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("%s: %s", mode, err)
+		}
+
+		var have, want interface{}
+		if err := json.Unmarshal(data, &have); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(subject), &want); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(have, want) {
+			t.Errorf("%s body did not round trip, have: %s, want: %s", mode, data, subject)
+		}
+	}
+}
+
+func TestBodyFields(t *testing.T) {
+	var body resources.Body
+	if err := json.Unmarshal([]byte(`{"mode":"raw","raw":"<a/>","options":{"raw":{"language":"xml"}}}`), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	if body.Language() != "xml" {
+		t.Errorf("Language is incorrect, have: %s, want: %s", body.Language(), "xml")
+	}
+
+	var form resources.Body
+	if err := json.Unmarshal([]byte(`{"mode":"formdata","formdata":[{"key":"avatar","src":"/tmp/ada.png","type":"file"}]}`), &form); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(form.FormData) != 1 || form.FormData[0].Type != "file" || form.FormData[0].Src != "/tmp/ada.png" {
+		t.Errorf("Form data is incorrect, have: %+v", form.FormData)
+	}
+}
+
+func TestRequestGraphQL(t *testing.T) {
+	var req resources.Request
+	subject := `{"method":"POST","url":"https://example.com/graphql","body":{"mode":"graphql","graphql":{"query":"query User($id: ID!) { user(id: $id) { name } }","variables":"{\"id\":\"1\"}"}}}`
+	if err := json.Unmarshal([]byte(subject), &req); err != nil {
+		t.Fatal(err)
+	}
+
+	query, variables, ok := req.GraphQL()
+	if !ok {
+		t.Fatal("Expected a GraphQL request.")
+	}
+
+	if query != "query User($id: ID!) { user(id: $id) { name } }" {
+		t.Errorf("Query is incorrect, have: %s", query)
+	}
+
+	if variables["id"] != "1" {
+		t.Errorf("Variables are incorrect, have: %v", variables)
+	}
+
+	req.Body = &resources.Body{Mode: resources.BodyModeRaw, Raw: "{}"}
+	if _, _, ok := req.GraphQL(); ok {
+		t.Error("Expected a raw body not to be a GraphQL request.")
+	}
+}
+
+func TestRequestSetGraphQL(t *testing.T) {
+	req := resources.Request{Method: http.MethodPost, URL: resources.ParseURL("https://example.com/graphql")}
+	if err := req.SetGraphQL("{ viewer { login } }", map[string]interface{}{"first": 10}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"mode":"graphql","graphql":{"query":"{ viewer { login } }","variables":"{\"first\":10}"}}`
+	if string(data) != want {
+		t.Errorf("Body is incorrect, have: %s, want: %s", data, want)
+	}
+
+	if _, variables, _ := req.GraphQL(); variables["first"] != float64(10) {
+		t.Errorf("Variables are incorrect, have: %v", variables)
+	}
+}
+
+const disabledEntriesSubject = `{
+	"method": "GET",
+	"url": {
+		"raw": "{{baseUrl}}/users?name=ada&debug=true&page=2",
+		"host": ["{{baseUrl}}"],
+		"path": ["users"],
+		"query": [
+			{"key": "name", "value": "ada"},
+			{"key": "debug", "value": "true", "disabled": true},
+			{"key": "page", "value": "2"}
+		]
+	},
+	"header": [
+		{"key": "Accept", "value": "application/json"},
+		{"key": "X-Debug", "value": "1", "disabled": true}
+	]
+}`
+
+func TestRequestDisabledEntries(t *testing.T) {
+	var raw interface{}
+	if err := json.Unmarshal([]byte(disabledEntriesSubject), &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := resources.ParseRequest(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !req.Header[1].Disabled || !req.URL.Query[1].Disabled {
+		t.Fatalf("Expected disabled flags to be decoded, have: %+v %+v", req.Header, req.URL.Query)
+	}
+
+	scope := resources.VariableScope{"baseUrl": "https://api.example.com"}
+	if have, want := req.ResolvedURL(scope), "https://api.example.com/users?name=ada&page=2"; have != want {
+		t.Errorf("Resolved URL is incorrect, have: %s, want: %s", have, want)
+	}
+
+	have, err := req.ToCurl(scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `curl 'https://api.example.com/users?name=ada&page=2' \
+  -H 'Accept: application/json'`
+	if have != want {
+		t.Errorf("Curl command is incorrect, have:\n%s\nwant:\n%s", have, want)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("debug") || r.Header.Get("X-Debug") != "" {
+			t.Errorf("Disabled entries were sent, have: %s %v", r.URL, r.Header)
+		}
+	}))
+	defer server.Close()
+
+	resp, err := req.Execute(context.Background(), server.Client(), resources.VariableScope{"baseUrl": server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var roundTripped resources.Request
+	if err := json.Unmarshal(data, &roundTripped); err != nil {
+		t.Fatal(err)
+	}
+
+	if !roundTripped.Header[1].Disabled {
+		t.Errorf("Disabled header flag was lost, have: %s", data)
+	}
+}
@@ -37,6 +37,8 @@ const (
 	SchemaType
 	WorkspaceType
 	UserType
+	FolderType
+	RequestType
 )
 
 // String returns a string version of the ResourceType.
@@ -62,6 +64,10 @@ func (r ResourceType) String() string {
 		return "Workspace"
 	case UserType:
 		return "User"
+	case FolderType:
+		return "Folder"
+	case RequestType:
+		return "Request"
 	}
 
 	return ""
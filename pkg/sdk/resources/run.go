@@ -0,0 +1,245 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Run statuses reported by local collection runs, matching those reported
+// for monitor runs.
+const (
+	RunStatusSuccess = "success"
+	RunStatusFailed  = "failed"
+)
+
+// RunOptions controls a local collection run.  Client defaults to
+// http.DefaultClient.  RequestTimeout bounds each request and Deadline
+// bounds the whole run; either is unbounded when zero.  A request that
+// fails, including by timing out, is recorded as a failed execution and the
+// run continues unless StopOnFailure is set.  Auth, when set, authenticates
+// every request in place of the auth stored in the collection; otherwise
+// requests without auth of their own inherit that of their folder or the
+// collection.  Assertions are checked against the responses of the requests
+// with the names they are keyed by, and a failed assertion fails the run.
+type RunOptions struct {
+	Client         *http.Client
+	RequestTimeout time.Duration
+	Deadline       time.Time
+	StopOnFailure  bool
+	Auth           AuthProvider
+	Assertions     map[string][]Assertion
+}
+
+// Run sends every request in the collection in order, resolving variables
+// from the scopes given by ItemScopes for each request, and returns a
+// summary of the executions.  Requests not sent before the deadline are left
+// out of the summary.  Requests that can't be built, such as those with an
+// unsupported body mode, are recorded as failed executions like requests
+// that fail to send.  An error is returned only when an item's request can't
+// be decoded.
+func (c *Collection) Run(ctx context.Context, options RunOptions, scopes ...VariableScope) (*RunSummary, error) {
+	client := options.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	if !options.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, options.Deadline)
+		defer cancel()
+	}
+
+	summary := &RunSummary{
+		Info:       RunInfo{Status: RunStatusSuccess, StartedAt: time.Now()},
+		Executions: []RunExecution{},
+	}
+	if c.Collection != nil && c.Info != nil {
+		summary.Info.Name = c.Info.Name
+	}
+
+	var (
+		err  error
+		stop bool
+	)
+
+	if c.Items != nil {
+		walkItemTree(&c.Items.Root, nil, func(path []string, item Item) {
+			if err != nil || stop || item.Item == nil || item.Item.Request == nil {
+				return
+			}
+
+			if ctx.Err() != nil {
+				summary.Info.Status = RunStatusFailed
+				stop = true
+				return
+			}
+
+			var r *Request
+			if r, err = ParseRequest(item.Item.Request); err != nil {
+				return
+			}
+
+			itemScopes := c.requestScopes(path, scopes)
+
+			var execution RunExecution
+			if authErr := c.inheritAuth(r, path); authErr != nil {
+				execution = buildFailure(r, authErr, itemScopes)
+			} else {
+				execution = runRequest(ctx, client, options, r, itemScopes)
+			}
+
+			execution.ID = len(summary.Executions) + 1
+			execution.Item = RunItem{ID: item.ID, Name: item.Name}
+			runAssertions(&execution, options.Assertions[item.Name])
+			summary.Executions = append(summary.Executions, execution)
+
+			for _, a := range execution.Assertions {
+				summary.Stats.Assertions.Total++
+				if a.Error != nil {
+					summary.Stats.Assertions.Failed++
+					summary.Info.Status = RunStatusFailed
+				}
+			}
+
+			summary.Stats.Requests.Total++
+			if execution.Error != nil {
+				summary.Stats.Requests.Failed++
+				summary.Info.Status = RunStatusFailed
+				stop = options.StopOnFailure
+			}
+		})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	summary.Info.FinishedAt = time.Now()
+
+	return summary, nil
+}
+
+// runRequest sends a single request of a run.  Failures to build or send the
+// request or read its response are recorded in the execution's Error.
+func runRequest(ctx context.Context, client *http.Client, options RunOptions, r *Request, scopes []VariableScope) RunExecution {
+	if options.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.RequestTimeout)
+		defer cancel()
+	}
+
+	req, err := r.httpRequest(ctx, options.Auth, scopes...)
+	if err != nil {
+		return buildFailure(r, err, scopes)
+	}
+
+	execution := RunExecution{
+		Request: RunRequest{
+			Method:    req.Method,
+			URL:       req.URL.String(),
+			Headers:   flattenHeaders(req.Header),
+			Timestamp: time.Now(),
+		},
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := ioutil.ReadAll(body)
+			body.Close()
+			execution.Request.Body = runBody(data)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		execution.Error = runError(err)
+		return execution
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		execution.Error = runError(err)
+		return execution
+	}
+
+	execution.Response = &RunResponse{
+		Code:         resp.StatusCode,
+		Headers:      flattenHeaders(resp.Header),
+		Body:         runBody(data),
+		ResponseSize: len(data),
+		ResponseTime: int(time.Since(execution.Request.Timestamp) / time.Millisecond),
+	}
+
+	return execution
+}
+
+// buildFailure records a request of a run that couldn't be built as a
+// failed execution, describing the request as far as it is known.
+func buildFailure(r *Request, err error, scopes []VariableScope) RunExecution {
+	method := r.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	return RunExecution{
+		Request: RunRequest{
+			Method:    strings.ToUpper(method),
+			URL:       r.ResolvedURL(scopes...),
+			Timestamp: time.Now(),
+		},
+		Error: runError(err),
+	}
+}
+
+// runError describes a failed request, naming timeouts as such.
+func runError(err error) *RunError {
+	name := "Error"
+	if errors.Is(err, context.DeadlineExceeded) {
+		name = "TimeoutError"
+	}
+
+	return &RunError{Name: name, Message: err.Error()}
+}
+
+// runBody encodes a body as a JSON string, as run bodies are reported.
+func runBody(data []byte) json.RawMessage {
+	if len(data) == 0 {
+		return nil
+	}
+
+	// swallow error here, strings will always marshal
+	b, _ := json.Marshal(string(data))
+	return b
+}
+
+// flattenHeaders joins repeated headers into a single comma separated value.
+func flattenHeaders(h http.Header) map[string]string {
+	m := make(map[string]string, len(h))
+	for k := range h {
+		m[k] = strings.Join(h[k], ", ")
+	}
+
+	return m
+}
@@ -0,0 +1,339 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func newRunServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+	mux.HandleFunc("/fast", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write([]byte("ok")); err != nil {
+			t.Error(err)
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func runCollection(t *testing.T, baseURL string) *resources.Collection {
+	t.Helper()
+
+	return unmarshalCollection(t, `{
+		"info": {"name": "run", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"variable": [{"key": "baseUrl", "value": "`+baseURL+`"}],
+		"item": [
+			{"name": "Slow", "request": {"method": "GET", "url": "{{baseUrl}}/slow"}},
+			{"name": "Fast", "request": {"method": "POST", "url": "{{baseUrl}}/fast", "body": {"mode": "raw", "raw": "ping"}}}
+		]
+	}`)
+}
+
+func TestCollectionRunRequestTimeout(t *testing.T) {
+	server := newRunServer(t)
+	defer server.Close()
+
+	summary, err := runCollection(t, server.URL).Run(context.Background(), resources.RunOptions{RequestTimeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(summary.Executions) != 2 {
+		t.Fatalf("Expected 2 executions, have: %d", len(summary.Executions))
+	}
+
+	slow := summary.Executions[0]
+	if slow.Item.Name != "Slow" || slow.Error == nil || slow.Error.Name != "TimeoutError" || slow.Response != nil {
+		t.Errorf("Expected the slow request to time out, have: %+v", slow)
+	}
+
+	fast := summary.Executions[1]
+	if fast.Error != nil || fast.Response == nil || fast.Response.Code != http.StatusOK {
+		t.Fatalf("Expected the fast request to succeed, have: %+v", fast)
+	}
+
+	if have, want := string(fast.Request.Body), `"ping"`; have != want {
+		t.Errorf("Request body is incorrect, have: %s, want: %s", have, want)
+	}
+
+	if !strings.HasSuffix(fast.Request.URL, "/fast") {
+		t.Errorf("Request URL is incorrect, have: %s", fast.Request.URL)
+	}
+
+	if summary.Stats.Requests.Total != 2 || summary.Stats.Requests.Failed != 1 || summary.Info.Status != resources.RunStatusFailed {
+		t.Errorf("Run results are incorrect, have: %+v %+v", summary.Info, summary.Stats)
+	}
+}
+
+func TestCollectionRunStopOnFailure(t *testing.T) {
+	server := newRunServer(t)
+	defer server.Close()
+
+	summary, err := runCollection(t, server.URL).Run(context.Background(), resources.RunOptions{
+		RequestTimeout: 50 * time.Millisecond,
+		StopOnFailure:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(summary.Executions) != 1 || summary.Executions[0].Error == nil {
+		t.Errorf("Expected the run to stop after the failed request, have: %+v", summary.Executions)
+	}
+}
+
+func formDataCollection(t *testing.T, baseURL string) *resources.Collection {
+	t.Helper()
+
+	return unmarshalCollection(t, `{
+		"info": {"name": "run", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"item": [
+			{"name": "Upload", "request": {"method": "POST", "url": "`+baseURL+`/upload", "body": {"mode": "formdata", "formdata": [{"key": "file", "type": "file", "src": "a.txt"}]}}},
+			{"name": "Fast", "request": {"method": "GET", "url": "`+baseURL+`/fast"}}
+		]
+	}`)
+}
+
+func TestCollectionRunRecordsBuildFailures(t *testing.T) {
+	server := newRunServer(t)
+	defer server.Close()
+
+	summary, err := formDataCollection(t, server.URL).Run(context.Background(), resources.RunOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(summary.Executions) != 2 {
+		t.Fatalf("Expected 2 executions, have: %+v", summary.Executions)
+	}
+
+	upload := summary.Executions[0]
+	if upload.Item.Name != "Upload" || upload.Error == nil || upload.Response != nil {
+		t.Errorf("Expected the formdata request to fail, have: %+v", upload)
+	}
+
+	if have, want := upload.Request.URL, server.URL+"/upload"; upload.Request.Method != http.MethodPost || have != want {
+		t.Errorf("Failed request is incorrect, have: %s %s, want: POST %s", upload.Request.Method, have, want)
+	}
+
+	fast := summary.Executions[1]
+	if fast.Error != nil || fast.Response == nil || fast.Response.Code != http.StatusOK {
+		t.Errorf("Expected the fast request to succeed, have: %+v", fast)
+	}
+
+	if summary.Stats.Requests.Total != 2 || summary.Stats.Requests.Failed != 1 || summary.Info.Status != resources.RunStatusFailed {
+		t.Errorf("Run results are incorrect, have: %+v %+v", summary.Info, summary.Stats)
+	}
+
+	summary, err = formDataCollection(t, server.URL).Run(context.Background(), resources.RunOptions{StopOnFailure: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(summary.Executions) != 1 || summary.Executions[0].Error == nil {
+		t.Errorf("Expected the run to stop after the failed build, have: %+v", summary.Executions)
+	}
+}
+
+func TestCollectionRunInDeclaredOrder(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+	}))
+	defer server.Close()
+
+	c := unmarshalCollection(t, `{
+		"info": {"name": "run", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"item": [
+			{"name": "Login", "request": {"method": "POST", "url": "`+server.URL+`/login"}},
+			{"name": "Users", "item": [
+				{"name": "Admins", "item": [{"name": "List admins", "request": {"method": "GET", "url": "`+server.URL+`/admins"}}]},
+				{"name": "List users", "request": {"method": "GET", "url": "`+server.URL+`/users"}}
+			]},
+			{"name": "Logout", "request": {"method": "POST", "url": "`+server.URL+`/logout"}}
+		]
+	}`)
+
+	summary, err := c.Run(context.Background(), resources.RunOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "/login,/admins,/users,/logout"
+	if have := strings.Join(paths, ","); have != want {
+		t.Errorf("Request order is incorrect, have: %s, want: %s", have, want)
+	}
+
+	var names []string
+	for _, e := range summary.Executions {
+		names = append(names, e.Item.Name)
+	}
+
+	if have, want := strings.Join(names, ","), "Login,List admins,List users,Logout"; have != want {
+		t.Errorf("Execution order is incorrect, have: %s, want: %s", have, want)
+	}
+}
+
+func TestCollectionRunDeadline(t *testing.T) {
+	server := newRunServer(t)
+	defer server.Close()
+
+	summary, err := runCollection(t, server.URL).Run(context.Background(), resources.RunOptions{
+		Deadline: time.Now().Add(100 * time.Millisecond),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(summary.Executions) != 1 || summary.Executions[0].Error == nil {
+		t.Errorf("Expected only the timed out request, have: %+v", summary.Executions)
+	}
+
+	if summary.Info.Status != resources.RunStatusFailed {
+		t.Errorf("Status is incorrect, have: %s, want: %s", summary.Info.Status, resources.RunStatusFailed)
+	}
+}
+
+func TestCollectionRunWithRuntimeAuth(t *testing.T) {
+	var auths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	c := unmarshalCollection(t, `{
+		"info": {"name": "run", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"item": [
+			{"name": "Stored", "request": {"method": "GET", "url": "`+server.URL+`/a", "auth": {"type": "basic", "basic": [{"key": "username", "value": "stored"}]}}},
+			{"name": "None", "request": {"method": "GET", "url": "`+server.URL+`/b"}}
+		]
+	}`)
+
+	if _, err := c.Run(context.Background(), resources.RunOptions{Auth: resources.BearerAuth{Token: "runtime"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(auths) != 2 || auths[0] != "Bearer runtime" || auths[1] != "Bearer runtime" {
+		t.Errorf("Authorization headers are incorrect, have: %q", auths)
+	}
+
+	auth, err := c.EffectiveAuth([]string{"Stored"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if auth == nil || auth.Type != "basic" {
+		t.Errorf("Stored auth was modified, have: %+v", auth)
+	}
+}
+
+func TestCollectionRunInheritsFolderAuth(t *testing.T) {
+	auths := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auths[r.URL.Path] = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	c := unmarshalCollection(t, `{
+		"info": {"name": "run", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"item": [
+			{"name": "Admin", "auth": {"type": "bearer", "bearer": [{"key": "token", "value": "folder"}]}, "item": [
+				{"name": "Inherited", "request": {"method": "GET", "url": "`+server.URL+`/a"}},
+				{"name": "Public", "request": {"method": "GET", "url": "`+server.URL+`/b", "auth": {"type": "noauth"}}}
+			]}
+		]
+	}`)
+
+	if _, err := c.Run(context.Background(), resources.RunOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := auths["/a"], "Bearer folder"; have != want {
+		t.Errorf("Inherited Authorization header is incorrect, have: %q, want: %q", have, want)
+	}
+
+	if have, ok := auths["/b"]; !ok || have != "" {
+		t.Errorf("Expected no Authorization header for noauth, have: %q", have)
+	}
+}
+
+func TestCollectionRunAssertions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if _, err := w.Write([]byte(`{"data":{"users":[{"name":"ada","age":36}]}}`)); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	c := unmarshalCollection(t, `{
+		"info": {"name": "run", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"item": [{"name": "Create user", "request": {"method": "POST", "url": "`+server.URL+`/users"}}]
+	}`)
+
+	summary, err := c.Run(context.Background(), resources.RunOptions{
+		Assertions: map[string][]resources.Assertion{
+			"Create user": {
+				resources.StatusEquals{Code: http.StatusCreated},
+				resources.StatusEquals{Code: http.StatusOK},
+				resources.HeaderPresent{Header: "content-type"},
+				resources.JSONPathEquals{Path: "$.data.users[0].age", Value: 36},
+				resources.JSONPathEquals{Path: "data.users[0].name", Value: "grace"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertions := summary.Executions[0].Assertions
+	if len(assertions) != 5 {
+		t.Fatalf("Expected 5 assertion results, have: %+v", assertions)
+	}
+
+	failed := []bool{false, true, false, false, true}
+	for i, a := range assertions {
+		if (a.Error != nil) != failed[i] {
+			t.Errorf("Assertion %q result is incorrect, have error: %v, want failed: %t", a.Assertion, a.Error, failed[i])
+		}
+	}
+
+	if have, want := assertions[4].Error.Message, `expected data.users[0].name to equal "grace", got "ada"`; have != want {
+		t.Errorf("Failure message is incorrect, have: %s, want: %s", have, want)
+	}
+
+	if summary.Stats.Assertions.Total != 5 || summary.Stats.Assertions.Failed != 2 || summary.Info.Status != resources.RunStatusFailed {
+		t.Errorf("Run results are incorrect, have: %+v %+v", summary.Info, summary.Stats)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"strings"
+)
+
+// CollectionSchemaVersion is a version of the Postman collection format.
+type CollectionSchemaVersion string
+
+// Versions of the Postman collection format.  CollectionSchemaUnknown is
+// recorded for collections declaring a schema that isn't recognized.
+const (
+	CollectionSchemaUnknown CollectionSchemaVersion = ""
+	CollectionSchemaV100    CollectionSchemaVersion = "v1.0.0"
+	CollectionSchemaV200    CollectionSchemaVersion = "v2.0.0"
+	CollectionSchemaV210    CollectionSchemaVersion = "v2.1.0"
+)
+
+// URL returns the canonical schema URL of the version, or "" when the
+// version is unknown.
+func (v CollectionSchemaVersion) URL() string {
+	switch v {
+	case CollectionSchemaV100, CollectionSchemaV200, CollectionSchemaV210:
+		return "https://schema.getpostman.com/json/collection/" + string(v) + "/collection.json"
+	}
+
+	return ""
+}
+
+// NormalizeCollectionSchema returns the canonical form of a collection
+// schema URL along with the version it declares.  Variants in scheme, host,
+// case, abbreviated versions, and trailing path are accepted.  Unknown
+// schemas are returned unchanged with CollectionSchemaUnknown.
+func NormalizeCollectionSchema(schema string) (string, CollectionSchemaVersion) {
+	v := collectionSchemaVersion(schema)
+	if v == CollectionSchemaUnknown {
+		return schema, v
+	}
+
+	return v.URL(), v
+}
+
+func collectionSchemaVersion(schema string) CollectionSchemaVersion {
+	s := strings.ToLower(strings.TrimSpace(schema))
+	for _, prefix := range []string{"https://", "http://", "//"} {
+		if strings.HasPrefix(s, prefix) {
+			s = s[len(prefix):]
+			break
+		}
+	}
+	s = strings.TrimPrefix(s, "www.")
+
+	var host bool
+	for _, h := range []string{"schema.getpostman.com/", "schema.postman.com/"} {
+		if strings.HasPrefix(s, h) {
+			s, host = s[len(h):], true
+			break
+		}
+	}
+
+	if !host {
+		return CollectionSchemaUnknown
+	}
+
+	s = strings.TrimPrefix(s, "json/")
+	if !strings.HasPrefix(s, "collection/") {
+		return CollectionSchemaUnknown
+	}
+	s = s[len("collection/"):]
+
+	segment := s
+	if i := strings.IndexAny(s, "/#?"); i >= 0 {
+		segment = s[:i]
+	}
+
+	if !strings.HasPrefix(segment, "v") {
+		return CollectionSchemaUnknown
+	}
+
+	parts := strings.Split(segment[1:], ".")
+	if len(parts) > 3 {
+		return CollectionSchemaUnknown
+	}
+	for len(parts) < 3 {
+		parts = append(parts, "0")
+	}
+
+	v := CollectionSchemaVersion("v" + strings.Join(parts, "."))
+	if v.URL() == "" {
+		return CollectionSchemaUnknown
+	}
+
+	return v
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestCollectionSchemaNormalization(t *testing.T) {
+	canonical := "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+	subjects := []string{
+		"https://schema.getpostman.com/json/collection/v2.1.0/collection.json",
+		"http://schema.getpostman.com/json/collection/v2.1.0/collection.json",
+		"https://schema.postman.com/json/collection/v2.1.0/collection.json",
+		"https://schema.getpostman.com/json/collection/v2.1.0/",
+		"https://schema.getpostman.com/json/collection/v2.1.0",
+		"https://schema.getpostman.com/json/collection/v2.1/collection.json",
+		" HTTPS://Schema.GetPostman.com/json/collection/v2.1.0/collection.json#",
+	}
+
+	for _, s := range subjects {
+		var c resources.Collection
+		data := `{"info":{"name":"Users","schema":"` + s + `"},"item":[]}`
+		if err := json.Unmarshal([]byte(data), &c); err != nil {
+			t.Fatal(err)
+		}
+
+		if c.SchemaVersion != resources.CollectionSchemaV210 {
+			t.Errorf("Schema version of %q is incorrect, have: %q, want: %q", s, c.SchemaVersion, resources.CollectionSchemaV210)
+		}
+
+		if c.Info.Schema != canonical {
+			t.Errorf("Schema of %q is incorrect, have: %s, want: %s", s, c.Info.Schema, canonical)
+		}
+	}
+}
+
+func TestCollectionSchemaVersions(t *testing.T) {
+	subjects := map[string]resources.CollectionSchemaVersion{
+		"https://schema.getpostman.com/collection/v1":                          resources.CollectionSchemaV100,
+		"https://schema.getpostman.com/json/collection/v2.0.0/collection.json": resources.CollectionSchemaV200,
+		"https://schema.getpostman.com/json/collection/v3.0.0/collection.json": resources.CollectionSchemaUnknown,
+		"https://example.com/json/collection/v2.1.0/collection.json":           resources.CollectionSchemaUnknown,
+		"": resources.CollectionSchemaUnknown,
+	}
+
+	for s, want := range subjects {
+		normalized, have := resources.NormalizeCollectionSchema(s)
+		if have != want {
+			t.Errorf("Schema version of %q is incorrect, have: %q, want: %q", s, have, want)
+		}
+
+		if want == resources.CollectionSchemaUnknown && normalized != s {
+			t.Errorf("Unknown schema %q was changed to %q", s, normalized)
+		}
+	}
+}
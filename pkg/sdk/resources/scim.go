@@ -0,0 +1,127 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+// SCIM schema URNs used by the Postman SCIM API.
+const (
+	SCIMUserSchema    = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMGroupSchema   = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMPatchOpSchema = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+)
+
+// SCIMUserListResponse represents a page of the SCIM users response from the
+// Postman API.
+type SCIMUserListResponse struct {
+	TotalResults int       `json:"totalResults"`
+	StartIndex   int       `json:"startIndex"`
+	ItemsPerPage int       `json:"itemsPerPage"`
+	Resources    SCIMUsers `json:"Resources"`
+}
+
+// SCIMUsers is a slice of SCIMUser.
+type SCIMUsers []SCIMUser
+
+// Format returns column headers and values for the resource.
+func (r SCIMUsers) Format() ([]string, []interface{}) {
+	s := make([]interface{}, len(r))
+	for i, v := range r {
+		s[i] = v
+	}
+
+	return []string{"ID", "UserName", "Active"}, s
+}
+
+// SCIMUser represents the subset of the SCIM user schema supported by
+// Postman.
+type SCIMUser struct {
+	Schemas    []string  `json:"schemas"`
+	ID         string    `json:"id,omitempty"`
+	UserName   string    `json:"userName"`
+	Name       SCIMName  `json:"name"`
+	ExternalID string    `json:"externalId,omitempty"`
+	Active     bool      `json:"active"`
+	Meta       *SCIMMeta `json:"meta,omitempty"`
+}
+
+// Format returns column headers and values for the resource.
+func (r SCIMUser) Format() ([]string, []interface{}) {
+	s := make([]interface{}, 1)
+	s[0] = r
+
+	return []string{"ID", "UserName", "Active"}, s
+}
+
+// SCIMName is the name of a SCIM user.
+type SCIMName struct {
+	GivenName  string `json:"givenName"`
+	FamilyName string `json:"familyName"`
+}
+
+// SCIMMeta holds the resource metadata of a SCIM user or group.
+type SCIMMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created"`
+	LastModified string `json:"lastModified"`
+}
+
+// SCIMGroupListResponse represents a page of the SCIM groups response from
+// the Postman API.
+type SCIMGroupListResponse struct {
+	TotalResults int        `json:"totalResults"`
+	StartIndex   int        `json:"startIndex"`
+	ItemsPerPage int        `json:"itemsPerPage"`
+	Resources    SCIMGroups `json:"Resources"`
+}
+
+// SCIMGroups is a slice of SCIMGroup.
+type SCIMGroups []SCIMGroup
+
+// Format returns column headers and values for the resource.
+func (r SCIMGroups) Format() ([]string, []interface{}) {
+	s := make([]interface{}, len(r))
+	for i, v := range r {
+		s[i] = v
+	}
+
+	return []string{"ID", "DisplayName"}, s
+}
+
+// SCIMGroup represents the subset of the SCIM group schema supported by
+// Postman.
+type SCIMGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	DisplayName string       `json:"displayName"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	Members     []SCIMMember `json:"members,omitempty"`
+	Meta        *SCIMMeta    `json:"meta,omitempty"`
+}
+
+// Format returns column headers and values for the resource.
+func (r SCIMGroup) Format() ([]string, []interface{}) {
+	s := make([]interface{}, 1)
+	s[0] = r
+
+	return []string{"ID", "DisplayName"}, s
+}
+
+// SCIMMember is a user that belongs to a SCIM group, referenced by the
+// user's ID in Value.
+type SCIMMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
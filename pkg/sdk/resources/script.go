@@ -0,0 +1,398 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ScriptWarning is returned by SimulateScript when it skipped statements
+// outside the subset it supports.  The supported statements were still
+// applied.
+type ScriptWarning struct {
+	Unsupported []string
+}
+
+func (e *ScriptWarning) Error() string {
+	return fmt.Sprintf("skipped %d unsupported script statement(s): %s", len(e.Unsupported), strings.Join(e.Unsupported, "; "))
+}
+
+// SimulateScript applies the variable changes made by a pre-request script
+// to env without executing JavaScript.  It understands
+// pm.environment.set/get, pm.variables.set/get, local var, let, and const
+// declarations, and string, number, and boolean literals joined with +.
+// Variables set with pm.variables.set only last for the script, as in
+// Postman.  Other statements are skipped, returning a *ScriptWarning that
+// lists them.
+func SimulateScript(src string, env *Environment) error {
+	if env == nil {
+		return errors.New("an environment is required to simulate a script")
+	}
+
+	statements, err := splitScript(src)
+	if err != nil {
+		return err
+	}
+
+	sim := scriptSimulator{env: env, locals: make(map[string]interface{})}
+
+	var warning ScriptWarning
+	for _, s := range statements {
+		if !sim.exec(s.tokens) {
+			warning.Unsupported = append(warning.Unsupported, s.text)
+		}
+	}
+
+	if len(warning.Unsupported) > 0 {
+		return &warning
+	}
+
+	return nil
+}
+
+// scriptStatement is a statement of a script, with its source text for
+// warnings.
+type scriptStatement struct {
+	text   string
+	tokens []scriptToken
+}
+
+type scriptTokenKind int
+
+const (
+	scriptIdent scriptTokenKind = iota
+	scriptString
+	scriptNumber
+	scriptPunct
+	scriptOpaque
+)
+
+type scriptToken struct {
+	kind scriptTokenKind
+	text string
+}
+
+// splitScript tokenizes src into statements, ending them at semicolons and
+// at line breaks outside parentheses that don't follow an operator.
+// Comments are dropped.
+func splitScript(src string) ([]scriptStatement, error) {
+	var statements []scriptStatement
+	var tokens []scriptToken
+	start, depth := -1, 0
+
+	end := func(pos int) {
+		if len(tokens) > 0 {
+			statements = append(statements, scriptStatement{
+				text:   strings.TrimSpace(src[start:pos]),
+				tokens: tokens,
+			})
+		}
+		tokens, start, depth = nil, -1, 0
+	}
+
+	continued := func() bool {
+		if len(tokens) == 0 || depth > 0 {
+			return true
+		}
+
+		last := tokens[len(tokens)-1]
+		return last.kind == scriptPunct && strings.ContainsAny(last.text, "+=,(")
+	}
+
+	for i := 0; i < len(src); {
+		c := src[i]
+
+		switch {
+		case c == '\n':
+			if !continued() {
+				end(i)
+			}
+			i++
+			continue
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+			continue
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			continue
+		case strings.HasPrefix(src[i:], "/*"):
+			n := strings.Index(src[i+2:], "*/")
+			if n < 0 {
+				return nil, errors.New("unterminated comment in script")
+			}
+			i += n + 4
+			continue
+		case c == ';' && depth == 0:
+			end(i)
+			i++
+			continue
+		}
+
+		if start < 0 {
+			start = i
+		}
+
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			j := i + 1
+			var sb strings.Builder
+			for ; j < len(src) && src[j] != c; j++ {
+				if src[j] == '\\' && j+1 < len(src) {
+					j++
+					if e, ok := scriptEscapes[src[j]]; ok {
+						sb.WriteByte(e)
+						continue
+					}
+				}
+				sb.WriteByte(src[j])
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("unterminated string in script at offset %d", i)
+			}
+
+			token := scriptToken{kind: scriptString, text: sb.String()}
+			if c == '`' && strings.Contains(token.text, "${") {
+				token.kind = scriptOpaque
+			}
+			tokens = append(tokens, token)
+			i = j + 1
+		case isScriptIdentByte(c):
+			j := i
+			for j < len(src) && (isScriptIdentByte(src[j]) || src[j] == '.') {
+				j++
+			}
+
+			kind := scriptIdent
+			if c >= '0' && c <= '9' {
+				kind = scriptNumber
+			}
+			tokens = append(tokens, scriptToken{kind: kind, text: src[i:j]})
+			i = j
+		default:
+			switch c {
+			case '(', '[', '{':
+				depth++
+			case ')', ']', '}':
+				depth--
+			}
+			tokens = append(tokens, scriptToken{kind: scriptPunct, text: string(c)})
+			i++
+		}
+	}
+	end(len(src))
+
+	return statements, nil
+}
+
+var scriptEscapes = map[byte]byte{'n': '\n', 't': '\t', 'r': '\r'}
+
+func isScriptIdentByte(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// scriptSimulator holds the state of a simulated script.
+type scriptSimulator struct {
+	env    *Environment
+	locals map[string]interface{}
+}
+
+// exec applies a statement, reporting whether it was supported.
+func (s *scriptSimulator) exec(tokens []scriptToken) bool {
+	if len(tokens) > 3 && tokens[0].kind == scriptIdent && tokens[1].kind == scriptIdent && tokens[2].text == "=" {
+		switch tokens[0].text {
+		case "var", "let", "const":
+			v, rest, ok := s.expr(tokens[3:])
+			if !ok || len(rest) > 0 || strings.Contains(tokens[1].text, ".") {
+				return false
+			}
+			s.locals[tokens[1].text] = v
+			return true
+		}
+	}
+
+	if len(tokens) == 0 || tokens[0].kind != scriptIdent {
+		return false
+	}
+
+	switch tokens[0].text {
+	case "pm.environment.set", "pm.variables.set":
+		args, ok := s.args(tokens[1:], 2)
+		if !ok {
+			return false
+		}
+
+		key := scriptText(args[0])
+		if tokens[0].text == "pm.variables.set" {
+			s.locals[scriptLocalKey(key)] = args[1]
+		} else {
+			s.setEnvironment(key, scriptText(args[1]))
+		}
+		return true
+	case "pm.environment.get", "pm.variables.get":
+		_, ok := s.args(tokens[1:], 1)
+		return ok
+	}
+
+	return false
+}
+
+// args parses the parenthesized arguments of a call, which must make up
+// the rest of the statement.
+func (s *scriptSimulator) args(tokens []scriptToken, n int) ([]interface{}, bool) {
+	args, rest, ok := s.call(tokens, n)
+	return args, ok && len(rest) == 0
+}
+
+func (s *scriptSimulator) call(tokens []scriptToken, n int) ([]interface{}, []scriptToken, bool) {
+	if len(tokens) == 0 || tokens[0].text != "(" {
+		return nil, nil, false
+	}
+	tokens = tokens[1:]
+
+	args := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			if len(tokens) == 0 || tokens[0].text != "," {
+				return nil, nil, false
+			}
+			tokens = tokens[1:]
+		}
+
+		v, rest, ok := s.expr(tokens)
+		if !ok {
+			return nil, nil, false
+		}
+		args = append(args, v)
+		tokens = rest
+	}
+
+	if len(tokens) == 0 || tokens[0].text != ")" {
+		return nil, nil, false
+	}
+
+	return args, tokens[1:], true
+}
+
+// expr evaluates terms joined by +, returning the tokens after it.
+func (s *scriptSimulator) expr(tokens []scriptToken) (interface{}, []scriptToken, bool) {
+	v, tokens, ok := s.term(tokens)
+	for ok && len(tokens) > 0 && tokens[0].text == "+" {
+		var w interface{}
+		if w, tokens, ok = s.term(tokens[1:]); ok {
+			v = scriptAdd(v, w)
+		}
+	}
+
+	return v, tokens, ok
+}
+
+func (s *scriptSimulator) term(tokens []scriptToken) (interface{}, []scriptToken, bool) {
+	if len(tokens) == 0 {
+		return nil, nil, false
+	}
+
+	t := tokens[0]
+	switch t.kind {
+	case scriptString:
+		return t.text, tokens[1:], true
+	case scriptNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		return f, tokens[1:], err == nil
+	case scriptIdent:
+		switch t.text {
+		case "true", "false":
+			return t.text == "true", tokens[1:], true
+		case "pm.environment.get", "pm.variables.get":
+			args, rest, ok := s.call(tokens[1:], 1)
+			if !ok {
+				return nil, nil, false
+			}
+
+			key := scriptText(args[0])
+			if t.text == "pm.variables.get" {
+				if v, ok := s.locals[scriptLocalKey(key)]; ok {
+					return v, rest, true
+				}
+			}
+			return s.getEnvironment(key), rest, true
+		}
+
+		v, ok := s.locals[t.text]
+		return v, tokens[1:], ok
+	}
+
+	return nil, nil, false
+}
+
+func (s *scriptSimulator) getEnvironment(key string) interface{} {
+	for _, v := range s.env.Values {
+		if v.Key == key && v.Enabled {
+			return v.Value
+		}
+	}
+
+	// Unset variables are undefined in Postman, which concatenates as such.
+	return "undefined"
+}
+
+func (s *scriptSimulator) setEnvironment(key, value string) {
+	for i, v := range s.env.Values {
+		if v.Key == key {
+			s.env.Values[i].Value = value
+			s.env.Values[i].Enabled = true
+			return
+		}
+	}
+
+	s.env.Values = append(s.env.Values, KeyValuePair{Key: key, Value: value, Enabled: true})
+}
+
+// scriptLocalKey keeps variables set with pm.variables.set apart from
+// locally declared names.
+func scriptLocalKey(key string) string {
+	return "pm.variables." + key
+}
+
+// scriptAdd applies + the way JavaScript does to strings, numbers, and
+// booleans.
+func scriptAdd(a, b interface{}) interface{} {
+	x, xok := a.(float64)
+	y, yok := b.(float64)
+	if xok && yok {
+		return x + y
+	}
+
+	return scriptText(a) + scriptText(b)
+}
+
+func scriptText(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	}
+
+	return fmt.Sprint(v)
+}
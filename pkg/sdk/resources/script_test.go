@@ -0,0 +1,111 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func environmentValue(env *resources.Environment, key string) (string, bool) {
+	for _, v := range env.Values {
+		if v.Key == key {
+			return v.Value, true
+		}
+	}
+
+	return "", false
+}
+
+func TestSimulateScriptSetsVariables(t *testing.T) {
+	env := &resources.Environment{
+		Values: []resources.KeyValuePair{
+			{Key: "host", Value: "api.example.com", Enabled: true},
+			{Key: "token", Value: "stale", Enabled: true},
+		},
+	}
+
+	src := `// Build the base URL.
+const scheme = 'https://';
+pm.variables.set("version", 2)
+pm.environment.set("baseUrl", scheme + pm.environment.get("host") +
+	"/v" + pm.variables.get("version"));
+pm.environment.set('token', "fresh")
+pm.environment.set("retries", 1 + 2);`
+
+	if err := resources.SimulateScript(src, env); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]string{
+		"baseUrl": "https://api.example.com/v2",
+		"token":   "fresh",
+		"retries": "3",
+	}
+	for key, want := range cases {
+		if have, _ := environmentValue(env, key); have != want {
+			t.Errorf("Variable %s is incorrect, have: %q, want: %q", key, have, want)
+		}
+	}
+
+	if _, ok := environmentValue(env, "version"); ok {
+		t.Errorf("Local variable was written to the environment.")
+	}
+
+	if len(env.Values) != 4 {
+		t.Errorf("Environment length is incorrect, have: %d, want: %d", len(env.Values), 4)
+	}
+}
+
+func TestSimulateScriptWarnsOnUnsupported(t *testing.T) {
+	env := &resources.Environment{}
+
+	src := `pm.environment.set("a", "1");
+console.log(pm.environment.get("a"));
+pm.environment.set("b", Date.now());
+pm.environment.set("c", pm.environment.get("a") + "!");`
+
+	err := resources.SimulateScript(src, env)
+
+	var warning *resources.ScriptWarning
+	if !errors.As(err, &warning) {
+		t.Fatalf("Error type is incorrect, have: %T, want: %T", err, warning)
+	}
+
+	if len(warning.Unsupported) != 2 || warning.Unsupported[0] != `console.log(pm.environment.get("a"))` {
+		t.Errorf("Unsupported statements are incorrect, have: %q", warning.Unsupported)
+	}
+
+	if have, _ := environmentValue(env, "c"); have != "1!" {
+		t.Errorf("Variable c is incorrect, have: %q, want: %q", have, "1!")
+	}
+
+	if _, ok := environmentValue(env, "b"); ok {
+		t.Errorf("Unsupported statement was applied.")
+	}
+}
+
+func TestSimulateScriptUnterminatedString(t *testing.T) {
+	err := resources.SimulateScript(`pm.environment.set("a, "1");`, &resources.Environment{})
+
+	var warning *resources.ScriptWarning
+	if err == nil || errors.As(err, &warning) {
+		t.Errorf("Expected syntax error, have: %v", err)
+	}
+}
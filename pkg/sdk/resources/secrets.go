@@ -0,0 +1,174 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources/gen"
+)
+
+// SecretPattern detects one kind of hardcoded secret.  When MinEntropy is
+// set, matches with a lower Shannon entropy in bits per character are
+// ignored.
+type SecretPattern struct {
+	ID         string
+	Pattern    *regexp.Regexp
+	MinEntropy float64
+}
+
+// SecretFinding is a possible hardcoded secret found in a collection.
+type SecretFinding struct {
+	PatternID string
+	Path      []string
+	Location  string
+	Snippet   string
+}
+
+func (f SecretFinding) String() string {
+	return fmt.Sprintf("[%s] %s (%s): %s", f.PatternID, strings.Join(f.Path, "/"), f.Location, f.Snippet)
+}
+
+// DefaultSecretPatterns returns the built-in secret patterns.
+func DefaultSecretPatterns() []SecretPattern {
+	return []SecretPattern{
+		{ID: "aws-access-key", Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+		{ID: "bearer-token", Pattern: regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]{16,}=*`)},
+		{ID: "secret-query-param", Pattern: regexp.MustCompile(`(?i)[?&](?:api_?key|access_token|token|secret|client_secret|password)=[^&#{\s]+`)},
+		{ID: "high-entropy-string", Pattern: regexp.MustCompile(`[A-Za-z0-9+/_\-]{32,}={0,2}`), MinEntropy: 4.5},
+	}
+}
+
+// ScanSecrets looks for hardcoded secrets in the URL, headers, auth, and body
+// of every request in the collection.  The default patterns are used when no
+// patterns are provided.  Values containing variable references are not
+// reported.
+func (c *Collection) ScanSecrets(patterns ...SecretPattern) []SecretFinding {
+	if len(patterns) == 0 {
+		patterns = DefaultSecretPatterns()
+	}
+
+	var findings []SecretFinding
+	if c.Items == nil {
+		return findings
+	}
+
+	walkItemTree(&c.Items.Root, nil, func(path []string, item Item) {
+		if item.Item == nil || item.Item.Request == nil {
+			return
+		}
+
+		r, err := ParseRequest(item.Item.Request)
+		if err != nil {
+			return
+		}
+
+		for _, f := range requestFields(r) {
+			for _, p := range patterns {
+				for _, m := range p.Pattern.FindAllString(f.value, -1) {
+					if p.MinEntropy > 0 && shannonEntropy(m) < p.MinEntropy {
+						continue
+					}
+
+					findings = append(findings, SecretFinding{
+						PatternID: p.ID,
+						Path:      path,
+						Location:  f.location,
+						Snippet:   redact(m),
+					})
+				}
+			}
+		}
+	})
+
+	return findings
+}
+
+type requestField struct {
+	location string
+	value    string
+}
+
+// requestFields returns the scannable values of a request without variable
+// references.
+func requestFields(r *Request) []requestField {
+	fields := []requestField{{"url", r.URL.String()}}
+	for _, h := range r.Header {
+		fields = append(fields, requestField{"header " + h.Key, h.Value})
+	}
+
+	if r.Auth != nil {
+		for _, attrs := range [][]*gen.AuthAttribute{r.Auth.Apikey, r.Auth.Basic, r.Auth.Bearer, r.Auth.Oauth2} {
+			for _, a := range attrs {
+				if s, ok := a.Value.(string); ok && a.Key != "key" && a.Key != "username" {
+					fields = append(fields, requestField{"auth " + a.Key, s})
+				}
+			}
+		}
+	}
+
+	if r.Body != nil {
+		fields = append(fields, requestField{"body", r.Body.Raw})
+		for _, p := range r.Body.URLEncoded {
+			fields = append(fields, requestField{"body " + p.Key, p.Value})
+		}
+	}
+
+	ret := fields[:0]
+	for _, f := range fields {
+		f.value = variablePattern.ReplaceAllString(f.value, " ")
+		if strings.TrimSpace(f.value) != "" {
+			ret = append(ret, f)
+		}
+	}
+
+	return ret
+}
+
+// redact keeps the first few characters of a secret for identification.
+func redact(s string) string {
+	const visible = 4
+	if len(s) <= visible*2 {
+		return strings.Repeat("*", len(s))
+	}
+
+	return s[:visible] + strings.Repeat("*", len(s)-visible)
+}
+
+// shannonEntropy returns the entropy of s in bits per character.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+
+	var entropy float64
+	n := float64(len(s))
+	for _, c := range counts {
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
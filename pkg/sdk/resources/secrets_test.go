@@ -0,0 +1,104 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+const secretsSubject = `{
+  "info": {"name": "secrets", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "item": [
+    {
+      "name": "Users",
+      "item": [
+        {
+          "name": "List Users",
+          "request": {
+            "method": "GET",
+            "url": "https://api.example.com/users?page=1&api_key=sk_live_51HqLyj",
+            "header": [
+              {"key": "Authorization", "value": "Bearer eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxMjM0NTY3ODkwIn0"},
+              {"key": "X-Trace", "value": "{{traceId}}"}
+            ]
+          }
+        }
+      ]
+    },
+    {
+      "name": "Safe",
+      "request": {
+        "method": "GET",
+        "url": "{{baseUrl}}/safe?api_key={{apiKey}}",
+        "header": [{"key": "Authorization", "value": "Bearer {{token}}"}]
+      }
+    }
+  ]
+}`
+
+func TestScanSecrets(t *testing.T) {
+	c := unmarshalCollection(t, secretsSubject)
+
+	findings := c.ScanSecrets()
+
+	found := make(map[string]resources.SecretFinding)
+	for _, f := range findings {
+		found[f.PatternID] = f
+	}
+
+	bearer, ok := found["bearer-token"]
+	if !ok {
+		t.Fatalf("Expected a bearer token finding, have: %v", findings)
+	}
+
+	if strings.Join(bearer.Path, "/") != "Users/List Users" || bearer.Location != "header Authorization" {
+		t.Errorf("Bearer token finding is incorrect, have: %s", bearer)
+	}
+
+	if strings.Contains(bearer.Snippet, "eyJhbGciOiJIUzI1NiJ9") {
+		t.Errorf("Expected snippet to be redacted, have: %s", bearer.Snippet)
+	}
+
+	query, ok := found["secret-query-param"]
+	if !ok {
+		t.Fatalf("Expected a query param finding, have: %v", findings)
+	}
+
+	if query.Location != "url" || strings.Contains(query.Snippet, "sk_live") {
+		t.Errorf("Query param finding is incorrect, have: %s", query)
+	}
+
+	for _, f := range findings {
+		if f.Path[0] == "Safe" {
+			t.Errorf("Expected variable references not to be reported, have: %s", f)
+		}
+	}
+}
+
+func TestScanSecretsCustomPattern(t *testing.T) {
+	c := unmarshalCollection(t, secretsSubject)
+
+	findings := c.ScanSecrets(resources.SecretPattern{ID: "stripe-key", Pattern: regexp.MustCompile(`sk_live_[0-9A-Za-z]+`)})
+
+	if len(findings) != 1 || findings[0].PatternID != "stripe-key" || findings[0].Snippet != "sk_l***********" {
+		t.Errorf("Findings are incorrect, have: %v", findings)
+	}
+}
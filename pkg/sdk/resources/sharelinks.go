@@ -0,0 +1,68 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import "time"
+
+// ShareAccess is what holders of a share link can do with a collection.
+type ShareAccess string
+
+// Share link access levels.
+const (
+	ShareAccessView    ShareAccess = "view"
+	ShareAccessComment ShareAccess = "comment"
+)
+
+// Valid reports whether the access is a known share link access level.
+func (a ShareAccess) Valid() bool {
+	switch a {
+	case ShareAccessView, ShareAccessComment:
+		return true
+	}
+
+	return false
+}
+
+// ShareOptions configures a temporary share link.  ExpiresIn is required,
+// and Access defaults to view.
+type ShareOptions struct {
+	ExpiresIn time.Duration
+	Access    ShareAccess
+}
+
+// ShareLinkResponse is the top-level response of creating a share link in
+// the Postman API.
+type ShareLinkResponse struct {
+	ShareLink ShareLink `json:"shareLink"`
+}
+
+// ShareLink is a temporary link granting access to a collection until it
+// expires or is revoked.
+type ShareLink struct {
+	ID        string      `json:"id"`
+	URL       string      `json:"url"`
+	Access    ShareAccess `json:"access"`
+	ExpiresAt time.Time   `json:"expiresAt"`
+}
+
+// Format returns column headers and values for the resource.
+func (r ShareLink) Format() ([]string, []interface{}) {
+	s := make([]interface{}, 1)
+	s[0] = r
+
+	return []string{"ID", "URL", "ExpiresAt"}, s
+}
@@ -0,0 +1,34 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+// SyncOptions changes how a directory of collection files is synced into a
+// workspace.  With DryRun set, the report says what would change without
+// changing anything.
+type SyncOptions struct {
+	DryRun bool
+}
+
+// SyncReport records the outcome of a workspace sync by collection name:
+// collections created because the workspace had none by that name,
+// collections updated because their content differed, and collections
+// skipped because they were already up to date.
+type SyncReport struct {
+	Created []string `json:"created"`
+	Updated []string `json:"updated"`
+	Skipped []string `json:"skipped"`
+}
@@ -0,0 +1,95 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Tag limits enforced by the Postman API.
+const (
+	MinTagLength = 2
+	MaxTagLength = 64
+	MaxTags      = 5
+)
+
+var tagPattern = regexp.MustCompile(`^[a-z][a-z0-9-]*[a-z0-9]$`)
+
+// TagsResponse represents the top-level tags response from the Postman API.
+type TagsResponse struct {
+	Tags Tags `json:"tags"`
+}
+
+// Tags is a slice of Tag.
+type Tags []Tag
+
+// Format returns column headers and values for the resource.
+func (r Tags) Format() ([]string, []interface{}) {
+	s := make([]interface{}, len(r))
+	for i, v := range r {
+		s[i] = v
+	}
+
+	return []string{"Slug"}, s
+}
+
+// Slugs returns the slug of each tag.
+func (r Tags) Slugs() []string {
+	s := make([]string, len(r))
+	for i, v := range r {
+		s[i] = v.Slug
+	}
+
+	return s
+}
+
+// Tag is a label used to organize Postman elements.
+type Tag struct {
+	Slug string `json:"slug"`
+}
+
+// Validate checks that the tag is a slug accepted by the Postman API:
+// lowercase letters, digits, and hyphens, starting with a letter.
+func (t Tag) Validate() error {
+	if len(t.Slug) < MinTagLength || len(t.Slug) > MaxTagLength {
+		return fmt.Errorf("tag %q must be between %d and %d characters", t.Slug, MinTagLength, MaxTagLength)
+	}
+
+	if !tagPattern.MatchString(t.Slug) {
+		return fmt.Errorf("invalid tag %q, tags may only contain lowercase letters, digits, and hyphens", t.Slug)
+	}
+
+	return nil
+}
+
+// NewTags validates slugs and returns them as Tags.
+func NewTags(slugs []string) (Tags, error) {
+	if len(slugs) > MaxTags {
+		return nil, fmt.Errorf("at most %d tags are allowed, have %d", MaxTags, len(slugs))
+	}
+
+	tags := make(Tags, len(slugs))
+	for i, slug := range slugs {
+		tags[i] = Tag{Slug: slug}
+		if err := tags[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+type tapDiagnostic struct {
+	Message  string `yaml:"message"`
+	Severity string `yaml:"severity"`
+	At       string `yaml:"at"`
+	Name     string `yaml:"name,omitempty"`
+}
+
+// ToTAP returns the run as a TAP version 13 stream with one test point per
+// assertion.  A request that failed without a response is reported as a
+// failed test point of its own.  Failures carry a YAML diagnostic block.
+func (r *RunSummary) ToTAP() ([]byte, error) {
+	var points bytes.Buffer
+	n := 0
+
+	for _, e := range r.Executions {
+		if e.Error != nil {
+			n++
+			diag := tapDiagnostic{Message: e.Error.Message, Severity: "fail", At: "request", Name: e.Error.Name}
+			if err := writeTAPPoint(&points, n, false, e.Item.Name+" request", "", &diag); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, a := range e.Assertions {
+			n++
+			description := e.Item.Name + " - " + a.Assertion
+			switch {
+			case a.Skipped:
+				if err := writeTAPPoint(&points, n, true, description, "SKIP", nil); err != nil {
+					return nil, err
+				}
+			case a.Error != nil:
+				diag := tapDiagnostic{Message: a.Error.Message, Severity: "fail", At: "assertion", Name: a.Error.Name}
+				if err := writeTAPPoint(&points, n, false, description, "", &diag); err != nil {
+					return nil, err
+				}
+			default:
+				if err := writeTAPPoint(&points, n, true, description, "", nil); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("TAP version 13\n")
+	fmt.Fprintf(&buf, "1..%d\n", n)
+	buf.Write(points.Bytes())
+
+	return buf.Bytes(), nil
+}
+
+// writeTAPPoint writes a single test point, followed by its diagnostic
+// block when one is given.
+func writeTAPPoint(buf *bytes.Buffer, n int, ok bool, description, directive string, diag *tapDiagnostic) error {
+	if !ok {
+		buf.WriteString("not ")
+	}
+
+	// a "#" in the description would start a directive
+	description = strings.ReplaceAll(description, "#", "\\#")
+	fmt.Fprintf(buf, "ok %d - %s", n, description)
+	if directive != "" {
+		fmt.Fprintf(buf, " # %s", directive)
+	}
+	buf.WriteString("\n")
+
+	if diag == nil {
+		return nil
+	}
+
+	data, err := yaml.Marshal(diag)
+	if err != nil {
+		return err
+	}
+
+	buf.WriteString("  ---\n")
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		buf.WriteString("  " + line + "\n")
+	}
+	buf.WriteString("  ...\n")
+
+	return nil
+}
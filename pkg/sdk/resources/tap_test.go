@@ -0,0 +1,104 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+const tapRunSubject = `{
+  "run": {
+    "info": {"name": "Users", "status": "failed"},
+    "stats": {"assertions": {"total": 3, "failed": 1}, "requests": {"total": 2, "failed": 1}},
+    "executions": [
+      {
+        "id": 1,
+        "item": {"id": "b5e8d7dd-909c-4ba4-aa0f-3bac5b8de1fb", "name": "Create user"},
+        "request": {"method": "POST", "url": "https://api.example.com/users"},
+        "response": {"code": 500},
+        "assertions": [
+          {"assertion": "Status code is 201", "error": {"name": "AssertionError", "message": "expected 500 to equal 201"}},
+          {"assertion": "Has an id"},
+          {"assertion": "Sends a welcome email", "skipped": true}
+        ]
+      },
+      {
+        "id": 2,
+        "item": {"id": "2c4a6b8e-1f3d-4e5a-9b7c-8d6e5f4a3b2c", "name": "Health"},
+        "request": {"method": "GET", "url": "https://down.example.com/health"},
+        "error": {"name": "Error", "message": "getaddrinfo ENOTFOUND down.example.com"}
+      }
+    ]
+  }
+}`
+
+func TestRunSummaryToTAP(t *testing.T) {
+	var resp resources.MonitorRunResponse
+	if err := json.Unmarshal([]byte(tapRunSubject), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := resp.Run.ToTAP()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	if lines[0] != "TAP version 13" || lines[1] != "1..4" {
+		t.Errorf("TAP header is incorrect, have: %q", lines[:2])
+	}
+
+	want := `TAP version 13
+1..4
+not ok 1 - Create user - Status code is 201
+  ---
+  message: expected 500 to equal 201
+  severity: fail
+  at: assertion
+  name: AssertionError
+  ...
+ok 2 - Create user - Has an id
+ok 3 - Create user - Sends a welcome email # SKIP
+not ok 4 - Health request
+  ---
+  message: getaddrinfo ENOTFOUND down.example.com
+  severity: fail
+  at: request
+  name: Error
+  ...
+`
+	if have := string(data); have != want {
+		t.Errorf("TAP stream is incorrect, have:\n%s\nwant:\n%s", have, want)
+	}
+}
+
+func TestRunSummaryToTAPWithoutAssertions(t *testing.T) {
+	run := resources.RunSummary{Executions: []resources.RunExecution{{ID: 1, Item: resources.RunItem{Name: "Health"}}}}
+
+	data, err := run.ToTAP()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := string(data), "TAP version 13\n1..0\n"; have != want {
+		t.Errorf("TAP stream is incorrect, have: %q, want: %q", have, want)
+	}
+}
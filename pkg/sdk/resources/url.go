@@ -0,0 +1,215 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// URL represents the URL of a request.  Postman stores URLs either as a
+// string or as an object; both are normalized to the structured form.
+// Parts may contain {{variable}} references, so they are kept as written
+// rather than escaped.
+type URL struct {
+	Raw      string        `json:"raw,omitempty"`
+	Protocol string        `json:"protocol,omitempty"`
+	Host     []string      `json:"host,omitempty"`
+	Port     string        `json:"port,omitempty"`
+	Path     []string      `json:"path,omitempty"`
+	Query    []QueryParam  `json:"query,omitempty"`
+	Hash     string        `json:"hash,omitempty"`
+	Variable []URLVariable `json:"variable,omitempty"`
+}
+
+// QueryParam is a single query parameter of a URL.
+type QueryParam struct {
+	Key         string `json:"key"`
+	Value       string `json:"value,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// URLVariable is a path variable of a URL, such as :id in /users/:id.
+type URLVariable struct {
+	Key         string `json:"key"`
+	Value       string `json:"value,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ParseURL splits a raw, possibly templated, URL into its parts.
+func ParseURL(raw string) URL {
+	u := URL{Raw: raw}
+
+	rest := raw
+	if i := strings.Index(rest, "#"); i >= 0 {
+		u.Hash = rest[i+1:]
+		rest = rest[:i]
+	}
+
+	if i := strings.Index(rest, "?"); i >= 0 {
+		u.Query = parseQuery(rest[i+1:])
+		rest = rest[:i]
+	}
+
+	if i := strings.Index(rest, "://"); i >= 0 {
+		u.Protocol = rest[:i]
+		rest = rest[i+3:]
+	}
+
+	host := rest
+	if i := strings.Index(rest, "/"); i >= 0 {
+		host = rest[:i]
+		u.Path = strings.Split(rest[i+1:], "/")
+	}
+
+	if i := strings.LastIndex(host, ":"); i >= 0 && i > strings.LastIndex(host, "]") {
+		u.Port = host[i+1:]
+		host = host[:i]
+	}
+
+	if host != "" {
+		u.Host = strings.Split(host, ".")
+	}
+
+	return u
+}
+
+func parseQuery(s string) []QueryParam {
+	if s == "" {
+		return nil
+	}
+
+	var params []QueryParam
+	for _, pair := range strings.Split(s, "&") {
+		if pair == "" {
+			continue
+		}
+
+		var p QueryParam
+		if i := strings.Index(pair, "="); i >= 0 {
+			p.Key, p.Value = pair[:i], pair[i+1:]
+		} else {
+			p.Key = pair
+		}
+		params = append(params, p)
+	}
+
+	return params
+}
+
+// String reconstructs the raw URL from its parts, leaving out disabled
+// query parameters.  Raw is returned when the URL has no parts.
+func (u URL) String() string {
+	if len(u.Host) == 0 && len(u.Path) == 0 && len(u.Query) == 0 {
+		return u.Raw
+	}
+
+	var b strings.Builder
+	if u.Protocol != "" {
+		b.WriteString(u.Protocol + "://")
+	}
+
+	b.WriteString(strings.Join(u.Host, "."))
+	if u.Port != "" {
+		b.WriteString(":" + u.Port)
+	}
+
+	if len(u.Path) > 0 {
+		b.WriteString("/" + strings.Join(u.Path, "/"))
+	}
+
+	sep := "?"
+	for _, p := range u.Query {
+		if p.Disabled {
+			continue
+		}
+
+		b.WriteString(sep + p.Key)
+		if p.Value != "" {
+			b.WriteString("=" + p.Value)
+		}
+		sep = "&"
+	}
+
+	if u.Hash != "" {
+		b.WriteString("#" + u.Hash)
+	}
+
+	return b.String()
+}
+
+// UnmarshalJSON converts JSON to a struct.  A URL may be a string or an
+// object; parts missing from an object are taken from its raw URL.
+func (u *URL) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		*u = URL{}
+		return nil
+	}
+
+	if len(b) > 0 && b[0] == '"' {
+		var raw string
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return err
+		}
+
+		*u = ParseURL(raw)
+		return nil
+	}
+
+	type url URL
+	var v url
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*u = URL(v)
+	if u.Raw != "" {
+		u.fillFrom(ParseURL(u.Raw))
+	}
+
+	return nil
+}
+
+// fillFrom sets the parts of u that are empty to those of parsed.
+func (u *URL) fillFrom(parsed URL) {
+	if u.Protocol == "" {
+		u.Protocol = parsed.Protocol
+	}
+
+	if len(u.Host) == 0 {
+		u.Host = parsed.Host
+	}
+
+	if u.Port == "" {
+		u.Port = parsed.Port
+	}
+
+	if len(u.Path) == 0 {
+		u.Path = parsed.Path
+	}
+
+	if len(u.Query) == 0 {
+		u.Query = parsed.Query
+	}
+
+	if u.Hash == "" {
+		u.Hash = parsed.Hash
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestURLUnmarshalString(t *testing.T) {
+	var u resources.URL
+	if err := json.Unmarshal([]byte(`"https://{{host}}:8443/v1/users/:id?limit=10&expand#top"`), &u); err != nil {
+		t.Fatal(err)
+	}
+
+	want := resources.URL{
+		Raw:      "https://{{host}}:8443/v1/users/:id?limit=10&expand#top",
+		Protocol: "https",
+		Host:     []string{"{{host}}"},
+		Port:     "8443",
+		Path:     []string{"v1", "users", ":id"},
+		Query:    []resources.QueryParam{{Key: "limit", Value: "10"}, {Key: "expand"}},
+		Hash:     "top",
+	}
+
+	if !reflect.DeepEqual(u, want) {
+		t.Errorf("URL is incorrect, have: %+v, want: %+v", u, want)
+	}
+}
+
+func TestURLUnmarshalObject(t *testing.T) {
+	subject := `{
+		"raw": "{{baseUrl}}/users/:id?page=2&debug=true",
+		"host": ["{{baseUrl}}"],
+		"path": ["users", ":id"],
+		"query": [{"key": "page", "value": "2"}, {"key": "debug", "value": "true", "disabled": true}],
+		"variable": [{"key": "id", "value": "42"}]
+	}`
+
+	var u resources.URL
+	if err := json.Unmarshal([]byte(subject), &u); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(u.Variable) != 1 || u.Variable[0].Key != "id" || u.Variable[0].Value != "42" {
+		t.Errorf("Variables are incorrect, have: %+v", u.Variable)
+	}
+
+	if !u.Query[1].Disabled {
+		t.Error("Expected debug query parameter to be disabled.")
+	}
+
+	if have, want := u.String(), "{{baseUrl}}/users/:id?page=2"; have != want {
+		t.Errorf("URL string is incorrect, have: %s, want: %s", have, want)
+	}
+}
+
+func TestURLUnmarshalObjectWithOnlyRaw(t *testing.T) {
+	var u resources.URL
+	if err := json.Unmarshal([]byte(`{"raw": "http://localhost:3000/health"}`), &u); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(u.Host, []string{"localhost"}) || u.Port != "3000" || !reflect.DeepEqual(u.Path, []string{"health"}) {
+		t.Errorf("URL parts are incorrect, have: %+v", u)
+	}
+}
+
+func TestURLRoundTrip(t *testing.T) {
+	raws := []string{
+		"https://api.example.com/v1/users?name=ada&sort=-created",
+		"{{baseUrl}}/users/",
+		"http://[::1]:8080/",
+		"postman-echo.com",
+		"{{baseUrl}}?query={{q}}#results",
+	}
+
+	for _, raw := range raws {
+		data, err := json.Marshal(resources.ParseURL(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var u resources.URL
+		if err := json.Unmarshal(data, &u); err != nil {
+			t.Fatal(err)
+		}
+
+		if u.String() != raw {
+			t.Errorf("URL did not round-trip, have: %s, want: %s", u.String(), raw)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import "time"
+
+// UsageResponse represents the top-level usage response from the Postman
+// API.  Usage is nil for accounts without usage data.
+type UsageResponse struct {
+	Usage *Usage `json:"usage"`
+}
+
+// Usage is the account's usage of the Postman API and related services in
+// the current billing period, measured against its plan limits.
+type Usage struct {
+	Period      UsagePeriod `json:"period"`
+	APICalls    UsageMetric `json:"apiCalls"`
+	MonitorRuns UsageMetric `json:"monitorRuns"`
+	MockCalls   UsageMetric `json:"mockCalls"`
+}
+
+// Metrics returns the usage metrics, each labeled with its name.
+func (r Usage) Metrics() []UsageMetric {
+	metrics := []UsageMetric{r.APICalls, r.MonitorRuns, r.MockCalls}
+	for i, name := range []string{"API calls", "Monitor runs", "Mock calls"} {
+		metrics[i].Name = name
+	}
+
+	return metrics
+}
+
+// Format returns column headers and values for the resource.
+func (r Usage) Format() ([]string, []interface{}) {
+	metrics := r.Metrics()
+	s := make([]interface{}, len(metrics))
+	for i, v := range metrics {
+		s[i] = v
+	}
+
+	return []string{"Name", "Used", "Limit"}, s
+}
+
+// UsagePeriod is the billing period usage is counted over.
+type UsagePeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// UsageMetric is the usage of a single quota.  Limit is zero when the plan
+// sets no limit.
+type UsageMetric struct {
+	Name  string `json:"-"`
+	Used  int64  `json:"used"`
+	Limit int64  `json:"limit"`
+}
+
+// Remaining returns the usage left before the limit is reached, or -1 when
+// there is no limit.
+func (m UsageMetric) Remaining() int64 {
+	if m.Limit == 0 {
+		return -1
+	}
+
+	if m.Used >= m.Limit {
+		return 0
+	}
+
+	return m.Limit - m.Used
+}
+
+// Exceeded reports whether the limit has been reached.
+func (m UsageMetric) Exceeded() bool {
+	return m.Limit != 0 && m.Used >= m.Limit
+}
@@ -30,7 +30,8 @@ type UserResponse struct {
 // User represents the user info associated with a user request in the
 // Postman API.
 type User struct {
-	ID string `json:"id"`
+	ID     string `json:"id"`
+	TeamID string `json:"teamId"`
 }
 
 // UnmarshalJSON sets the receiver to a copy of data.
@@ -41,6 +42,9 @@ func (r *User) UnmarshalJSON(data []byte) error {
 	}
 
 	r.ID = strconv.Itoa(int(v["id"].(float64)))
+	if teamID, ok := v["teamId"].(float64); ok {
+		r.TeamID = strconv.Itoa(int(teamID))
+	}
 
 	return nil
 }
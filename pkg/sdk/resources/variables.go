@@ -0,0 +1,274 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var variablePattern = regexp.MustCompile(`\{\{([^{}]+)\}\}`)
+
+// VariableScope holds variable values used to resolve {{variable}}
+// references.
+type VariableScope map[string]string
+
+// EnvironmentScope returns the enabled values of an environment as a
+// VariableScope.
+func EnvironmentScope(env *Environment) VariableScope {
+	scope := VariableScope{}
+	if env == nil {
+		return scope
+	}
+
+	for _, v := range env.Values {
+		if v.Enabled {
+			scope[v.Key] = v.Value
+		}
+	}
+
+	return scope
+}
+
+// CollectionScope returns the enabled collection-level variables of a
+// collection as a VariableScope.
+func CollectionScope(c *Collection) VariableScope {
+	scope := VariableScope{}
+	if c == nil || c.Collection == nil {
+		return scope
+	}
+
+	for _, v := range c.Variable {
+		if v == nil || v.Disabled || v.Value == nil {
+			continue
+		}
+
+		key := v.Key
+		if key == "" {
+			key = v.ID
+		}
+		scope[key] = fmt.Sprint(v.Value)
+	}
+
+	return scope
+}
+
+// ItemScopes returns the chain of scopes for resolving variables in the
+// request or folder at itemPath, given as folder names followed by the item
+// name.  The variables declared by each containing folder come first,
+// innermost first, so they shadow those of outer folders and the
+// collection, followed by the collection variables and then the given
+// scopes.  Run, ToCurl, Hosts, ToMarkdown, and Inline resolve the variables
+// of each request in this order.
+func (c *Collection) ItemScopes(itemPath []string, scopes ...VariableScope) ([]VariableScope, error) {
+	if c.Collection == nil {
+		return nil, errors.New("the collection is empty")
+	}
+
+	var folders []VariableScope
+	items := c.Item
+	for i, name := range itemPath {
+		item := findRawItem(items, name)
+		if item == nil {
+			return nil, fmt.Errorf("item %q not found", strings.Join(itemPath[:i+1], "/"))
+		}
+
+		sub, ok := item["item"].([]interface{})
+		if !ok {
+			if i != len(itemPath)-1 {
+				return nil, fmt.Errorf("item %q is not a folder", strings.Join(itemPath[:i+1], "/"))
+			}
+			break
+		}
+
+		folders = append([]VariableScope{rawVariableScope(item["variable"])}, folders...)
+		items = sub
+	}
+
+	ret := make([]VariableScope, 0, len(folders)+1+len(scopes))
+	ret = append(ret, folders...)
+	ret = append(ret, CollectionScope(c))
+
+	return append(ret, scopes...), nil
+}
+
+// requestScopes returns the scopes for resolving the variables of the
+// request at itemPath, as ItemScopes does.  Requests that can't be located by
+// their path, such as those sharing a name with a sibling, are resolved
+// without folder variables.
+func (c *Collection) requestScopes(itemPath []string, scopes []VariableScope) []VariableScope {
+	ret, err := c.ItemScopes(itemPath, scopes...)
+	if err != nil {
+		return append([]VariableScope{CollectionScope(c)}, scopes...)
+	}
+
+	return ret
+}
+
+// rawVariableScope returns the enabled variables of a raw variable list as a
+// VariableScope.
+func rawVariableScope(raw interface{}) VariableScope {
+	scope := VariableScope{}
+	list, _ := raw.([]interface{})
+	for _, v := range list {
+		m, ok := v.(map[string]interface{})
+		if !ok || m["value"] == nil {
+			continue
+		}
+
+		if disabled, _ := m["disabled"].(bool); disabled {
+			continue
+		}
+
+		key, _ := m["key"].(string)
+		if key == "" {
+			key, _ = m["id"].(string)
+		}
+		scope[key] = fmt.Sprint(m["value"])
+	}
+
+	return scope
+}
+
+// ResolveVariables replaces {{variable}} references in s with the value from
+// the first scope defining the variable, resolving references within values
+// in turn.  Unresolved and cyclic references are left intact.
+func ResolveVariables(s string, scopes ...VariableScope) string {
+	ret, _ := resolveVariables(s, nil, scopes)
+	return ret
+}
+
+// ResolveVariablesChecked is ResolveVariables, but also returns a
+// *VariableCycleError when a variable references itself, directly or
+// through other variables.
+func ResolveVariablesChecked(s string, scopes ...VariableScope) (string, error) {
+	return resolveVariables(s, nil, scopes)
+}
+
+// VariableCycleError is returned when variables reference each other in a
+// cycle.  Chain lists the variables in the cycle, starting and ending with
+// the same variable.
+type VariableCycleError struct {
+	Chain []string
+}
+
+func (e *VariableCycleError) Error() string {
+	return fmt.Sprintf("cyclic variable reference: %s", strings.Join(e.Chain, " -> "))
+}
+
+// resolveVariables resolves the references in s.  chain holds the variables
+// being resolved that led to s, so references back into it are cycles.
+func resolveVariables(s string, chain []string, scopes []VariableScope) (string, error) {
+	var cycle error
+	ret := variablePattern.ReplaceAllStringFunc(s, func(ref string) string {
+		name := ref[2 : len(ref)-2]
+		for i, n := range chain {
+			if n == name {
+				if cycle == nil {
+					cycle = &VariableCycleError{Chain: appendPath(chain[i:], name)}
+				}
+				return ref
+			}
+		}
+
+		for _, scope := range scopes {
+			if v, ok := scope[name]; ok {
+				resolved, err := resolveVariables(v, appendPath(chain, name), scopes)
+				if err != nil && cycle == nil {
+					cycle = err
+				}
+				return resolved
+			}
+		}
+
+		return ref
+	})
+
+	return ret, cycle
+}
+
+// resolveValue resolves variables in every string contained in a raw JSON
+// value, returning the resolved copy.
+func resolveValue(v interface{}, scopes ...VariableScope) interface{} {
+	switch t := v.(type) {
+	case string:
+		return ResolveVariables(t, scopes...)
+	case []interface{}:
+		ret := make([]interface{}, len(t))
+		for i, e := range t {
+			ret[i] = resolveValue(e, scopes...)
+		}
+		return ret
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			ret[k] = resolveValue(e, scopes...)
+		}
+		return ret
+	}
+
+	return v
+}
+
+// Inline returns a copy of the collection with folder, collection, and
+// environment variables substituted into every request URL, header, and body
+// so that it can be shared without the environment.  Variables are resolved
+// in the order given by ItemScopes and unresolved variables are left intact.
+// The receiver is not modified.
+func (c *Collection) Inline(env *Environment) *Collection {
+	dup := c.clone()
+	if dup.Collection == nil {
+		return dup
+	}
+
+	inlineRawItems(dup.Item, []VariableScope{CollectionScope(c), EnvironmentScope(env)})
+
+	// The items decoded before and only had string values replaced, so
+	// rebuilding the tree cannot fail.
+	_ = dup.refreshItems()
+
+	return dup
+}
+
+// inlineRawItems substitutes variables into the raw requests in items,
+// recursing into folders with their variables ahead of scopes.
+func inlineRawItems(items []interface{}, scopes []VariableScope) {
+	for _, v := range items {
+		item, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if children, ok := item["item"].([]interface{}); ok {
+			inlineRawItems(children, append([]VariableScope{rawVariableScope(item["variable"])}, scopes...))
+			continue
+		}
+
+		switch r := item["request"].(type) {
+		case string:
+			item["request"] = ResolveVariables(r, scopes...)
+		case map[string]interface{}:
+			for _, k := range []string{"url", "header", "body"} {
+				if v, ok := r[k]; ok {
+					r[k] = resolveValue(v, scopes...)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,250 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+func TestResolveVariables(t *testing.T) {
+	scopes := []resources.VariableScope{
+		{"host": "api.example.com"},
+		{"host": "ignored", "version": "v1"},
+	}
+
+	have := resources.ResolveVariables("https://{{host}}/{{version}}/{{missing}}", scopes...)
+	want := "https://api.example.com/v1/{{missing}}"
+
+	if have != want {
+		t.Errorf("Resolved value is incorrect, have: %s, want: %s", have, want)
+	}
+}
+
+func TestResolveVariablesNested(t *testing.T) {
+	scope := resources.VariableScope{"baseUrl": "https://{{host}}/{{version}}", "host": "api.example.com", "version": "v1"}
+
+	have, err := resources.ResolveVariablesChecked("{{baseUrl}}/users", scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "https://api.example.com/v1/users"; have != want {
+		t.Errorf("Resolved value is incorrect, have: %s, want: %s", have, want)
+	}
+}
+
+func TestResolveVariablesCycle(t *testing.T) {
+	scope := resources.VariableScope{"a": "x{{b}}", "b": "y{{a}}", "c": "ok"}
+
+	have, err := resources.ResolveVariablesChecked("{{c}} {{a}}", scope)
+
+	var cycle *resources.VariableCycleError
+	if !errors.As(err, &cycle) {
+		t.Fatalf("Expected cycle error, have: %v", err)
+	}
+
+	if chain := strings.Join(cycle.Chain, ","); chain != "a,b,a" {
+		t.Errorf("Cycle chain is incorrect, have: %s, want: %s", chain, "a,b,a")
+	}
+
+	if want := "ok xy{{a}}"; have != want {
+		t.Errorf("Resolved value is incorrect, have: %s, want: %s", have, want)
+	}
+
+	if lenient := resources.ResolveVariables("{{a}}", scope); lenient != "xy{{a}}" {
+		t.Errorf("Resolved value is incorrect, have: %s, want: %s", lenient, "xy{{a}}")
+	}
+}
+
+func TestResolveVariablesSelfReference(t *testing.T) {
+	scope := resources.VariableScope{"path": "{{path}}/users"}
+
+	have, err := resources.ResolveVariablesChecked("{{path}}", scope)
+
+	var cycle *resources.VariableCycleError
+	if !errors.As(err, &cycle) {
+		t.Fatalf("Expected cycle error, have: %v", err)
+	}
+
+	if chain := strings.Join(cycle.Chain, ","); chain != "path,path" {
+		t.Errorf("Cycle chain is incorrect, have: %s, want: %s", chain, "path,path")
+	}
+
+	if want := "{{path}}/users"; have != want {
+		t.Errorf("Resolved value is incorrect, have: %s, want: %s", have, want)
+	}
+}
+
+const inlineSubject = `{
+  "info": {"name": "inline", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "variable": [{"key": "version", "value": "v1"}, {"key": "token", "value": "collection-token"}],
+  "item": [
+    {
+      "name": "Folder",
+      "variable": [{"key": "version", "value": "v2"}],
+      "item": [
+        {
+          "name": "Get User",
+          "request": {
+            "method": "GET",
+            "url": {"raw": "{{baseUrl}}/{{version}}/users/{{userId}}", "host": ["{{baseUrl}}"]},
+            "header": [{"key": "Authorization", "value": "Bearer {{token}}"}]
+          }
+        }
+      ]
+    },
+    {"name": "Ping", "request": "{{baseUrl}}/ping"}
+  ]
+}`
+
+func TestCollectionInline(t *testing.T) {
+	c := unmarshalCollection(t, inlineSubject)
+	env := &resources.Environment{
+		Values: []resources.KeyValuePair{
+			{Key: "baseUrl", Value: "https://api.example.com", Enabled: true},
+			{Key: "token", Value: "env-token", Enabled: true},
+			{Key: "userId", Value: "42", Enabled: false},
+		},
+	}
+
+	inlined := c.Inline(env)
+
+	b, err := json.Marshal(inlined)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var v struct {
+		Item []struct {
+			Item []struct {
+				Request struct {
+					URL struct {
+						Raw  string   `json:"raw"`
+						Host []string `json:"host"`
+					} `json:"url"`
+					Header []struct {
+						Key   string `json:"key"`
+						Value string `json:"value"`
+					} `json:"header"`
+				} `json:"request"`
+			} `json:"item"`
+			Request interface{} `json:"request"`
+		} `json:"item"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatal(err)
+	}
+
+	req := v.Item[0].Item[0].Request
+	if want := "https://api.example.com/v2/users/{{userId}}"; req.URL.Raw != want {
+		t.Errorf("URL is incorrect, have: %s, want: %s", req.URL.Raw, want)
+	}
+
+	if req.URL.Host[0] != "https://api.example.com" {
+		t.Errorf("URL host is incorrect, have: %s, want: %s", req.URL.Host[0], "https://api.example.com")
+	}
+
+	if want := "Bearer collection-token"; req.Header[0].Value != want {
+		t.Errorf("Header value is incorrect, have: %s, want: %s", req.Header[0].Value, want)
+	}
+
+	if want := "https://api.example.com/ping"; v.Item[1].Request != want {
+		t.Errorf("Request is incorrect, have: %v, want: %s", v.Item[1].Request, want)
+	}
+
+	items := *inlined.Items.Root.Items
+	if want := "https://api.example.com/ping"; items[0].Request != want {
+		t.Errorf("Item tree was not refreshed, have: %v, want: %s", items[0].Request, want)
+	}
+}
+
+func TestCollectionInlineLeavesOriginalUnmodified(t *testing.T) {
+	c := unmarshalCollection(t, inlineSubject)
+	before, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inlined := c.Inline(&resources.Environment{
+		Values: []resources.KeyValuePair{{Key: "baseUrl", Value: "https://api.example.com", Enabled: true}},
+	})
+	inlined.Info.Name = "changed"
+
+	after, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(before) != string(after) {
+		t.Errorf("Original collection was modified, have: %s, want: %s", string(after), string(before))
+	}
+}
+
+func TestCollectionItemScopes(t *testing.T) {
+	c := unmarshalCollection(t, `{
+		"info": {"name": "scopes", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"variable": [{"key": "baseUrl", "value": "https://api.example.com"}, {"key": "version", "value": "v1"}],
+		"item": [
+			{
+				"name": "Admin",
+				"variable": [{"key": "baseUrl", "value": "https://admin.example.com"}, {"key": "version", "value": "v0", "disabled": true}],
+				"item": [
+					{
+						"name": "Reports",
+						"variable": [{"key": "report", "value": "daily"}],
+						"item": [{"name": "Get report", "request": {"method": "GET", "url": "{{baseUrl}}/{{version}}/reports/{{report}}?user={{user}}"}}]
+					}
+				]
+			},
+			{"name": "Health", "request": {"method": "GET", "url": "{{baseUrl}}/health"}}
+		]
+	}`)
+
+	env := resources.VariableScope{"user": "ada", "baseUrl": "https://env.example.com"}
+
+	scopes, err := c.ItemScopes([]string{"Admin", "Reports", "Get report"}, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have := resources.ResolveVariables("{{baseUrl}}/{{version}}/reports/{{report}}?user={{user}}", scopes...)
+	if want := "https://admin.example.com/v1/reports/daily?user=ada"; have != want {
+		t.Errorf("Resolved URL is incorrect, have: %s, want: %s", have, want)
+	}
+
+	scopes, err = c.ItemScopes([]string{"Health"}, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := resources.ResolveVariables("{{baseUrl}}/health", scopes...), "https://api.example.com/health"; have != want {
+		t.Errorf("Resolved URL is incorrect, have: %s, want: %s", have, want)
+	}
+
+	if _, err := c.ItemScopes([]string{"Health", "Nested"}); err == nil {
+		t.Error("Expected error.")
+	}
+
+	if _, err := c.ItemScopes([]string{"Missing"}); err == nil {
+		t.Error("Expected error.")
+	}
+}
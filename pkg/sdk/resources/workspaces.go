@@ -16,6 +16,11 @@ limitations under the License.
 
 package resources
 
+import (
+	"fmt"
+	"time"
+)
+
 // WorkspaceListResponse represents the top-level workspaces response from the
 // Postman API.
 type WorkspaceListResponse struct {
@@ -49,7 +54,7 @@ type WorkspaceResponse struct {
 }
 
 // Workspace represents the single workspace response from the
-// Postman API
+// Postman API.  Fields the SDK doesn't model are kept in Extras.
 type Workspace struct {
 	ID           string                         `json:"id"`
 	Name         string                         `json:"name"`
@@ -59,6 +64,7 @@ type Workspace struct {
 	Environments []WorkspaceEnvironmentListItem `json:"environments"`
 	Mocks        []WorkspaceMockListItem        `json:"mocks"`
 	Monitors     []WorkspaceMonitorListItem     `json:"monitors"`
+	Extras       Extras                         `json:"-"`
 }
 
 // Format returns column headers and values for the resource.
@@ -105,3 +111,177 @@ type WorkspaceMockListItem struct {
 type WorkspaceMonitorListItem struct {
 	ID string `json:"id"`
 }
+
+// WorkspaceRole is a role that can be granted on a workspace.
+type WorkspaceRole string
+
+// Workspace roles.
+const (
+	WorkspaceRoleViewer WorkspaceRole = "viewer"
+	WorkspaceRoleEditor WorkspaceRole = "editor"
+	WorkspaceRoleAdmin  WorkspaceRole = "admin"
+)
+
+// Valid reports whether the role is a known workspace role.
+func (r WorkspaceRole) Valid() bool {
+	switch r {
+	case WorkspaceRoleViewer, WorkspaceRoleEditor, WorkspaceRoleAdmin:
+		return true
+	}
+
+	return false
+}
+
+// WorkspaceVisibility controls who can see a workspace.
+type WorkspaceVisibility string
+
+// Workspace visibilities.
+const (
+	WorkspaceVisibilityPersonal WorkspaceVisibility = "personal"
+	WorkspaceVisibilityPrivate  WorkspaceVisibility = "private"
+	WorkspaceVisibilityTeam     WorkspaceVisibility = "team"
+	WorkspaceVisibilityPublic   WorkspaceVisibility = "public"
+)
+
+// Valid reports whether the visibility is a known workspace visibility.
+func (v WorkspaceVisibility) Valid() bool {
+	switch v {
+	case WorkspaceVisibilityPersonal, WorkspaceVisibilityPrivate, WorkspaceVisibilityTeam, WorkspaceVisibilityPublic:
+		return true
+	}
+
+	return false
+}
+
+// Workspace role member types.
+const (
+	RoleMemberUser  = "user"
+	RoleMemberGroup = "group"
+)
+
+// WorkspaceRolesResponse represents the top-level workspace roles response
+// from the Postman API.
+type WorkspaceRolesResponse struct {
+	Roles WorkspaceRoles `json:"roles"`
+}
+
+// WorkspaceRoles is a slice of WorkspaceRoleAssignment.
+type WorkspaceRoles []WorkspaceRoleAssignment
+
+// Format returns column headers and values for the resource.
+func (r WorkspaceRoles) Format() ([]string, []interface{}) {
+	s := make([]interface{}, len(r))
+	for i, v := range r {
+		s[i] = v
+	}
+
+	return []string{"ID", "Type", "Role"}, s
+}
+
+// WorkspaceRoleAssignment represents a role granted to a user or group on a
+// workspace.
+type WorkspaceRoleAssignment struct {
+	ID   string        `json:"id"`
+	Type string        `json:"type"`
+	Role WorkspaceRole `json:"role"`
+}
+
+// Role change operations.
+const (
+	RoleChangeAdd    = "add"
+	RoleChangeRemove = "remove"
+)
+
+// RoleChange adds or removes a role for a user or group on a workspace.
+type RoleChange struct {
+	Op   string        `json:"op"`
+	Type string        `json:"type"`
+	ID   string        `json:"id"`
+	Role WorkspaceRole `json:"role"`
+}
+
+// Validate checks that the change can be sent to the Postman API.
+func (c RoleChange) Validate() error {
+	if c.Op != RoleChangeAdd && c.Op != RoleChangeRemove {
+		return fmt.Errorf("invalid role change operation %q, must be %s or %s", c.Op, RoleChangeAdd, RoleChangeRemove)
+	}
+
+	if c.Type != RoleMemberUser && c.Type != RoleMemberGroup {
+		return fmt.Errorf("invalid role member type %q, must be %s or %s", c.Type, RoleMemberUser, RoleMemberGroup)
+	}
+
+	if c.ID == "" {
+		return fmt.Errorf("a %s ID is required for a role change", c.Type)
+	}
+
+	if !c.Role.Valid() {
+		return fmt.Errorf("invalid workspace role %q", c.Role)
+	}
+
+	return nil
+}
+
+// WorkspaceActivityListResponse represents a page of the workspace activity
+// feed from the Postman API.
+type WorkspaceActivityListResponse struct {
+	Activities WorkspaceActivities `json:"activities"`
+	Meta       struct {
+		NextCursor string `json:"nextCursor"`
+	} `json:"meta"`
+}
+
+// WorkspaceActivities is a slice of WorkspaceActivity.
+type WorkspaceActivities []WorkspaceActivity
+
+// Format returns column headers and values for the resource.
+func (r WorkspaceActivities) Format() ([]string, []interface{}) {
+	s := make([]interface{}, len(r))
+	for i, v := range r {
+		s[i] = v
+	}
+
+	return []string{"Timestamp", "Action", "Target"}, s
+}
+
+// WorkspaceActivityList is a list of workspace changes along with its
+// pagination details.
+type WorkspaceActivityList struct {
+	Items WorkspaceActivities
+	PageInfo
+}
+
+// Format returns column headers and values for the resource.
+func (r WorkspaceActivityList) Format() ([]string, []interface{}) {
+	return r.Items.Format()
+}
+
+// WorkspaceActivity is a single change made in a workspace.
+type WorkspaceActivity struct {
+	ID        string                  `json:"id"`
+	Action    string                  `json:"action"`
+	Timestamp time.Time               `json:"timestamp"`
+	Actor     WorkspaceActivityActor  `json:"actor"`
+	Target    WorkspaceActivityTarget `json:"target"`
+}
+
+// WorkspaceActivityActor is the user who made a workspace change.
+type WorkspaceActivityActor struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+// WorkspaceActivityTarget is the element changed by a workspace activity.
+type WorkspaceActivityTarget struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (t WorkspaceActivityTarget) String() string {
+	if t.Name == "" {
+		return fmt.Sprintf("%s %s", t.Type, t.ID)
+	}
+
+	return fmt.Sprintf("%s %s", t.Type, t.Name)
+}
@@ -19,14 +19,22 @@ package sdk
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
 )
 
 // Service is used by Postman API consumers.
 type Service struct {
 	Options *client.Options
+
+	teamID string
 }
 
 // NewService returns a new instance of the Postman API service client.
@@ -36,25 +44,69 @@ func NewService(options *client.Options) *Service {
 	}
 }
 
+// AsTeam returns a copy of the service that creates resources in the team
+// account identified by teamID rather than the personal account.  Only
+// requests creating resources, including imports and forks, are sent for
+// the team.  The current user must belong to the team.
+func (s *Service) AsTeam(ctx context.Context, teamID string) (*Service, error) {
+	if teamID == "" {
+		return nil, errors.New("a team ID is required")
+	}
+
+	user, err := s.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TeamID != teamID {
+		return nil, fmt.Errorf("the current user does not belong to team %s", teamID)
+	}
+
+	ret := *s
+	ret.teamID = teamID
+
+	return &ret, nil
+}
+
+// createParams returns queryParams for a request creating a resource, with
+// the team of a service returned by AsTeam added so the resource is created
+// in that team's account.
+func (s *Service) createParams(queryParams map[string]string) map[string]string {
+	if s.teamID == "" {
+		return queryParams
+	}
+
+	params := map[string]string{"team": s.teamID}
+	for k, v := range queryParams {
+		params[k] = v
+	}
+
+	return params
+}
+
 func (s *Service) get(ctx context.Context, r interface{}, queryParams map[string]string, path ...string) (*http.Response, error) {
 	req := client.NewRequestWithContext(ctx, s.Options)
 	res, err := req.Get().
 		Path(path...).
 		Params(queryParams).
-		Into(&r).
+		Into(r).
 		Do()
 
 	return res, err
 }
 
 func (s *Service) post(ctx context.Context, input []byte, output interface{}, queryParams map[string]string, path ...string) (*http.Response, error) {
+	return s.postContent(ctx, input, "application/json", output, queryParams, path...)
+}
+
+func (s *Service) postContent(ctx context.Context, input []byte, contentType string, output interface{}, queryParams map[string]string, path ...string) (*http.Response, error) {
 	req := client.NewRequestWithContext(ctx, s.Options)
 	res, err := req.Post().
 		Path(path...).
 		Params(queryParams).
-		AddHeader("Content-Type", "application/json").
+		AddHeader("Content-Type", contentType).
 		Body(bytes.NewReader(input)).
-		Into(&output).
+		Into(output).
 		Do()
 
 	return res, err
@@ -66,7 +118,19 @@ func (s *Service) put(ctx context.Context, input []byte, output interface{}, pat
 		Path(path...).
 		AddHeader("Content-Type", "application/json").
 		Body(bytes.NewReader(input)).
-		Into(&output).
+		Into(output).
+		Do()
+
+	return res, err
+}
+
+func (s *Service) patch(ctx context.Context, input []byte, output interface{}, path ...string) (*http.Response, error) {
+	req := client.NewRequestWithContext(ctx, s.Options)
+	res, err := req.Patch().
+		Path(path...).
+		AddHeader("Content-Type", "application/json").
+		Body(bytes.NewReader(input)).
+		Into(output).
 		Do()
 
 	return res, err
@@ -77,8 +141,159 @@ func (s *Service) delete(ctx context.Context, output interface{}, path ...string
 	res, err := req.Delete().
 		Path(path...).
 		AddHeader("Content-Type", "application/json").
-		Into(&output).
+		Into(output).
 		Do()
 
 	return res, err
 }
+
+// pageMeta holds the pagination details of a list response.  Cursor-based
+// endpoints return nextCursor, either at the top level or under meta;
+// offset-based endpoints return a total count under meta.
+type pageMeta struct {
+	NextCursor string `json:"nextCursor"`
+	Meta       struct {
+		NextCursor string `json:"nextCursor"`
+		Total      *int   `json:"total"`
+		Offset     int    `json:"offset"`
+	} `json:"meta"`
+}
+
+// paginate calls page with the body of each page of a list endpoint,
+// following cursors, or offsets as a fallback, until there are no further
+// pages or page reports that it needs no more.  page returns the number of
+// entries it found in the body.  The returned PageInfo describes the last
+// page read.
+func (s *Service) paginate(ctx context.Context, queryParams map[string]string, page func(body []byte) (count int, more bool, err error), path ...string) (resources.PageInfo, error) {
+	params := make(map[string]string, len(queryParams)+1)
+	for k, v := range queryParams {
+		params[k] = v
+	}
+
+	var info resources.PageInfo
+	for {
+		var body json.RawMessage
+		if _, err := s.get(ctx, &body, params, path...); err != nil {
+			return info, err
+		}
+
+		count, more, err := page(body)
+		if err != nil || count == 0 {
+			return info, err
+		}
+
+		var meta pageMeta
+		if err := json.Unmarshal(body, &meta); err != nil {
+			if !more {
+				return info, nil
+			}
+			return info, err
+		}
+
+		if meta.Meta.Total != nil {
+			info.Total = *meta.Meta.Total
+		}
+
+		info.HasMore = meta.next(params, count)
+		if !more || !info.HasMore {
+			return info, nil
+		}
+	}
+}
+
+// next sets the query params for the page following one with count entries,
+// reporting whether there is one.
+func (m pageMeta) next(params map[string]string, count int) bool {
+	if cursor := m.Meta.NextCursor; cursor != "" {
+		params["cursor"] = cursor
+	} else if cursor := m.NextCursor; cursor != "" {
+		params["cursor"] = cursor
+	} else if m.Meta.Total != nil && m.Meta.Offset+count < *m.Meta.Total {
+		params["offset"] = strconv.Itoa(m.Meta.Offset + count)
+	} else {
+		return false
+	}
+
+	return true
+}
+
+// responseID makes a best attempt at returning the ID value of the resource
+// wrapped under key in a Postman API response.
+func responseID(responseBody interface{}, key string) string {
+	responseValue, ok := responseBody.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	vMap, ok := responseValue[key].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	if v, ok := vMap["uid"].(string); ok {
+		return v
+	}
+
+	if v, ok := vMap["id"].(string); ok {
+		return v
+	}
+
+	return ""
+}
+
+// permissionError converts a 403 RequestError into a PermissionError.
+func permissionError(err error) error {
+	var e *client.RequestError
+	if errors.As(err, &e) && e.StatusCode == http.StatusForbidden {
+		return &client.PermissionError{RequestError: e}
+	}
+
+	return err
+}
+
+// MergeConflictError is returned when a fork can't be merged because the
+// same keys were changed in both the fork and its destination.
+type MergeConflictError struct {
+	*client.RequestError
+	Keys []string
+}
+
+func (e *MergeConflictError) Error() string {
+	return fmt.Sprintf("merge conflict on keys: %s", strings.Join(e.Keys, ", "))
+}
+
+// Unwrap returns the underlying RequestError.
+func (e *MergeConflictError) Unwrap() error {
+	return e.RequestError
+}
+
+// mergeConflictError converts a 409 RequestError into a MergeConflictError,
+// collecting the conflicting keys from the error details.
+func mergeConflictError(err error) error {
+	var e *client.RequestError
+	if !errors.As(err, &e) || e.StatusCode != http.StatusConflict {
+		return err
+	}
+
+	var keys []string
+	if conflicts, ok := e.Details["conflicts"].([]interface{}); ok {
+		for _, c := range conflicts {
+			switch v := c.(type) {
+			case string:
+				keys = append(keys, v)
+			case map[string]interface{}:
+				if k, ok := v["key"].(string); ok {
+					keys = append(keys, k)
+				}
+			}
+		}
+	} else {
+		for _, f := range e.FieldErrors {
+			if f.Field != "" {
+				keys = append(keys, f.Field)
+			}
+		}
+	}
+
+	return &MergeConflictError{RequestError: e, Keys: keys}
+}
@@ -17,12 +17,15 @@ limitations under the License.
 package sdk
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"sync"
+	"time"
 
 	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
 )
@@ -38,6 +41,49 @@ func (s *Service) CreateCollectionFromReader(ctx context.Context, reader io.Read
 	return s.CreateFromReader(ctx, resources.CollectionType, reader, params, nil)
 }
 
+// ImportOpenAPIFromReader imports an OpenAPI spec as a new collection and
+// returns its ID.  The spec is sent as is, declared as JSON or YAML based on
+// its content unless contentType overrides it.
+func (s *Service) ImportOpenAPIFromReader(ctx context.Context, reader io.Reader, workspace, contentType string) (string, error) {
+	spec, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	if contentType == "" {
+		contentType = resources.SpecContentType(spec)
+	}
+
+	var params map[string]string
+	if workspace != "" {
+		params = make(map[string]string)
+		params["workspace"] = workspace
+	}
+
+	var resource resources.ImportResponse
+	if _, err := s.postContent(ctx, spec, contentType, &resource, s.createParams(params), "import", "openapi"); err != nil {
+		return "", err
+	}
+
+	if len(resource.Collections) == 0 {
+		return "", nil
+	}
+
+	return resource.Collections[0].UID, nil
+}
+
+// ImportOpenAPIBundle imports the OpenAPI spec at rootPath as a new
+// collection, after bundling the local files it references into a single
+// spec, and returns the collection's ID.
+func (s *Service) ImportOpenAPIBundle(ctx context.Context, rootPath, workspace string) (string, error) {
+	spec, err := resources.BundleOpenAPI(rootPath)
+	if err != nil {
+		return "", err
+	}
+
+	return s.ImportOpenAPIFromReader(ctx, bytes.NewReader(spec), workspace, resources.SpecContentTypeJSON)
+}
+
 // CreateEnvironmentFromReader creates a new environment.
 func (s *Service) CreateEnvironmentFromReader(ctx context.Context, reader io.Reader, workspace string) (string, error) {
 	var params map[string]string
@@ -128,6 +174,151 @@ func (s *Service) CreateSchemaFromReader(ctx context.Context, reader io.Reader,
 	return s.CreateFromReader(ctx, resources.SchemaType, reader, queryParams, urlParams)
 }
 
+// AddCollectionItem adds a single request item to an existing collection
+// without replacing the whole collection.  The item is added to the root of
+// the collection when parentFolderID is empty.
+func (s *Service) AddCollectionItem(ctx context.Context, collectionID, parentFolderID string, item resources.Item) (string, error) {
+	if collectionID == "" {
+		return "", errors.New("a collection ID is required for adding an item")
+	}
+
+	if item.Item == nil {
+		return "", errors.New("an item is required for adding to a collection")
+	}
+
+	requestBody, err := json.Marshal(item)
+	if err != nil {
+		return "", err
+	}
+
+	var queryParams map[string]string
+	if parentFolderID != "" {
+		queryParams = make(map[string]string)
+		queryParams["folder"] = parentFolderID
+	}
+
+	var resource resources.CollectionItemResponse
+	if _, err := s.post(ctx, requestBody, &resource, queryParams, "collections", collectionID, "requests"); err != nil {
+		return "", err
+	}
+
+	return resource.Data.ID, nil
+}
+
+// CreateComment posts a comment on a collection, folder, or request and
+// returns the new comment.
+func (s *Service) CreateComment(ctx context.Context, target resources.CommentTarget, comment resources.NewComment) (*resources.Comment, error) {
+	path, err := target.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.Body == "" {
+		return nil, errors.New("a body is required for creating a comment")
+	}
+
+	requestBody, err := json.Marshal(comment)
+	if err != nil {
+		return nil, err
+	}
+
+	var resource resources.CommentResponse
+	if _, err := s.post(ctx, requestBody, &resource, nil, path...); err != nil {
+		return nil, err
+	}
+
+	return &resource.Data, nil
+}
+
+// CreateCollectionShareLink creates a temporary link sharing a collection,
+// which expires after options.ExpiresIn.
+func (s *Service) CreateCollectionShareLink(ctx context.Context, id string, options resources.ShareOptions) (*resources.ShareLink, error) {
+	if id == "" {
+		return nil, errors.New("a collection ID is required for creating a share link")
+	}
+
+	if options.ExpiresIn <= 0 {
+		return nil, errors.New("a positive expiry is required for creating a share link")
+	}
+
+	access := options.Access
+	if access == "" {
+		access = resources.ShareAccessView
+	}
+
+	if !access.Valid() {
+		return nil, fmt.Errorf("invalid share link access %q", access)
+	}
+
+	input := struct {
+		ShareLink struct {
+			Access    resources.ShareAccess `json:"access"`
+			ExpiresAt time.Time             `json:"expiresAt"`
+		} `json:"shareLink"`
+	}{}
+	input.ShareLink.Access = access
+	input.ShareLink.ExpiresAt = time.Now().Add(options.ExpiresIn).UTC()
+
+	// swallow error here, the input structs will always marshal
+	requestBody, _ := json.Marshal(input)
+
+	var resource resources.ShareLinkResponse
+	if _, err := s.post(ctx, requestBody, &resource, nil, "collections", id, "share-links"); err != nil {
+		return nil, permissionError(err)
+	}
+
+	return &resource.ShareLink, nil
+}
+
+// AddAPIRelations links elements to an API version, given as the IDs of
+// the elements of each relation type.
+func (s *Service) AddAPIRelations(ctx context.Context, apiID, apiVersionID string, relations map[resources.APIRelationType][]string) error {
+	if apiID == "" || apiVersionID == "" {
+		return errors.New("an API ID and API version ID are required for adding relations")
+	}
+
+	if len(relations) == 0 {
+		return errors.New("at least one relation is required")
+	}
+
+	for t := range relations {
+		if !t.Valid() {
+			return fmt.Errorf("invalid API relation type %q", t)
+		}
+	}
+
+	// swallow error here, the relations will always marshal
+	requestBody, _ := json.Marshal(relations)
+
+	var resource interface{}
+	if _, err := s.post(ctx, requestBody, &resource, nil, "apis", apiID, "versions", apiVersionID, "relations"); err != nil {
+		return permissionError(err)
+	}
+
+	return nil
+}
+
+// DuplicateCollection creates an independent copy of an existing collection,
+// unlike a fork, named newName in the given workspace.  The default workspace
+// is used when workspace is empty.
+func (s *Service) DuplicateCollection(ctx context.Context, id, newName, workspace string) (string, error) {
+	if newName == "" {
+		return "", errors.New("a name is required for duplicating a collection")
+	}
+
+	c, err := s.Collection(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(c.Duplicate(newName))
+	if err != nil {
+		return "", err
+	}
+
+	return s.CreateCollectionFromReader(ctx, bytes.NewReader(b), workspace)
+}
+
 // CreateFromReader posts a new resource to the Postman API.
 func (s *Service) CreateFromReader(ctx context.Context, t resources.ResourceType, reader io.Reader, queryParams, urlParams map[string]string) (string, error) {
 	b, err := ioutil.ReadAll(reader)
@@ -233,7 +424,7 @@ func (s *Service) CreateFromReader(ctx context.Context, t resources.ResourceType
 	}
 
 	var responseBody interface{}
-	if _, err := s.post(ctx, requestBody, &responseBody, queryParams, path...); err != nil {
+	if _, err := s.post(ctx, requestBody, &responseBody, s.createParams(queryParams), path...); err != nil {
 		return "", err
 	}
 
@@ -251,3 +442,103 @@ func (s *Service) CreateFromReader(ctx context.Context, t resources.ResourceType
 	}
 	return "", nil
 }
+
+// maxConcurrentCreateRequests bounds the resources created in parallel by
+// CreateMany.
+const maxConcurrentCreateRequests = 4
+
+// CreateMany creates a resource of type t from each reader, in parallel,
+// with the same parameters as CreateFromReader.  It returns the IDs created
+// in reader order, empty for resources that weren't created.
+//
+// When ctx is cancelled, no further creates are started and those in flight
+// are waited for, so their IDs are known.  With rollback, a cancellation or
+// a failed create deletes the resources that were created and no IDs are
+// returned.  The error wraps ctx.Err(), or the failed creates, with a
+// summary of what was created and rolled back.
+func (s *Service) CreateMany(ctx context.Context, t resources.ResourceType, readers []io.Reader, queryParams, urlParams map[string]string, rollback bool) ([]string, error) {
+	var (
+		// Creates in flight finish regardless of ctx, otherwise a resource
+		// created by the Postman API could be lost along with its ID.
+		work = context.WithoutCancel(ctx)
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, maxConcurrentCreateRequests)
+		ids  = make([]string, len(readers))
+		errs = make([]error, len(readers))
+	)
+
+	for i, r := range readers {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, r io.Reader) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			ids[i], errs[i] = s.CreateFromReader(work, t, r, queryParams, urlParams)
+		}(i, r)
+	}
+
+	wg.Wait()
+
+	cause := ctx.Err()
+	if cause == nil {
+		cause = errors.Join(errs...)
+	}
+
+	if cause == nil {
+		return ids, nil
+	}
+
+	created := 0
+	for _, id := range ids {
+		if id != "" {
+			created++
+		}
+	}
+
+	if !rollback {
+		return ids, fmt.Errorf("%w: created %d of %d resources", cause, created, len(readers))
+	}
+
+	removed, err := s.deleteMany(work, t, ids, urlParams)
+	summary := fmt.Errorf("%w: created %d of %d resources, rolled back %d", cause, created, len(readers), removed)
+
+	return nil, errors.Join(summary, err)
+}
+
+// deleteMany deletes the resources of type t with the given IDs, skipping
+// empty ones, and returns how many were deleted.
+func (s *Service) deleteMany(ctx context.Context, t resources.ResourceType, ids []string, urlParams map[string]string) (int, error) {
+	var (
+		removed int
+		errs    []error
+	)
+
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+
+		params := make(map[string]string, len(urlParams)+1)
+		for k, v := range urlParams {
+			params[k] = v
+		}
+		params["ID"] = id
+
+		if _, err := s.Delete(ctx, t, params); err != nil {
+			errs = append(errs, fmt.Errorf("rolling back %s: %w", id, err))
+			continue
+		}
+		removed++
+	}
+
+	return removed, errors.Join(errs...)
+}
@@ -18,11 +18,21 @@ package sdk_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
 	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
 )
 
@@ -390,6 +400,384 @@ func TestCreateSchemaFromReader(t *testing.T) {
 	}
 }
 
+func TestImportOpenAPIFromReader(t *testing.T) {
+	cases := []struct {
+		name        string
+		spec        string
+		override    string
+		contentType string
+	}{
+		{"yaml", "openapi: 3.0.0\ninfo:\n  title: Test\n", "", "text/yaml"},
+		{"json", "{\"openapi\":\"3.0.0\"}", "", "application/json"},
+		{"override", "openapi: 3.0.0\n", "application/vnd.oai.openapi", "application/vnd.oai.openapi"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			teardown := setupCreateTest()
+			defer teardown()
+
+			path := "/import/openapi"
+			createMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPost)
+				}
+
+				if have := r.Header.Get("Content-Type"); have != c.contentType {
+					t.Errorf("Content-Type is incorrect, have: %s, want: %s", have, c.contentType)
+				}
+
+				if have := r.URL.Query().Get("workspace"); have != "ws" {
+					t.Errorf("Workspace is incorrect, have: %s, want: %s", have, "ws")
+				}
+
+				body, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if string(body) != c.spec {
+					t.Errorf("Body is incorrect, have: %s, want: %s", body, c.spec)
+				}
+
+				if _, err := w.Write([]byte(`{"collections":[{"id":"123","name":"Test","uid":"1-123"}]}`)); err != nil {
+					t.Error(err)
+				}
+			})
+
+			ensurePath(t, createMux, path)
+
+			r, err := createService.ImportOpenAPIFromReader(context.Background(), strings.NewReader(c.spec), "ws", c.override)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if r != "1-123" {
+				t.Errorf("Resource UID is incorrect, have: %s, want: %s", r, "1-123")
+			}
+		})
+	}
+}
+
+func TestImportOpenAPIBundle(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"openapi.yaml": "openapi: 3.0.0\ninfo:\n  title: Users\n  version: \"1.0\"\npaths:\n  /users:\n    $ref: users.yaml\n",
+		"users.yaml":   "get:\n  responses:\n    \"200\":\n      description: OK\n",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := "/import/openapi"
+	createMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if have := r.Header.Get("Content-Type"); have != "application/json" {
+			t.Errorf("Content-Type is incorrect, have: %s, want: %s", have, "application/json")
+		}
+
+		var spec map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := spec["paths"].(map[string]interface{})["/users"].(map[string]interface{})["get"]; !ok {
+			t.Errorf("Spec was not bundled, have: %v", spec)
+		}
+
+		if _, err := w.Write([]byte(`{"collections":[{"uid":"1-123"}]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, createMux, path)
+
+	r, err := createService.ImportOpenAPIBundle(context.Background(), filepath.Join(dir, "openapi.yaml"), "ws")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r != "1-123" {
+		t.Errorf("Resource UID is incorrect, have: %s, want: %s", r, "1-123")
+	}
+}
+
+func TestImportOpenAPIBundleMissingRoot(t *testing.T) {
+	_, err := createService.ImportOpenAPIBundle(context.Background(), filepath.Join(t.TempDir(), "openapi.yaml"), "")
+	if err == nil {
+		t.Errorf("Expected error.")
+	}
+}
+
+func TestCreateCollectionShareLink(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	path := "/collections/abcdef/share-links"
+	createMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPost)
+		}
+
+		var input struct {
+			ShareLink struct {
+				Access    string    `json:"access"`
+				ExpiresAt time.Time `json:"expiresAt"`
+			} `json:"shareLink"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Fatal(err)
+		}
+
+		if input.ShareLink.Access != "view" {
+			t.Errorf("Access is incorrect, have: %s, want: %s", input.ShareLink.Access, "view")
+		}
+
+		if d := time.Until(input.ShareLink.ExpiresAt); d < 23*time.Hour || d > 24*time.Hour {
+			t.Errorf("Expiry is incorrect, have: %s", input.ShareLink.ExpiresAt)
+		}
+
+		if _, err := w.Write([]byte(`{"shareLink":{"id":"link-1","url":"https://go.postman.co/share/link-1","access":"view","expiresAt":"2020-06-02T10:00:00.000Z"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, createMux, path)
+
+	link, err := createService.CreateCollectionShareLink(context.Background(), "abcdef", resources.ShareOptions{ExpiresIn: 24 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if link.ID != "link-1" || link.URL != "https://go.postman.co/share/link-1" || link.Access != resources.ShareAccessView {
+		t.Errorf("Share link is incorrect, have: %+v", link)
+	}
+
+	if !link.ExpiresAt.Equal(time.Date(2020, 6, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expiry is incorrect, have: %s", link.ExpiresAt)
+	}
+}
+
+func TestCreateCollectionShareLinkInvalidOptions(t *testing.T) {
+	cases := []resources.ShareOptions{
+		{},
+		{ExpiresIn: time.Hour, Access: "edit"},
+	}
+
+	for _, options := range cases {
+		if _, err := createService.CreateCollectionShareLink(context.Background(), "abcdef", options); err == nil {
+			t.Errorf("Expected error for %+v.", options)
+		}
+	}
+}
+
+func TestAddAPIRelations(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	path := "/apis/12345/versions/4567/relations"
+	createMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPost)
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := string(body), `{"documentation":["1-docs"],"testsuite":["1-tests","2-tests"]}`; have != want {
+			t.Errorf("Body is incorrect, have: %s, want: %s", have, want)
+		}
+
+		if _, err := w.Write([]byte(`{"documentation":["1-docs"],"testsuite":["1-tests","2-tests"]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, createMux, path)
+
+	relations := map[resources.APIRelationType][]string{
+		resources.APIRelationDocumentation: {"1-docs"},
+		resources.APIRelationTestSuite:     {"1-tests", "2-tests"},
+	}
+	if err := createService.AddAPIRelations(context.Background(), "12345", "4567", relations); err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := map[resources.APIRelationType][]string{"wiki": {"1"}}
+	if err := createService.AddAPIRelations(context.Background(), "12345", "4567", invalid); err == nil {
+		t.Errorf("Expected error.")
+	}
+}
+
+func collectionReaders(n int) []io.Reader {
+	readers := make([]io.Reader, n)
+	for i := range readers {
+		readers[i] = strings.NewReader(`{"info":{"name":"bulk"}}`)
+	}
+
+	return readers
+}
+
+// handleBulkCollections serves collection creates, calling onCreate with the
+// number of each, and records deleted collections.
+func handleBulkCollections(t *testing.T, onCreate func(n int) bool) (created, deleted *[]string) {
+	var (
+		mu sync.Mutex
+		c  []string
+		d  []string
+	)
+
+	createMux.HandleFunc("/collections", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		id := fmt.Sprintf("c%d", len(c)+1)
+		ok := onCreate(len(c) + 1)
+		if ok {
+			c = append(c, id)
+		}
+		mu.Unlock()
+
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"name":"malformedRequestError","message":"invalid collection"}}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"collection":{"uid":"%s"}}`, id)
+	})
+
+	createMux.HandleFunc("/collections/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodDelete)
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/collections/")
+		mu.Lock()
+		d = append(d, id)
+		mu.Unlock()
+
+		fmt.Fprintf(w, `{"collection":{"uid":"%s"}}`, id)
+	})
+
+	return &c, &d
+}
+
+func TestCreateManyCancelRollsBack(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	created, deleted := handleBulkCollections(t, func(n int) bool {
+		if n == 2 {
+			cancel()
+		}
+		return true
+	})
+
+	ids, err := createService.CreateMany(ctx, resources.CollectionType, collectionReaders(20), nil, nil, true)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Error is incorrect, have: %v, want: %v", err, context.Canceled)
+	}
+
+	if ids != nil {
+		t.Errorf("IDs were returned after rolling back: %v", ids)
+	}
+
+	if len(*created) == 0 || len(*created) >= 20 {
+		t.Errorf("Creates were not stopped, have: %d", len(*created))
+	}
+
+	sort.Strings(*created)
+	sort.Strings(*deleted)
+	if strings.Join(*deleted, ",") != strings.Join(*created, ",") {
+		t.Errorf("Rolled back resources are incorrect, have: %v, want: %v", *deleted, *created)
+	}
+
+	if want := fmt.Sprintf("rolled back %d", len(*created)); !strings.Contains(err.Error(), want) {
+		t.Errorf("Error summary is incorrect, have: %s, want it to contain: %s", err, want)
+	}
+}
+
+func TestCreateManyFailureRollsBack(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	created, deleted := handleBulkCollections(t, func(n int) bool {
+		return n != 3
+	})
+
+	_, err := createService.CreateMany(context.Background(), resources.CollectionType, collectionReaders(3), nil, nil, true)
+
+	var reqErr *client.RequestError
+	if !errors.As(err, &reqErr) {
+		t.Fatalf("Error type is incorrect, have: %T, want: %T", err, reqErr)
+	}
+
+	if len(*deleted) != len(*created) || len(*created) != 2 {
+		t.Errorf("Rolled back resources are incorrect, have: %v, want: %v", *deleted, *created)
+	}
+}
+
+func TestCreateManyCancelWithoutRollback(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	created, deleted := handleBulkCollections(t, func(n int) bool {
+		if n == 1 {
+			cancel()
+		}
+		return true
+	})
+
+	ids, err := createService.CreateMany(ctx, resources.CollectionType, collectionReaders(20), nil, nil, false)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Error is incorrect, have: %v, want: %v", err, context.Canceled)
+	}
+
+	var have []string
+	for _, id := range ids {
+		if id != "" {
+			have = append(have, id)
+		}
+	}
+
+	if len(have) != len(*created) {
+		t.Errorf("IDs are incorrect, have: %v, want: %v", have, *created)
+	}
+
+	if len(*deleted) != 0 {
+		t.Errorf("Resources were rolled back: %v", *deleted)
+	}
+}
+
+func TestCreateMany(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	handleBulkCollections(t, func(n int) bool { return true })
+
+	ids, err := createService.CreateMany(context.Background(), resources.CollectionType, collectionReaders(6), nil, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	if have, want := strings.Join(sorted, ","), "c1,c2,c3,c4,c5,c6"; have != want {
+		t.Errorf("IDs are incorrect, have: %s, want: %s", have, want)
+	}
+}
+
 func TestCreateFromReaderReadError(t *testing.T) {
 	queryParams := make(map[string]string)
 	urlParams := make(map[string]string)
@@ -419,3 +807,292 @@ func TestCreateFromReaderInvalidResourceError(t *testing.T) {
 		t.Errorf("Expected error.")
 	}
 }
+
+func TestAddCollectionItem(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	path := "/collections/abcdef/requests"
+	subject := `{"data":{"id":"item-1","name":"Get User","collection":"abcdef","folder":"folder-1"},"meta":{"model":"request","action":"create"}}`
+
+	createMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPost)
+		}
+
+		if folder := r.URL.Query().Get("folder"); folder != "folder-1" {
+			t.Errorf("Folder is incorrect, have: %s, want: %s", folder, "folder-1")
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		if body["name"] != "Get User" {
+			t.Errorf("Item name is incorrect, have: %v, want: %s", body["name"], "Get User")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, createMux, path)
+
+	var item resources.Item
+	if err := json.Unmarshal([]byte(`{"name":"Get User","request":{"method":"GET","url":"{{baseUrl}}/users/1"}}`), &item); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := createService.AddCollectionItem(context.Background(), "abcdef", "folder-1", item)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r != "item-1" {
+		t.Errorf("Resource ID is incorrect, have: %s, want: %s", r, "item-1")
+	}
+}
+
+func TestAddCollectionItemRequiresItem(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	ensurePath(t, createMux, "")
+
+	if _, err := createService.AddCollectionItem(context.Background(), "abcdef", "", resources.Item{}); err == nil {
+		t.Error("Expected error.")
+	}
+}
+
+func TestDuplicateCollection(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	source := `{"collection":{
+		"info":{"_postman_id":"source-id","name":"Source","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"item":[
+			{"id":"folder-1","name":"Folder","item":[
+				{"id":"nested-folder","name":"Nested","item":[
+					{"id":"request-1","name":"Get","request":"https://example.com","response":[{"id":"response-1","name":"OK"}]}
+				]}
+			]},
+			{"id":"request-2","name":"Post","request":{"method":"POST","url":"https://example.com"},"event":[{"id":"event-1","listen":"test","script":{"id":"script-1","exec":[]}}]}
+		]}}`
+
+	createMux.HandleFunc("/collections/source-uid", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodGet)
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(source)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	createMux.HandleFunc("/collections", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPost)
+		}
+
+		if workspace := r.URL.Query().Get("workspace"); workspace != "ws-1" {
+			t.Errorf("Workspace is incorrect, have: %s, want: %s", workspace, "ws-1")
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, id := range []string{"source-id", "folder-1", "nested-folder", "request-1", "request-2", "response-1", "event-1", "script-1"} {
+			if strings.Contains(string(body), id) {
+				t.Errorf("Expected ID %s to be stripped from duplicate: %s", id, string(body))
+			}
+		}
+
+		if !strings.Contains(string(body), `"name":"Copy"`) {
+			t.Errorf("Expected duplicate to be renamed: %s", string(body))
+		}
+
+		if !strings.Contains(string(body), `"name":"Nested"`) {
+			t.Errorf("Expected duplicate to contain nested folder: %s", string(body))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"collection":{"uid":"copy-uid"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, createMux, "/collections")
+
+	r, err := createService.DuplicateCollection(context.Background(), "source-uid", "Copy", "ws-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r != "copy-uid" {
+		t.Errorf("Resource UID is incorrect, have: %s, want: %s", r, "copy-uid")
+	}
+}
+
+func TestCreateCollectionComment(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	path := "/collections/abcdef/comments"
+	subject := `{"data":{"id":1,"threadId":10,"createdBy":12345,"body":"Looks good"}}`
+
+	createMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPost)
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+
+		if body["body"] != "Looks good" {
+			t.Errorf("Comment body is incorrect, have: %v, want: %s", body["body"], "Looks good")
+		}
+
+		if _, ok := body["threadId"]; ok {
+			t.Error("Expected threadId to be omitted for a new thread.")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, createMux, path)
+
+	c, err := createService.CreateComment(context.Background(), resources.CollectionComments("abcdef"), resources.NewComment{Body: "Looks good"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.ID != 1 || c.ThreadID != 10 {
+		t.Errorf("Comment is incorrect, have: %+v", c)
+	}
+}
+
+func TestCreateCollectionAsTeam(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	createMux.HandleFunc("/me", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"user":{"id":12345,"teamId":678}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	path := "/collections"
+	createMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("team") != "678" {
+			t.Errorf("Team is incorrect, have: %s, want: %s", q.Get("team"), "678")
+		}
+
+		if q.Get("workspace") != "abcdef" {
+			t.Errorf("Workspace is incorrect, have: %s, want: %s", q.Get("workspace"), "abcdef")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"collection":{"uid":"abcdef"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, createMux, path)
+
+	team, err := createService.AsTeam(context.Background(), "678")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := team.CreateCollectionFromReader(context.Background(), strings.NewReader(`{"info":{}}`), "abcdef"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAsTeamOnlyScopesCreates(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	createMux.HandleFunc("/me", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"user":{"id":12345,"teamId":678}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	teams := make(map[string]string)
+	handle := func(path, body string) {
+		createMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			teams[r.URL.Path] = r.URL.Query().Get("team")
+
+			w.WriteHeader(http.StatusOK)
+			if _, err := w.Write([]byte(body)); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	handle("/collections/fork/1-a", `{"collection":{"uid":"1-f"}}`)
+	handle("/collections/merge", `{"collection":{"uid":"1-a"}}`)
+	handle("/monitors/1-m/run", `{"run":{}}`)
+
+	ensurePath(t, createMux, "/collections/fork/1-a")
+
+	team, err := createService.AsTeam(context.Background(), "678")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := team.ForkCollection(context.Background(), "1-a", "abcdef", "fork"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := team.MergeCollection(context.Background(), "1-f", "1-a", "deleteSource"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := team.RunMonitor(context.Background(), "1-m"); err != nil {
+		t.Fatal(err)
+	}
+
+	if teams["/collections/fork/1-a"] != "678" {
+		t.Errorf("Expected fork to be created for the team, have: %q", teams["/collections/fork/1-a"])
+	}
+
+	for _, path := range []string{"/collections/merge", "/monitors/1-m/run"} {
+		if v, ok := teams[path]; !ok || v != "" {
+			t.Errorf("Expected no team on %s, have: %q (called: %t)", path, v, ok)
+		}
+	}
+}
+
+func TestAsTeamRequiresMembership(t *testing.T) {
+	teardown := setupCreateTest()
+	defer teardown()
+
+	path := "/me"
+	createMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"user":{"id":12345,"teamId":678}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, createMux, path)
+
+	if _, err := createService.AsTeam(context.Background(), "999"); err == nil {
+		t.Error("Expected error for a team the user does not belong to.")
+	}
+}
@@ -103,6 +103,57 @@ func (s *Service) DeleteSchema(ctx context.Context, resourceID, apiID, apiVersio
 	return s.Delete(ctx, resources.SchemaType, urlParams)
 }
 
+// RemoveCollectionItem removes a single request item from an existing
+// collection without replacing the whole collection.
+func (s *Service) RemoveCollectionItem(ctx context.Context, collectionID, itemID string) (string, error) {
+	if collectionID == "" {
+		return "", errors.New("a collection ID is required for removing an item")
+	}
+
+	if itemID == "" {
+		return "", errors.New("an item ID is required for removing an item")
+	}
+
+	var resource resources.CollectionItemResponse
+	if _, err := s.delete(ctx, &resource, "collections", collectionID, "requests", itemID); err != nil {
+		return "", err
+	}
+
+	return resource.Data.ID, nil
+}
+
+// DeleteComment deletes a comment from a collection, folder, or request.
+func (s *Service) DeleteComment(ctx context.Context, target resources.CommentTarget, commentID string) error {
+	path, err := target.Path()
+	if err != nil {
+		return err
+	}
+
+	if commentID == "" {
+		return errors.New("a comment ID is required for deleting a comment")
+	}
+
+	var resource interface{}
+	_, err = s.delete(ctx, &resource, append(path, commentID)...)
+
+	return err
+}
+
+// RevokeCollectionShareLink revokes a share link of a collection before it
+// expires.
+func (s *Service) RevokeCollectionShareLink(ctx context.Context, id, linkID string) error {
+	if id == "" || linkID == "" {
+		return errors.New("a collection ID and share link ID are required for revoking a share link")
+	}
+
+	var resource interface{}
+	if _, err := s.delete(ctx, &resource, "collections", id, "share-links", linkID); err != nil {
+		return permissionError(err)
+	}
+
+	return nil
+}
+
 // Delete posts a new resource to the Postman API.
 func (s *Service) Delete(ctx context.Context, t resources.ResourceType, urlParams map[string]string) (string, error) {
 	var (
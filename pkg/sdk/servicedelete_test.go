@@ -373,3 +373,75 @@ func TestDeleteInvalidResourceError(t *testing.T) {
 		t.Errorf("Expected error.")
 	}
 }
+
+func TestRemoveCollectionItem(t *testing.T) {
+	teardown := setupDeleteTest()
+	defer teardown()
+
+	path := "/collections/abcdef/requests/item-1"
+	subject := `{"data":{"id":"item-1","owner":"12345"},"meta":{"model":"request","action":"destroy"}}`
+
+	deleteMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodDelete)
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, deleteMux, path)
+
+	r, err := deleteService.RemoveCollectionItem(context.Background(), "abcdef", "item-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r != "item-1" {
+		t.Errorf("Resource ID is incorrect, have: %s, want: %s", r, "item-1")
+	}
+}
+
+func TestRemoveCollectionItemError(t *testing.T) {
+	teardown := setupDeleteTest()
+	defer teardown()
+
+	path := "/collections/abcdef/requests/item-1"
+	deleteMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	ensurePath(t, deleteMux, path)
+
+	if _, err := deleteService.RemoveCollectionItem(context.Background(), "abcdef", "item-1"); err == nil {
+		t.Error("Expected error.")
+	}
+}
+
+func TestRevokeCollectionShareLink(t *testing.T) {
+	teardown := setupDeleteTest()
+	defer teardown()
+
+	path := "/collections/abcdef/share-links/link-1"
+	deleteMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodDelete)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"shareLink":{"id":"link-1"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, deleteMux, path)
+
+	if err := deleteService.RevokeCollectionShareLink(context.Background(), "abcdef", "link-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := deleteService.RevokeCollectionShareLink(context.Background(), "abcdef", ""); err == nil {
+		t.Errorf("Expected error.")
+	}
+}
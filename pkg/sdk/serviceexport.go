@@ -0,0 +1,168 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+// ExportManifestFile is the file in an export directory recording the
+// export's progress.
+const ExportManifestFile = "manifest.json"
+
+// ExportWorkspace writes the collections and environments of a workspace
+// to dir, as Postman collection and environment files under collections/
+// and environments/.  Progress is recorded in the export manifest as each
+// file is written, so calling ExportWorkspace again on the same directory
+// after an interruption fetches only what's missing.  The manifest so far
+// is returned along with any error.
+func (s *Service) ExportWorkspace(ctx context.Context, id, dir string) (*resources.ExportManifest, error) {
+	if id == "" {
+		return nil, errors.New("a workspace ID is required for exporting a workspace")
+	}
+
+	manifest, err := readExportManifest(dir, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.Complete {
+		return manifest, nil
+	}
+
+	workspace, err := s.Workspace(ctx, id)
+	if err != nil {
+		return manifest, err
+	}
+
+	for _, c := range workspace.Collections {
+		if manifest.HasCollection(c.UID) {
+			continue
+		}
+
+		collection, err := s.Collection(ctx, c.UID)
+		if err != nil {
+			return manifest, fmt.Errorf("collection %s: %w", c.UID, err)
+		}
+
+		data, err := json.MarshalIndent(collection, "", "\t")
+		if err != nil {
+			return manifest, fmt.Errorf("collection %s: %w", c.UID, err)
+		}
+
+		if err := writeExportFile(dir, data, "collections", c.UID+".postman_collection.json"); err != nil {
+			return manifest, err
+		}
+
+		manifest.Collections = append(manifest.Collections, c.UID)
+		if err := writeExportManifest(dir, manifest); err != nil {
+			return manifest, err
+		}
+	}
+
+	for _, e := range workspace.Environments {
+		if manifest.HasEnvironment(e.UID) {
+			continue
+		}
+
+		env, err := s.Environment(ctx, e.UID)
+		if err != nil {
+			return manifest, fmt.Errorf("environment %s: %w", e.UID, err)
+		}
+
+		data, err := env.ExportJSON()
+		if err != nil {
+			return manifest, fmt.Errorf("environment %s: %w", e.UID, err)
+		}
+
+		if err := writeExportFile(dir, data, "environments", e.UID+".postman_environment.json"); err != nil {
+			return manifest, err
+		}
+
+		manifest.Environments = append(manifest.Environments, e.UID)
+		if err := writeExportManifest(dir, manifest); err != nil {
+			return manifest, err
+		}
+	}
+
+	manifest.Complete = true
+
+	return manifest, writeExportManifest(dir, manifest)
+}
+
+// readExportManifest reads the manifest of an export to dir, or starts a
+// new one when there is none.
+func readExportManifest(dir, workspaceID string) (*resources.ExportManifest, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, ExportManifestFile))
+	if os.IsNotExist(err) {
+		return &resources.ExportManifest{WorkspaceID: workspaceID}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var manifest resources.ExportManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid export manifest: %w", err)
+	}
+
+	if manifest.WorkspaceID != workspaceID {
+		return nil, fmt.Errorf("%s holds an export of workspace %s, not %s", dir, manifest.WorkspaceID, workspaceID)
+	}
+
+	return &manifest, nil
+}
+
+func writeExportManifest(dir string, manifest *resources.ExportManifest) error {
+	// swallow error here, manifests will always marshal
+	data, _ := json.MarshalIndent(manifest, "", "\t")
+
+	return writeExportFile(dir, data, ExportManifestFile)
+}
+
+// writeExportFile writes data to the file at path under dir through a
+// temporary file, so an interruption never leaves a partial file behind.
+func writeExportFile(dir string, data []byte, path ...string) error {
+	name := filepath.Join(append([]string{dir}, path...)...)
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(name), ".export-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
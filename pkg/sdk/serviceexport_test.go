@@ -0,0 +1,148 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+var (
+	exportMux     *http.ServeMux
+	exportService *sdk.Service
+)
+
+func setupExportTest() func() {
+	teardown := setupService(&exportMux, &exportService)
+
+	return teardown
+}
+
+func TestExportWorkspaceResumes(t *testing.T) {
+	teardown := setupExportTest()
+	defer teardown()
+
+	dir := t.TempDir()
+	calls := make(map[string]int)
+	interrupted := true
+
+	exportMux.HandleFunc("/workspaces/ws", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write([]byte(`{"workspace":{"id":"ws","name":"Team","collections":[{"uid":"1-a"},{"uid":"1-b"}],"environments":[{"uid":"1-e"}]}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	exportMux.HandleFunc("/collections/", func(w http.ResponseWriter, r *http.Request) {
+		uid := strings.TrimPrefix(r.URL.Path, "/collections/")
+		calls[uid]++
+
+		if uid == "1-b" && interrupted {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		if _, err := w.Write([]byte(`{"collection":{"info":{"name":"` + uid + `","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[]}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	exportMux.HandleFunc("/environments/1-e", func(w http.ResponseWriter, r *http.Request) {
+		calls["1-e"]++
+		if _, err := w.Write([]byte(`{"environment":{"id":"e","name":"dev","values":[{"key":"host","value":"example.com","enabled":true}]}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, exportMux, "/workspaces/ws")
+
+	manifest, err := exportService.ExportWorkspace(context.Background(), "ws", dir)
+	if err == nil {
+		t.Fatal("Expected error.")
+	}
+
+	if len(manifest.Collections) != 1 || manifest.Collections[0] != "1-a" || manifest.Complete {
+		t.Errorf("Manifest is incorrect after interruption, have: %+v", manifest)
+	}
+
+	interrupted = false
+	manifest, err = exportService.ExportWorkspace(context.Background(), "ws", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls["1-a"] != 1 || calls["1-b"] != 2 || calls["1-e"] != 1 {
+		t.Errorf("Collections were fetched again, have: %v", calls)
+	}
+
+	if !manifest.Complete || len(manifest.Collections) != 2 || len(manifest.Environments) != 1 {
+		t.Errorf("Manifest is incorrect, have: %+v", manifest)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, sdk.ExportManifestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var saved resources.ExportManifest
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+
+	if !saved.Complete || !saved.HasCollection("1-b") {
+		t.Errorf("Saved manifest is incorrect, have: %s", data)
+	}
+
+	for _, name := range []string{"collections/1-a.postman_collection.json", "collections/1-b.postman_collection.json"} {
+		data, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var c resources.Collection
+		if err := json.Unmarshal(data, &c); err != nil {
+			t.Errorf("%s is not a collection: %v", name, err)
+		}
+	}
+
+	data, err = ioutil.ReadFile(filepath.Join(dir, "environments", "1-e.postman_environment.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := resources.EnvironmentFromJSON(data); err != nil {
+		t.Errorf("Environment file is invalid: %v", err)
+	}
+}
+
+func TestExportWorkspaceRejectsOtherWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(dir, sdk.ExportManifestFile), []byte(`{"workspaceId":"other"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := exportService.ExportWorkspace(context.Background(), "ws", dir); err == nil || !strings.Contains(err.Error(), "other") {
+		t.Errorf("Expected workspace mismatch error, have: %v", err)
+	}
+}
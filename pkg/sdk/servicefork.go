@@ -19,6 +19,7 @@ package sdk
 import (
 	"context"
 	"encoding/json"
+	"errors"
 )
 
 // ForkCollection makes a fork of an existing collection.
@@ -37,7 +38,7 @@ func (s *Service) ForkCollection(ctx context.Context, id, workspace, label strin
 	requestBody, _ := json.Marshal(input)
 
 	var responseBody interface{}
-	if _, err := s.post(ctx, requestBody, &responseBody, queryParams, "collections", "fork", id); err != nil {
+	if _, err := s.post(ctx, requestBody, &responseBody, s.createParams(queryParams), "collections", "fork", id); err != nil {
 		return "", err
 	}
 
@@ -88,3 +89,54 @@ func (s *Service) MergeCollection(ctx context.Context, id, destination, strategy
 	}
 	return "", nil
 }
+
+// ForkEnvironment makes a fork of an existing environment in a workspace.
+func (s *Service) ForkEnvironment(ctx context.Context, id, workspace, label string) (string, error) {
+	if id == "" {
+		return "", errors.New("an environment ID is required for forking an environment")
+	}
+
+	queryParams := make(map[string]string)
+	queryParams["workspace"] = workspace
+
+	input := struct {
+		ForkName string `json:"forkName"`
+	}{
+		ForkName: label,
+	}
+
+	// swallow error here, strings will always marshal
+	requestBody, _ := json.Marshal(input)
+
+	var responseBody interface{}
+	if _, err := s.post(ctx, requestBody, &responseBody, s.createParams(queryParams), "environments", id, "forks"); err != nil {
+		return "", err
+	}
+
+	return responseID(responseBody, "environment"), nil
+}
+
+// MergeEnvironment merges a forked environment into its destination.  When
+// variables were changed in both, a *MergeConflictError listing the
+// conflicting variable keys is returned.
+func (s *Service) MergeEnvironment(ctx context.Context, id, destination string) (string, error) {
+	if id == "" || destination == "" {
+		return "", errors.New("a fork ID and a destination ID are required for merging an environment")
+	}
+
+	input := struct {
+		Source string `json:"source"`
+	}{
+		Source: id,
+	}
+
+	// swallow error here, strings will always marshal
+	requestBody, _ := json.Marshal(input)
+
+	var responseBody interface{}
+	if _, err := s.post(ctx, requestBody, &responseBody, nil, "environments", destination, "merges"); err != nil {
+		return "", mergeConflictError(err)
+	}
+
+	return responseID(responseBody, "environment"), nil
+}
@@ -18,7 +18,10 @@ package sdk_test
 
 import (
 	"context"
+	"errors"
+	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/kevinswiber/postmanctl/pkg/sdk"
@@ -248,3 +251,81 @@ func TestMergeCollectionError(t *testing.T) {
 		t.Error("Expected error.")
 	}
 }
+
+func TestForkEnvironment(t *testing.T) {
+	teardown := setupForkTest()
+	defer teardown()
+
+	path := "/environments/abcdef/forks"
+	subject := `{"environment":{"uid":"1234-fedcba","forkName":"staging"}}`
+
+	forkMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPost)
+		}
+
+		if have := r.URL.Query().Get("workspace"); have != "12345" {
+			t.Errorf("Workspace is incorrect, have: %s, want: %s", have, "12345")
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := `{"forkName":"staging"}`; string(body) != want {
+			t.Errorf("Request body is incorrect, have: %s, want: %s", string(body), want)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, forkMux, path)
+
+	r, err := forkService.ForkEnvironment(context.Background(), "abcdef", "12345", "staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r != "1234-fedcba" {
+		t.Errorf("Resource ID is incorrect, have: %s, want: %s", r, "1234-fedcba")
+	}
+}
+
+func TestMergeEnvironmentConflict(t *testing.T) {
+	teardown := setupForkTest()
+	defer teardown()
+
+	path := "/environments/abcdef/merges"
+	subject := `{"error":{"name":"mergeConflictError","message":"The fork has conflicts with its parent.","details":{"conflicts":["baseUrl",{"key":"token"}]}}}`
+
+	forkMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPost)
+		}
+		w.WriteHeader(http.StatusConflict)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, forkMux, path)
+
+	_, err := forkService.MergeEnvironment(context.Background(), "1234-fedcba", "abcdef")
+
+	var conflict *sdk.MergeConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Expected merge conflict error, have: %v", err)
+	}
+
+	if have := strings.Join(conflict.Keys, ","); have != "baseUrl,token" {
+		t.Errorf("Conflicting keys are incorrect, have: %s, want: %s", have, "baseUrl,token")
+	}
+
+	if conflict.StatusCode != http.StatusConflict {
+		t.Errorf("Status code is incorrect, have: %d, want: %d", conflict.StatusCode, http.StatusConflict)
+	}
+}
@@ -18,7 +18,18 @@ package sdk
 
 import (
 	"context"
-
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
 	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
 )
 
@@ -32,6 +43,168 @@ func (s *Service) Collections(ctx context.Context) (*resources.CollectionListIte
 	return &resource.Collections, nil
 }
 
+// StreamCollections calls fn with each collection as it is decoded from the
+// list response, following pages, so large lists are never held in memory
+// at once.  Streaming stops at the first error returned by fn, which is
+// returned.
+func (s *Service) StreamCollections(ctx context.Context, fn func(resources.CollectionListItem) error) error {
+	params := make(map[string]string)
+
+	for {
+		resp, err := client.NewRequestWithContext(ctx, s.Options).
+			Get().
+			Path("collections").
+			Params(params).
+			Do()
+		if err != nil {
+			return err
+		}
+
+		count, meta, err := streamCollectionPage(resp.Body, fn)
+		resp.Body.Close()
+		if err != nil || count == 0 || !meta.next(params, count) {
+			return err
+		}
+	}
+}
+
+// streamCollectionPage decodes a page of the collection list response,
+// calling fn with each collection, and returns the number of collections
+// and the page's pagination details.
+func streamCollectionPage(r io.Reader, fn func(resources.CollectionListItem) error) (int, pageMeta, error) {
+	var (
+		dec   = json.NewDecoder(r)
+		meta  pageMeta
+		count int
+	)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return 0, meta, err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return count, meta, err
+		}
+
+		switch tok {
+		case "collections":
+			if err := expectDelim(dec, '['); err != nil {
+				return count, meta, err
+			}
+
+			for dec.More() {
+				var c resources.CollectionListItem
+				if err := dec.Decode(&c); err != nil {
+					return count, meta, err
+				}
+
+				count++
+				if err := fn(c); err != nil {
+					return count, meta, err
+				}
+			}
+
+			if err := expectDelim(dec, ']'); err != nil {
+				return count, meta, err
+			}
+		case "meta":
+			if err := dec.Decode(&meta.Meta); err != nil {
+				return count, meta, err
+			}
+		case "nextCursor":
+			if err := dec.Decode(&meta.NextCursor); err != nil {
+				return count, meta, err
+			}
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return count, meta, err
+			}
+		}
+	}
+
+	return count, meta, nil
+}
+
+// expectDelim reads the next token, failing unless it is delim.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok != delim {
+		return fmt.Errorf("unexpected token %v in list response, want %v", tok, delim)
+	}
+
+	return nil
+}
+
+// SearchCollections returns the collections whose names contain query,
+// using the API's server-side name filter.
+func (s *Service) SearchCollections(ctx context.Context, query string) (*resources.CollectionListItems, error) {
+	queryParams := make(map[string]string)
+	queryParams["name"] = query
+
+	var resource resources.CollectionListResponse
+	if _, err := s.get(ctx, &resource, queryParams, "collections"); err != nil {
+		return nil, err
+	}
+
+	return &resource.Collections, nil
+}
+
+// CollectionsUpdatedSince returns the collections for the current user
+// updated at or after since, most recently updated first.  The Postman API
+// has no filter for it, so collections are filtered as they are listed.
+func (s *Service) CollectionsUpdatedSince(ctx context.Context, since time.Time) (*resources.CollectionListItems, error) {
+	collections, err := s.Collections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := resources.CollectionListItems{}
+	for _, c := range *collections {
+		if !c.UpdatedAt.Before(since) {
+			ret = append(ret, c)
+		}
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].UpdatedAt.After(ret[j].UpdatedAt)
+	})
+
+	return &ret, nil
+}
+
+// SharedCollections returns the collections shared with the user from other
+// workspaces, paging through the results.  Each is tagged with its owner.
+func (s *Service) SharedCollections(ctx context.Context) (*resources.CollectionListItems, error) {
+	queryParams := make(map[string]string)
+	queryParams["shared"] = "true"
+
+	collections := resources.CollectionListItems{}
+	_, err := s.paginate(ctx, queryParams, func(body []byte) (int, bool, error) {
+		var resource resources.SharedCollectionListResponse
+		if err := json.Unmarshal(body, &resource); err != nil {
+			return 0, false, err
+		}
+
+		for _, c := range resource.Data {
+			collections = append(collections, c.ListItem())
+		}
+
+		return len(resource.Data), true, nil
+	}, "collections")
+	if err != nil {
+		return nil, permissionError(err)
+	}
+
+	return &collections, nil
+}
+
 // Collection returns a single collection.
 func (s *Service) Collection(ctx context.Context, id string) (*resources.Collection, error) {
 	var resource resources.CollectionResponse
@@ -42,6 +215,159 @@ func (s *Service) Collection(ctx context.Context, id string) (*resources.Collect
 	return &resource.Collection, nil
 }
 
+// CollectionDependencies returns the graph of mocks and monitors that use
+// a collection, along with the environments they use.  The API doesn't
+// report which monitors use a collection, so every monitor is fetched, a few
+// at a time.
+func (s *Service) CollectionDependencies(ctx context.Context, id string) (*resources.DependencyGraph, error) {
+	c, err := s.Collection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{id}
+	var name string
+	if c.Collection != nil && c.Info != nil {
+		name = c.Info.Name
+		if c.Info.PostmanID != "" {
+			ids = append(ids, c.Info.PostmanID)
+		}
+	}
+
+	usesCollection := func(uid string) bool {
+		for _, id := range ids {
+			if sameResource(uid, id) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	environments, err := s.Environments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mocks, err := s.Mocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	monitors, err := s.Monitors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &resources.DependencyGraph{}
+	graph.AddNode(resources.DependencyNode{Kind: resources.DependencyCollection, UID: id, Name: name})
+
+	addEnvironment := func(from, uid string) {
+		node := resources.DependencyNode{Kind: resources.DependencyEnvironment, UID: uid}
+		for _, e := range *environments {
+			if sameResource(e.UID, uid) {
+				node.UID, node.Name = e.UID, e.Name
+				break
+			}
+		}
+
+		graph.AddNode(node)
+		graph.AddEdge(from, node.UID)
+	}
+
+	for _, m := range *mocks {
+		if !usesCollection(m.Collection) {
+			continue
+		}
+
+		graph.AddNode(resources.DependencyNode{Kind: resources.DependencyMock, UID: m.UID, Name: m.Name})
+		graph.AddEdge(m.UID, id)
+		if m.Environment != "" {
+			addEnvironment(m.UID, m.Environment)
+		}
+	}
+
+	details, err := s.monitorDetails(ctx, *monitors)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range details {
+		if !usesCollection(m.CollectionUID) {
+			continue
+		}
+
+		graph.AddNode(resources.DependencyNode{Kind: resources.DependencyMonitor, UID: m.UID, Name: m.Name})
+		graph.AddEdge(m.UID, id)
+		if m.EnvironmentUID != "" {
+			addEnvironment(m.UID, m.EnvironmentUID)
+		}
+	}
+
+	return graph, nil
+}
+
+// maxConcurrentMonitorRequests bounds the monitors fetched in parallel by
+// CollectionDependencies.
+const maxConcurrentMonitorRequests = 4
+
+// monitorDetails fetches each listed monitor in parallel, as the monitor
+// list doesn't include the collections and environments monitors use.  The
+// monitors are returned in list order.
+func (s *Service) monitorDetails(ctx context.Context, monitors resources.MonitorListItems) ([]*resources.Monitor, error) {
+	var (
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, maxConcurrentMonitorRequests)
+		results = make([]*resources.Monitor, len(monitors))
+		errs    = make([]error, len(monitors))
+	)
+
+	for i, m := range monitors {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			monitor, err := s.Monitor(ctx, id)
+			if err != nil {
+				errs[i] = fmt.Errorf("monitor %s: %w", id, err)
+				return
+			}
+
+			results[i] = monitor
+		}(i, m.ID)
+	}
+
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// sameResource reports whether a and b identify the same resource, where
+// either may be a UID, which is an ID prefixed with the owner's ID.
+func sameResource(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+
+	return a == b || strings.HasSuffix(a, "-"+b) || strings.HasSuffix(b, "-"+a)
+}
+
+// CollectionTags returns the tags on a collection.
+func (s *Service) CollectionTags(ctx context.Context, id string) (resources.Tags, error) {
+	var resource resources.TagsResponse
+	if _, err := s.get(ctx, &resource, nil, "collections", id, "tags"); err != nil {
+		return nil, err
+	}
+
+	return resource.Tags, nil
+}
+
 // Environments returns all environments.
 func (s *Service) Environments(ctx context.Context) (*resources.EnvironmentListItems, error) {
 	var resource resources.EnvironmentListResponse
@@ -52,6 +378,100 @@ func (s *Service) Environments(ctx context.Context) (*resources.EnvironmentListI
 	return &resource.Environments, nil
 }
 
+// SearchEnvironments returns the environments whose names contain query,
+// ignoring case.  The API has no server-side search for environments, so
+// every environment is fetched and filtered locally.
+func (s *Service) SearchEnvironments(ctx context.Context, query string) (*resources.EnvironmentListItems, error) {
+	environments, err := s.Environments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := resources.EnvironmentListItems{}
+	for _, e := range *environments {
+		if nameMatches(e.Name, query) {
+			ret = append(ret, e)
+		}
+	}
+
+	return &ret, nil
+}
+
+// maxConcurrentWorkspaceRequests bounds the requests made in parallel when
+// aggregating resources across workspaces.
+const maxConcurrentWorkspaceRequests = 4
+
+// AllEnvironments returns the environments of every workspace accessible to
+// the current user, each tagged with the first workspace it was listed in.
+// Environments from workspaces that could be listed are returned alongside
+// the combined errors of those that could not.
+func (s *Service) AllEnvironments(ctx context.Context) (resources.TaggedEnvironmentListItems, error) {
+	return s.AllEnvironmentsWithProgress(ctx, nil)
+}
+
+// AllEnvironmentsWithProgress is AllEnvironments, calling fn as each
+// workspace is listed.
+func (s *Service) AllEnvironmentsWithProgress(ctx context.Context, fn ProgressFunc) (resources.TaggedEnvironmentListItems, error) {
+	workspaces, err := s.Workspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		p       = newProgress(len(*workspaces), fn)
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, maxConcurrentWorkspaceRequests)
+		results = make([]resources.EnvironmentListItems, len(*workspaces))
+		errs    = make([]error, len(*workspaces))
+	)
+
+	for i, w := range *workspaces {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			defer p.step()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			queryParams := make(map[string]string)
+			queryParams["workspace"] = id
+
+			var resource resources.EnvironmentListResponse
+			if _, err := s.get(ctx, &resource, queryParams, "environments"); err != nil {
+				errs[i] = fmt.Errorf("workspace %s: %w", id, err)
+				return
+			}
+
+			results[i] = resource.Environments
+		}(i, w.ID)
+	}
+
+	wg.Wait()
+
+	var ret resources.TaggedEnvironmentListItems
+	seen := make(map[string]bool)
+	for i, envs := range results {
+		for _, e := range envs {
+			key := e.UID
+			if key == "" {
+				key = e.ID
+			}
+
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			ret = append(ret, resources.TaggedEnvironmentListItem{
+				EnvironmentListItem: e,
+				WorkspaceID:         (*workspaces)[i].ID,
+			})
+		}
+	}
+
+	return ret, errors.Join(errs...)
+}
+
 // Environment returns a single environment.
 func (s *Service) Environment(ctx context.Context, id string) (*resources.Environment, error) {
 	var resource resources.EnvironmentResponse
@@ -159,6 +579,31 @@ func (s *Service) Workspaces(ctx context.Context) (*resources.WorkspaceListItems
 	return &resource.Workspaces, nil
 }
 
+// SearchWorkspaces returns the workspaces whose names contain query,
+// ignoring case.  The API has no server-side search for workspaces, so
+// every workspace is fetched and filtered locally.
+func (s *Service) SearchWorkspaces(ctx context.Context, query string) (*resources.WorkspaceListItems, error) {
+	workspaces, err := s.Workspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := resources.WorkspaceListItems{}
+	for _, w := range *workspaces {
+		if nameMatches(w.Name, query) {
+			ret = append(ret, w)
+		}
+	}
+
+	return &ret, nil
+}
+
+// nameMatches reports whether name contains query, ignoring case, for
+// searches filtered locally.
+func nameMatches(name, query string) bool {
+	return strings.Contains(strings.ToLower(name), strings.ToLower(query))
+}
+
 // Workspace returns a single workspace for the current user.
 func (s *Service) Workspace(ctx context.Context, id string) (*resources.Workspace, error) {
 	var resource resources.WorkspaceResponse
@@ -169,6 +614,127 @@ func (s *Service) Workspace(ctx context.Context, id string) (*resources.Workspac
 	return &resource.Workspace, nil
 }
 
+// WorkspaceRoles returns the role assignments of a workspace.
+func (s *Service) WorkspaceRoles(ctx context.Context, id string) (resources.WorkspaceRoles, error) {
+	var resource resources.WorkspaceRolesResponse
+	if _, err := s.get(ctx, &resource, nil, "workspaces", id, "roles"); err != nil {
+		return nil, permissionError(err)
+	}
+
+	return resource.Roles, nil
+}
+
+// WorkspaceActivity returns up to limit of the most recent changes made in
+// a workspace, newest first, paging through the activity feed as needed.
+func (s *Service) WorkspaceActivity(ctx context.Context, id string, limit int) (resources.WorkspaceActivities, error) {
+	list, err := s.WorkspaceActivityList(ctx, id, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// WorkspaceActivityList returns up to limit of the most recent changes made
+// in a workspace, newest first, paging through the activity feed as
+// needed.  HasMore reports whether older changes were left out.
+func (s *Service) WorkspaceActivityList(ctx context.Context, id string, limit int) (*resources.WorkspaceActivityList, error) {
+	if limit < 1 {
+		return nil, errors.New("a positive limit is required for workspace activity")
+	}
+
+	queryParams := make(map[string]string)
+	queryParams["limit"] = strconv.Itoa(limit)
+
+	list := &resources.WorkspaceActivityList{Items: resources.WorkspaceActivities{}}
+	var err error
+	list.PageInfo, err = s.paginate(ctx, queryParams, func(body []byte) (int, bool, error) {
+		var resource resources.WorkspaceActivityListResponse
+		if err := json.Unmarshal(body, &resource); err != nil {
+			return 0, false, err
+		}
+
+		list.Items = append(list.Items, resource.Activities...)
+
+		return len(resource.Activities), len(list.Items) < limit, nil
+	}, "workspaces", id, "activities")
+	if err != nil {
+		return nil, permissionError(err)
+	}
+
+	sort.SliceStable(list.Items, func(i, j int) bool {
+		return list.Items[i].Timestamp.After(list.Items[j].Timestamp)
+	})
+
+	if len(list.Items) > limit {
+		list.Items = list.Items[:limit]
+		list.HasMore = true
+	}
+
+	return list, nil
+}
+
+// ErrUsageNotAvailable is returned by Usage for accounts without usage data.
+var ErrUsageNotAvailable = errors.New("usage data is not available for this account")
+
+// Usage returns the account's usage in the current billing period against
+// its plan limits.
+func (s *Service) Usage(ctx context.Context) (*resources.Usage, error) {
+	var resource resources.UsageResponse
+	if _, err := s.get(ctx, &resource, nil, "usage"); err != nil {
+		var e *client.RequestError
+		if errors.As(err, &e) && e.StatusCode == http.StatusNotFound {
+			return nil, ErrUsageNotAvailable
+		}
+
+		return nil, permissionError(err)
+	}
+
+	if resource.Usage == nil {
+		return nil, ErrUsageNotAvailable
+	}
+
+	return resource.Usage, nil
+}
+
+// Comments returns the comments on a collection, folder, or request, paging
+// through the results.
+func (s *Service) Comments(ctx context.Context, target resources.CommentTarget) (resources.CommentListItems, error) {
+	list, err := s.CommentList(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// CommentList returns the comments on a collection, folder, or request,
+// paging through the results, along with the total reported by the Postman
+// API.
+func (s *Service) CommentList(ctx context.Context, target resources.CommentTarget) (*resources.CommentList, error) {
+	path, err := target.Path()
+	if err != nil {
+		return nil, err
+	}
+
+	list := &resources.CommentList{}
+	list.PageInfo, err = s.paginate(ctx, nil, func(body []byte) (int, bool, error) {
+		var resource resources.CommentListResponse
+		if err := json.Unmarshal(body, &resource); err != nil {
+			return 0, false, err
+		}
+
+		list.Items = append(list.Items, resource.Data...)
+
+		return len(resource.Data), true, nil
+	}, path...)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
 // Monitors returns the monitors for the current user.
 func (s *Service) Monitors(ctx context.Context) (*resources.MonitorListItems, error) {
 	var resource resources.MonitorListResponse
@@ -189,6 +755,92 @@ func (s *Service) Monitor(ctx context.Context, id string) (*resources.Monitor, e
 	return &resource.Monitor, nil
 }
 
+// MonitorRunHistory returns up to limit of the most recent runs of a
+// monitor, newest first, paging through the run history as needed.
+func (s *Service) MonitorRunHistory(ctx context.Context, id string, limit int) (resources.MonitorRuns, error) {
+	list, err := s.MonitorRunList(ctx, id, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// MonitorRunList returns up to limit of the most recent runs of a monitor,
+// newest first, paging through the run history as needed.  HasMore reports
+// whether older runs were left out.
+func (s *Service) MonitorRunList(ctx context.Context, id string, limit int) (*resources.MonitorRunList, error) {
+	if limit < 1 {
+		return nil, errors.New("a positive limit is required for monitor run history")
+	}
+
+	queryParams := make(map[string]string)
+	queryParams["limit"] = strconv.Itoa(limit)
+
+	list := &resources.MonitorRunList{}
+	var err error
+	list.PageInfo, err = s.paginate(ctx, queryParams, func(body []byte) (int, bool, error) {
+		var resource resources.MonitorRunListResponse
+		if err := json.Unmarshal(body, &resource); err != nil {
+			return 0, false, err
+		}
+
+		list.Items = append(list.Items, resource.Runs...)
+
+		return len(resource.Runs), len(list.Items) < limit, nil
+	}, "monitors", id, "runs")
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(list.Items, func(i, j int) bool {
+		return list.Items[i].StartedAt.After(list.Items[j].StartedAt)
+	})
+
+	if len(list.Items) > limit {
+		list.Items = list.Items[:limit]
+		list.HasMore = true
+	}
+
+	return list, nil
+}
+
+// FailedMonitorRuns returns the failed runs of a monitor started since the
+// given time, newest first.  Paging through the run history stops once it
+// reaches runs started before then.
+func (s *Service) FailedMonitorRuns(ctx context.Context, id string, since time.Time) (resources.MonitorRuns, error) {
+	var runs resources.MonitorRuns
+	_, err := s.paginate(ctx, nil, func(body []byte) (int, bool, error) {
+		var resource resources.MonitorRunListResponse
+		if err := json.Unmarshal(body, &resource); err != nil {
+			return 0, false, err
+		}
+
+		more := true
+		for _, r := range resource.Runs {
+			if r.StartedAt.Before(since) {
+				more = false
+				continue
+			}
+
+			if r.Failed() {
+				runs = append(runs, r)
+			}
+		}
+
+		return len(resource.Runs), more, nil
+	}, "monitors", id, "runs")
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].StartedAt.After(runs[j].StartedAt)
+	})
+
+	return runs, nil
+}
+
 // Mocks returns the mocks for the current user.
 func (s *Service) Mocks(ctx context.Context) (*resources.MockListItems, error) {
 	var resource resources.MockListResponse
@@ -199,6 +851,77 @@ func (s *Service) Mocks(ctx context.Context) (*resources.MockListItems, error) {
 	return &resource.Mocks, nil
 }
 
+// maxConcurrentMockCollectionRequests bounds the collections fetched in
+// parallel by MocksWithCollections.
+const maxConcurrentMockCollectionRequests = 4
+
+// MocksWithCollections returns the mocks for the current user, each with
+// the name of its backing collection.  Collections are fetched in parallel,
+// once each.  Mocks whose collection can't be fetched are returned
+// alongside the combined errors, and are marked as missing their collection
+// when it no longer exists.
+func (s *Service) MocksWithCollections(ctx context.Context) (resources.MockCollectionListItems, error) {
+	mocks, err := s.Mocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, m := range *mocks {
+		if m.Collection != "" && !seen[m.Collection] {
+			seen[m.Collection] = true
+			ids = append(ids, m.Collection)
+		}
+	}
+
+	var (
+		wg    sync.WaitGroup
+		sem   = make(chan struct{}, maxConcurrentMockCollectionRequests)
+		names = make([]string, len(ids))
+		errs  = make([]error, len(ids))
+	)
+
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			c, err := s.Collection(ctx, id)
+			if err != nil {
+				errs[i] = fmt.Errorf("collection %s: %w", id, err)
+				return
+			}
+
+			if c.Collection != nil && c.Info != nil {
+				names[i] = c.Info.Name
+			}
+		}(i, id)
+	}
+
+	wg.Wait()
+
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+
+	ret := make(resources.MockCollectionListItems, len(*mocks))
+	for i, m := range *mocks {
+		ret[i].MockListItem = m
+		if j, ok := index[m.Collection]; ok {
+			ret[i].CollectionName = names[j]
+
+			var e *client.RequestError
+			ret[i].CollectionMissing = errors.As(errs[j], &e) && e.StatusCode == http.StatusNotFound
+		}
+	}
+
+	return ret, errors.Join(errs...)
+}
+
 // Mock returns a single mock for the current user.
 func (s *Service) Mock(ctx context.Context, id string) (*resources.Mock, error) {
 	var resource resources.MockResponse
@@ -208,3 +931,39 @@ func (s *Service) Mock(ctx context.Context, id string) (*resources.Mock, error)
 
 	return &resource.Mock, nil
 }
+
+// AuditLogs returns the audit log entries of the team between since and
+// until, inclusive, paging through the results.  A zero time leaves that end
+// of the range open.  Teams without audit log access get a PermissionError.
+func (s *Service) AuditLogs(ctx context.Context, since, until time.Time) (resources.AuditLogEntries, error) {
+	queryParams := make(map[string]string)
+	if !since.IsZero() {
+		queryParams["since"] = since.UTC().Format("2006-01-02")
+	}
+	if !until.IsZero() {
+		queryParams["until"] = until.UTC().Format("2006-01-02")
+	}
+
+	var entries resources.AuditLogEntries
+	_, err := s.paginate(ctx, queryParams, func(body []byte) (int, bool, error) {
+		var resource resources.AuditLogListResponse
+		if err := json.Unmarshal(body, &resource); err != nil {
+			return 0, false, err
+		}
+
+		// The Postman API only filters by day, so entries are matched against
+		// the exact range here.
+		for _, e := range resource.Trails {
+			if (since.IsZero() || !e.Timestamp.Before(since)) && (until.IsZero() || !e.Timestamp.After(until)) {
+				entries = append(entries, e)
+			}
+		}
+
+		return len(resource.Trails), true, nil
+	}, "audit", "logs")
+	if err != nil {
+		return nil, permissionError(err)
+	}
+
+	return entries, nil
+}
@@ -18,10 +18,18 @@ package sdk_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
 )
 
 var (
@@ -84,6 +92,310 @@ func TestCollectionsListError(t *testing.T) {
 	}
 }
 
+func TestStreamCollections(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	pages := map[string]string{
+		"":       `{"collections":[{"uid":"1-a","name":"A"},{"uid":"1-b","name":"B"}],"meta":{"nextCursor":"page-2"}}`,
+		"page-2": `{"meta":{},"collections":[{"uid":"1-c","name":"C","fork":{"label":"mine"}}],"other":{"ignored":[1,2]}}`,
+	}
+
+	path := "/collections"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Query().Get("cursor")]
+		if !ok {
+			t.Errorf("Unexpected page requested: %s", r.URL.Query().Get("cursor"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(page)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	seen := make(map[string]int)
+	var order []string
+	err := getService.StreamCollections(context.Background(), func(c resources.CollectionListItem) error {
+		seen[c.UID]++
+		order = append(order, c.UID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := strings.Join(order, ","), "1-a,1-b,1-c"; have != want {
+		t.Errorf("Collections are incorrect, have: %s, want: %s", have, want)
+	}
+
+	for uid, n := range seen {
+		if n != 1 {
+			t.Errorf("Collection %s delivered %d times, want once", uid, n)
+		}
+	}
+}
+
+func TestStreamCollectionsStopsOnError(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	calls := 0
+	path := "/collections"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"collections":[{"uid":"1-a"},{"uid":"1-b"}],"meta":{"nextCursor":"page-2"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	stop := errors.New("stop")
+	delivered := 0
+	err := getService.StreamCollections(context.Background(), func(c resources.CollectionListItem) error {
+		delivered++
+		return stop
+	})
+
+	if !errors.Is(err, stop) {
+		t.Errorf("Error is incorrect, have: %v, want: %v", err, stop)
+	}
+
+	if delivered != 1 || calls != 1 {
+		t.Errorf("Expected streaming to stop after the first collection, have %d collections from %d pages", delivered, calls)
+	}
+}
+
+func BenchmarkStreamCollections(b *testing.B) {
+	var (
+		mux     *http.ServeMux
+		service *sdk.Service
+	)
+	teardown := setupService(&mux, &service)
+	defer teardown()
+
+	var body strings.Builder
+	body.WriteString(`{"collections":[`)
+	for i := 0; i < 10000; i++ {
+		if i > 0 {
+			body.WriteString(",")
+		}
+		fmt.Fprintf(&body, `{"id":"c-%d","name":"Collection %d","owner":"12345","uid":"12345-c-%d"}`, i, i, i)
+	}
+	body.WriteString(`]}`)
+	page := []byte(body.String())
+
+	mux.HandleFunc("/collections", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write(page); err != nil {
+			b.Error(err)
+		}
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := service.StreamCollections(context.Background(), func(resources.CollectionListItem) error { return nil }); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSearchCollections(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/collections"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if have := r.URL.Query().Get("name"); have != "billing" {
+			t.Errorf("Name param is incorrect, have: %s, want: %s", have, "billing")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"collections":[{"uid":"1234-abcd","name":"Billing API"}]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.SearchCollections(context.Background(), "billing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*r) != 1 || (*r)[0].UID != "1234-abcd" {
+		t.Errorf("Collections are incorrect, have: %+v", *r)
+	}
+}
+
+func TestCollectionsUpdatedSince(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/collections"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"collections":[
+			{"uid":"1","name":"Old","updatedAt":"2020-05-01T10:00:00.000Z"},
+			{"uid":"2","name":"Boundary","updatedAt":"2020-06-01T00:00:00.000Z"},
+			{"uid":"3","name":"Recent","updatedAt":"2020-06-10T10:00:00.000Z"},
+			{"uid":"4","name":"Unknown"}
+		]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	since := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	r, err := getService.CollectionsUpdatedSince(context.Background(), since)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"3", "2"}
+	if len(*r) != len(want) {
+		t.Fatalf("Incorrect number of collections, have: %+v", *r)
+	}
+
+	for i, uid := range want {
+		if (*r)[i].UID != uid {
+			t.Errorf("Collection is incorrect, have: %s, want: %s", (*r)[i].UID, uid)
+		}
+	}
+
+	if !(*r)[0].UpdatedAt.Equal(time.Date(2020, 6, 10, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Updated time is incorrect, have: %s", (*r)[0].UpdatedAt)
+	}
+}
+
+func TestSearchEnvironmentsFiltersLocally(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/environments"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if len(r.URL.Query()) != 0 {
+			t.Errorf("Expected no search params, have: %s", r.URL.RawQuery)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"environments":[{"uid":"1","name":"Staging"},{"uid":"2","name":"Production"},{"uid":"3","name":"staging-eu"}]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.SearchEnvironments(context.Background(), "STAGING")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*r) != 2 || (*r)[0].UID != "1" || (*r)[1].UID != "3" {
+		t.Errorf("Environments are incorrect, have: %+v", *r)
+	}
+}
+
+func TestSearchWorkspacesFiltersLocally(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/workspaces"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"workspaces":[{"id":"1","name":"Payments"},{"id":"2","name":"Search"}]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.SearchWorkspaces(context.Background(), "pay")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*r) != 1 || (*r)[0].ID != "1" {
+		t.Errorf("Workspaces are incorrect, have: %+v", *r)
+	}
+
+	r, err = getService.SearchWorkspaces(context.Background(), "missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r == nil || len(*r) != 0 {
+		t.Errorf("Expected no workspaces, have: %+v", r)
+	}
+}
+
+func TestSharedCollectionsList(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	pages := map[string]string{
+		"":       `{"data":[{"id":"abcd","uid":"678-abcd","name":"Billing","owner":{"id":"678","name":"Jordan Diaz"},"workspace":{"id":"ws-1","name":"Payments"}}],"meta":{"nextCursor":"page-2"}}`,
+		"page-2": `{"data":[{"id":"efgh","uid":"910-efgh","name":"Search","owner":{"id":"910"},"workspace":{"id":"ws-2"}}],"meta":{}}`,
+	}
+
+	path := "/collections"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if have := r.URL.Query().Get("shared"); have != "true" {
+			t.Errorf("Shared param is incorrect, have: %s, want: %s", have, "true")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(pages[r.URL.Query().Get("cursor")])); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.SharedCollections(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*r) != 2 {
+		t.Fatalf("Expected 2 collections, have: %d", len(*r))
+	}
+
+	c := (*r)[0]
+	if c.UID != "678-abcd" || !c.Shared || c.Owner != "678" || c.OwnerName != "Jordan Diaz" || c.Workspace != "ws-1" {
+		t.Errorf("Shared collection is incorrect, have: %+v", c)
+	}
+
+	if (*r)[1].UID != "910-efgh" || (*r)[1].Owner != "910" {
+		t.Errorf("Shared collection is incorrect, have: %+v", (*r)[1])
+	}
+}
+
+func TestSharedCollectionsListError(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/collections"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	ensurePath(t, getMux, path)
+
+	_, err := getService.SharedCollections(context.Background())
+
+	var e *client.PermissionError
+	if !errors.As(err, &e) {
+		t.Errorf("Expected a PermissionError, have: %v", err)
+	}
+}
+
 func TestCollectionsItem(t *testing.T) {
 	teardown := setupGetTest()
 	defer teardown()
@@ -881,62 +1193,260 @@ func TestAPIRelationsList(t *testing.T) {
 	}
 }
 
-func TestAPIRelationsListError(t *testing.T) {
+func TestMocksWithCollections(t *testing.T) {
 	teardown := setupGetTest()
 	defer teardown()
 
-	path := "/apis/12345/versions/4567/relations"
-	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodGet)
+	getMux.HandleFunc("/mocks", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write([]byte(`{"mocks":[
+			{"id":"m1","uid":"1-m1","name":"Users mock","collection":"1-abc"},
+			{"id":"m2","uid":"1-m2","name":"Orphan mock","collection":"1-gone"}
+		]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+	getMux.HandleFunc("/collections/1-abc", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write([]byte(`{"collection":{"info":{"name":"Users","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[]}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+	getMux.HandleFunc("/collections/1-gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		if _, err := w.Write([]byte(`{"error":{"name":"instanceNotFoundError","message":"We could not find the collection you are looking for"}}`)); err != nil {
+			t.Error(err)
 		}
-		w.WriteHeader(http.StatusInternalServerError)
 	})
 
-	ensurePath(t, getMux, path)
+	ensurePath(t, getMux, "/mocks")
 
-	_, err := getService.APIRelations(context.Background(), "12345", "4567")
-	if err == nil {
-		t.Errorf("Should return an error.")
+	mocks, err := getService.MocksWithCollections(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "collection 1-gone") {
+		t.Errorf("Expected error for the missing collection, have: %v", err)
+	}
+
+	if len(mocks) != 2 {
+		t.Fatalf("Expected 2 mocks, have: %+v", mocks)
+	}
+
+	if mocks[0].CollectionName != "Users" || mocks[0].CollectionMissing {
+		t.Errorf("First mock is incorrect, have: %+v", mocks[0])
+	}
+
+	if mocks[1].UID != "1-m2" || mocks[1].CollectionName != "" || !mocks[1].CollectionMissing {
+		t.Errorf("Second mock is incorrect, have: %+v", mocks[1])
 	}
 }
-func TestFormattedAPIRelationsList(t *testing.T) {
+
+func TestCollectionDependencies(t *testing.T) {
 	teardown := setupGetTest()
 	defer teardown()
 
-	path := "/apis/12345/versions/4567/relations"
-	subject := `{"relations":{}}`
+	respond := func(path, body string) {
+		getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if _, err := w.Write([]byte(body)); err != nil {
+				t.Error(err)
+			}
+		})
+	}
 
-	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodGet)
+	respond("/collections/1-abc", `{"collection":{"info":{"_postman_id":"abc","name":"Users","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[]}}`)
+	respond("/environments", `{"environments":[{"id":"env","name":"Staging","uid":"1-env"},{"id":"other","name":"Other","uid":"1-other"}]}`)
+	respond("/mocks", `{"mocks":[
+		{"id":"m1","uid":"1-m1","name":"Users mock","collection":"1-abc","environment":"env"},
+		{"id":"m2","uid":"1-m2","name":"Other mock","collection":"1-xyz"}
+	]}`)
+	respond("/monitors", `{"monitors":[{"id":"mon1","uid":"1-mon1","name":"Hourly"},{"id":"mon2","uid":"1-mon2","name":"Other"}]}`)
+	respond("/monitors/mon1", `{"monitor":{"id":"mon1","uid":"1-mon1","name":"Hourly","collectionUid":"1-abc","environmentUid":"1-env"}}`)
+	respond("/monitors/mon2", `{"monitor":{"id":"mon2","uid":"1-mon2","name":"Other","collectionUid":"1-xyz","environmentUid":"1-other"}}`)
+
+	ensurePath(t, getMux, "/collections/1-abc")
+
+	graph, err := getService.CollectionDependencies(context.Background(), "1-abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []resources.DependencyNode{
+		{Kind: resources.DependencyCollection, UID: "1-abc", Name: "Users"},
+		{Kind: resources.DependencyMock, UID: "1-m1", Name: "Users mock"},
+		{Kind: resources.DependencyEnvironment, UID: "1-env", Name: "Staging"},
+		{Kind: resources.DependencyMonitor, UID: "1-mon1", Name: "Hourly"},
+	}
+	if !reflect.DeepEqual(graph.Nodes, expected) {
+		t.Errorf("Nodes are incorrect, have: %+v, want: %+v", graph.Nodes, expected)
+	}
+
+	dependents := graph.Dependents("1-env")
+	if len(dependents) != 2 || dependents[0].UID != "1-m1" || dependents[1].UID != "1-mon1" {
+		t.Errorf("Environment dependents are incorrect, have: %+v", dependents)
+	}
+
+	if dependents := graph.Dependents("1-abc"); len(dependents) != 2 {
+		t.Errorf("Collection dependents are incorrect, have: %+v", dependents)
+	}
+}
+
+func TestCollectionDependenciesBoundsMonitorRequests(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	respond := func(path, body string) {
+		getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if _, err := w.Write([]byte(body)); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	const n = 12
+	list := make([]string, n)
+	for i := range list {
+		list[i] = fmt.Sprintf(`{"id":"mon%d","uid":"1-mon%d","name":"Monitor %d"}`, i, i, i)
+	}
+
+	respond("/collections/1-abc", `{"collection":{"info":{"name":"Users","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[]}}`)
+	respond("/environments", `{"environments":[]}`)
+	respond("/mocks", `{"mocks":[]}`)
+	respond("/monitors", `{"monitors":[`+strings.Join(list, ",")+`]}`)
+
+	var inFlight, peak int32
+	getMux.HandleFunc("/monitors/", func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if current <= p || atomic.CompareAndSwapInt32(&peak, p, current) {
+				break
+			}
 		}
-		w.WriteHeader(http.StatusOK)
-		if _, err := w.Write([]byte(subject)); err != nil {
+
+		time.Sleep(20 * time.Millisecond)
+
+		id := strings.TrimPrefix(r.URL.Path, "/monitors/")
+		if _, err := fmt.Fprintf(w, `{"monitor":{"id":%q,"uid":"1-%s","name":%q,"collectionUid":"1-abc"}}`, id, id, id); err != nil {
 			t.Error(err)
 		}
 	})
 
-	ensurePath(t, getMux, path)
+	ensurePath(t, getMux, "/collections/1-abc")
 
-	r, err := getService.FormattedAPIRelationItems(context.Background(), "12345", "4567")
+	graph, err := getService.CollectionDependencies(context.Background(), "1-abc")
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	if r == nil {
-		t.Errorf("Should return a response.")
+	if dependents := graph.Dependents("1-abc"); len(dependents) != n || dependents[0].UID != "1-mon0" || dependents[n-1].UID != fmt.Sprintf("1-mon%d", n-1) {
+		t.Errorf("Monitors are incorrect or out of order, have: %+v", dependents)
+	}
+
+	if p := atomic.LoadInt32(&peak); p < 2 || p > 4 {
+		t.Errorf("Monitor requests in flight are incorrect, have: %d, want: 2 to 4", p)
 	}
 }
 
-func TestFormattedAPIRelationsListError(t *testing.T) {
+func TestAPIRelationsDecodesLinkedElements(t *testing.T) {
 	teardown := setupGetTest()
 	defer teardown()
 
 	path := "/apis/12345/versions/4567/relations"
+	subject := `{"relations":{
+		"documentation":{"1-docs":{"id":"1-docs","name":"Docs","createdAt":"2020-06-01T10:00:00.000Z"}},
+		"testsuite":{"1-tests":{"id":"1-tests","name":"Tests"}},
+		"environment":{"1-env":{"id":"1-env","name":"Staging"}}
+	}}`
+
 	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodGet)
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.APIRelations(context.Background(), "12345", "4567")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Documentation["1-docs"].Name != "Docs" || r.TestSuite["1-tests"].Name != "Tests" {
+		t.Errorf("Relations are incorrect, have: %+v", r)
+	}
+
+	items := resources.NewFormattedAPIRelationItems(r)
+	types := make(map[string]string)
+	for _, item := range items {
+		types[item.ID] = item.Type
+	}
+
+	want := map[string]string{"1-docs": "documentation", "1-tests": "testsuite", "1-env": "environment"}
+	if len(types) != len(want) {
+		t.Fatalf("Formatted relations are incorrect, have: %+v", items)
+	}
+
+	for id, typ := range want {
+		if types[id] != typ {
+			t.Errorf("Relation type of %s is incorrect, have: %s, want: %s", id, types[id], typ)
+		}
+	}
+}
+
+func TestAPIRelationsListError(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/apis/12345/versions/4567/relations"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodGet)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	ensurePath(t, getMux, path)
+
+	_, err := getService.APIRelations(context.Background(), "12345", "4567")
+	if err == nil {
+		t.Errorf("Should return an error.")
+	}
+}
+func TestFormattedAPIRelationsList(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/apis/12345/versions/4567/relations"
+	subject := `{"relations":{}}`
+
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodGet)
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.FormattedAPIRelationItems(context.Background(), "12345", "4567")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r == nil {
+		t.Errorf("Should return a response.")
+	}
+}
+
+func TestFormattedAPIRelationsListError(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/apis/12345/versions/4567/relations"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodGet)
 		}
 		w.WriteHeader(http.StatusInternalServerError)
 	})
@@ -948,3 +1458,894 @@ func TestFormattedAPIRelationsListError(t *testing.T) {
 		t.Errorf("Should return an error.")
 	}
 }
+
+func TestWorkspaceRoles(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/workspaces/abcdef/roles"
+	subject := `{"roles":[{"id":"12345","type":"user","role":"admin"},{"id":"678","type":"group","role":"viewer"}]}`
+
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodGet)
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.WorkspaceRoles(context.Background(), "abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r) != 2 {
+		t.Fatalf("Incorrect number of roles, have: %d, want: %d", len(r), 2)
+	}
+
+	if r[0].ID != "12345" || r[0].Type != resources.RoleMemberUser || r[0].Role != resources.WorkspaceRoleAdmin {
+		t.Errorf("Role assignment is incorrect, have: %+v", r[0])
+	}
+
+	if r[1].Type != resources.RoleMemberGroup || r[1].Role != resources.WorkspaceRoleViewer {
+		t.Errorf("Role assignment is incorrect, have: %+v", r[1])
+	}
+}
+
+func TestWorkspaceRolesForbidden(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/workspaces/abcdef/roles"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		if _, err := w.Write([]byte(`{"error":{"name":"forbiddenError","message":"You do not have access"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	_, err := getService.WorkspaceRoles(context.Background(), "abcdef")
+
+	var e *client.PermissionError
+	if !errors.As(err, &e) {
+		t.Fatalf("Incorrect error, expected PermissionError, got: %v", err)
+	}
+
+	if e.Name != "forbiddenError" {
+		t.Errorf("Error name is incorrect, have: %s, want: %s", e.Name, "forbiddenError")
+	}
+
+	if !client.IsPermissionError(err) {
+		t.Error("Expected IsPermissionError to be true.")
+	}
+}
+
+func TestCollectionComments(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/collections/abcdef/comments"
+	subject := `{"data":[
+		{"id":1,"threadId":10,"createdBy":12345,"body":"Looks good","createdAt":"2020-06-01T10:00:00.000Z","updatedAt":"2020-06-01T10:00:00.000Z"},
+		{"id":2,"threadId":20,"createdBy":12345,"body":"Needs a test","resolved":true},
+		{"id":3,"threadId":10,"createdBy":67890,"body":"Thanks"}
+	]}`
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.Comments(context.Background(), resources.CollectionComments("abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r) != 3 {
+		t.Fatalf("Incorrect number of comments, have: %d, want: %d", len(r), 3)
+	}
+
+	if r[0].Body != "Looks good" || r[0].CreatedBy != 12345 {
+		t.Errorf("Comment is incorrect, have: %+v", r[0])
+	}
+
+	threads := r.Threads()
+	if len(threads) != 2 {
+		t.Fatalf("Incorrect number of threads, have: %d, want: %d", len(threads), 2)
+	}
+
+	if threads[0].ID != 10 || len(threads[0].Comments) != 2 || threads[0].Resolved {
+		t.Errorf("Thread is incorrect, have: %+v", threads[0])
+	}
+
+	if threads[1].ID != 20 || !threads[1].Resolved {
+		t.Errorf("Thread is incorrect, have: %+v", threads[1])
+	}
+}
+
+func TestCommentsInvalidTarget(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	target := resources.CommentTarget{Type: resources.EnvironmentType, CollectionID: "abcdef", ID: "abcdef"}
+	if _, err := getService.Comments(context.Background(), target); err == nil {
+		t.Error("Expected error for unsupported comment target.")
+	}
+
+	if _, err := getService.Comments(context.Background(), resources.RequestComments("abcdef", "")); err == nil {
+		t.Error("Expected error for missing request ID.")
+	}
+}
+
+func TestMonitorRunHistory(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	pages := map[string]string{
+		"": `{"runs":[
+			{"id":"run-4","status":"success","startedAt":"2020-06-04T10:00:00.000Z"},
+			{"id":"run-3","status":"failed","startedAt":"2020-06-03T10:00:00.000Z","stats":{"assertions":{"total":4,"failed":1}}}
+		],"meta":{"nextCursor":"page-2"}}`,
+		"page-2": `{"runs":[
+			{"id":"run-2","status":"success","startedAt":"2020-06-02T10:00:00.000Z"},
+			{"id":"run-1","status":"success","startedAt":"2020-06-01T10:00:00.000Z"}
+		],"meta":{"nextCursor":"page-3"}}`,
+	}
+
+	var calls []string
+	path := "/monitors/abcdef/runs"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		calls = append(calls, q.Get("cursor"))
+
+		page, ok := pages[q.Get("cursor")]
+		if !ok {
+			t.Errorf("Unexpected page requested: %s", q.Get("cursor"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(page)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	runs, err := getService.MonitorRunHistory(context.Background(), "abcdef", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(calls) != 2 {
+		t.Errorf("Incorrect number of page requests, have: %d, want: %d", len(calls), 2)
+	}
+
+	want := []string{"run-4", "run-3", "run-2"}
+	if len(runs) != len(want) {
+		t.Fatalf("Incorrect number of runs, have: %d, want: %d", len(runs), len(want))
+	}
+
+	for i, id := range want {
+		if runs[i].ID != id {
+			t.Errorf("Run is incorrect, have: %s, want: %s", runs[i].ID, id)
+		}
+	}
+
+	if runs[1].Status != "failed" || runs[1].Stats.Assertions.Failed != 1 {
+		t.Errorf("Run is incorrect, have: %+v", runs[1])
+	}
+}
+
+func TestFailedMonitorRuns(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	pages := map[string]string{
+		"": `{"runs":[
+			{"id":"run-6","status":"success","startedAt":"2020-06-06T10:00:00.000Z"},
+			{"id":"run-5","status":"failed","startedAt":"2020-06-05T10:00:00.000Z"}
+		],"meta":{"nextCursor":"page-2"}}`,
+		"page-2": `{"runs":[
+			{"id":"run-4","status":"success","startedAt":"2020-06-04T10:00:00.000Z","stats":{"requests":{"total":2,"failed":1}}},
+			{"id":"run-3","status":"failed","startedAt":"2020-06-02T10:00:00.000Z"}
+		],"meta":{"nextCursor":"page-3"}}`,
+	}
+
+	var calls []string
+	path := "/monitors/abcdef/runs"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		calls = append(calls, q.Get("cursor"))
+
+		page, ok := pages[q.Get("cursor")]
+		if !ok {
+			t.Errorf("Unexpected page requested: %s", q.Get("cursor"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(page)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	since := time.Date(2020, 6, 3, 0, 0, 0, 0, time.UTC)
+	runs, err := getService.FailedMonitorRuns(context.Background(), "abcdef", since)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(calls) != 2 {
+		t.Errorf("Incorrect number of page requests, have: %d, want: %d", len(calls), 2)
+	}
+
+	want := []string{"run-5", "run-4"}
+	if len(runs) != len(want) {
+		t.Fatalf("Incorrect number of runs, have: %d, want: %d", len(runs), len(want))
+	}
+
+	for i, id := range want {
+		if runs[i].ID != id {
+			t.Errorf("Run is incorrect, have: %s, want: %s", runs[i].ID, id)
+		}
+	}
+}
+
+func TestMonitorRunHistoryStopsAtLimit(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	calls := 0
+	path := "/monitors/abcdef/runs"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if limit := r.URL.Query().Get("limit"); limit != "1" {
+			t.Errorf("Limit is incorrect, have: %s, want: %s", limit, "1")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"runs":[{"id":"run-4"}],"meta":{"nextCursor":"page-2"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	runs, err := getService.MonitorRunHistory(context.Background(), "abcdef", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(runs) != 1 || calls != 1 {
+		t.Errorf("Expected a single run from a single page, have %d runs from %d pages", len(runs), calls)
+	}
+}
+
+func TestMonitorRunListHasMore(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	pages := map[string]string{
+		"":       `{"runs":[{"id":"run-4"},{"id":"run-3"}],"meta":{"nextCursor":"page-2"}}`,
+		"page-2": `{"runs":[{"id":"run-2"},{"id":"run-1"}],"meta":{"nextCursor":"page-3"}}`,
+	}
+
+	path := "/monitors/abcdef/runs"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(pages[r.URL.Query().Get("cursor")])); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	list, err := getService.MonitorRunList(context.Background(), "abcdef", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list.Items) != 3 || !list.HasMore {
+		t.Errorf("Expected 3 runs with more left, have %d runs, has more: %t", len(list.Items), list.HasMore)
+	}
+
+	list, err = getService.MonitorRunList(context.Background(), "abcdef", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list.Items) != 2 || !list.HasMore {
+		t.Errorf("Expected 2 runs with more left, have %d runs, has more: %t", len(list.Items), list.HasMore)
+	}
+}
+
+func TestAuditLogs(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	pages := map[string]string{
+		"":       `{"trails":[{"id":1,"ip":"192.0.2.1","action":"user.login","timestamp":"2020-06-02T10:00:00.000Z","message":"Ada logged in","data":{"actor":{"id":12345,"name":"Ada","username":"ada"}}}],"nextCursor":"page-2"}`,
+		"page-2": `{"trails":[{"id":2,"action":"team.user_removed","timestamp":"2020-06-01T10:00:00.000Z","data":{"actor":{"id":12345,"name":"Ada"},"user":{"id":67890,"name":"Grace"},"team":{"id":1,"name":"Engineering"}}}]}`,
+	}
+
+	path := "/audit/logs"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("since") != "2020-06-01" || q.Get("until") != "2020-06-30" {
+			t.Errorf("Time range is incorrect, have: %s to %s", q.Get("since"), q.Get("until"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(pages[q.Get("cursor")])); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	since := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2020, 6, 30, 0, 0, 0, 0, time.UTC)
+	entries, err := getService.AuditLogs(context.Background(), since, until)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Incorrect number of entries, have: %d, want: %d", len(entries), 2)
+	}
+
+	e := entries[0]
+	if e.Action != "user.login" || e.Data.Actor.Name != "Ada" || e.IP != "192.0.2.1" {
+		t.Errorf("Entry is incorrect, have: %+v", e)
+	}
+
+	if !e.Timestamp.Equal(time.Date(2020, 6, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Timestamp is incorrect, have: %s", e.Timestamp)
+	}
+
+	if entries[1].Data.User == nil || entries[1].Data.User.Name != "Grace" || entries[1].Data.Team.Name != "Engineering" {
+		t.Errorf("Entry is incorrect, have: %+v", entries[1].Data)
+	}
+}
+
+func TestAuditLogsExactRange(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/audit/logs"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("since") != "2020-06-02" || q.Get("until") != "2020-06-02" {
+			t.Errorf("Time range is incorrect, have: %s to %s", q.Get("since"), q.Get("until"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"trails":[
+			{"id":1,"action":"user.login","timestamp":"2020-06-02T09:59:59.000Z"},
+			{"id":2,"action":"user.login","timestamp":"2020-06-02T10:00:00.000Z"},
+			{"id":3,"action":"user.login","timestamp":"2020-06-02T10:30:00.000Z"},
+			{"id":4,"action":"user.login","timestamp":"2020-06-02T11:00:00.000Z"},
+			{"id":5,"action":"user.login","timestamp":"2020-06-02T11:00:01.000Z"}
+		]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	since := time.Date(2020, 6, 2, 10, 0, 0, 0, time.UTC)
+	until := time.Date(2020, 6, 2, 11, 0, 0, 0, time.UTC)
+	entries, err := getService.AuditLogs(context.Background(), since, until)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make([]int, len(entries))
+	for i, e := range entries {
+		ids[i] = e.ID
+	}
+
+	if !reflect.DeepEqual(ids, []int{2, 3, 4}) {
+		t.Errorf("Entries outside the range were returned, have: %v, want: %v", ids, []int{2, 3, 4})
+	}
+}
+
+func TestAuditLogsForbidden(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/audit/logs"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		if _, err := w.Write([]byte(`{"error":{"name":"forbiddenError","message":"Audit logs are available on Enterprise plans"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	_, err := getService.AuditLogs(context.Background(), time.Time{}, time.Time{})
+	if !client.IsPermissionError(err) {
+		t.Errorf("Expected permission error, got: %v", err)
+	}
+}
+
+func TestAllEnvironments(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	getMux.HandleFunc("/workspaces", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"workspaces":[{"id":"ws-1","name":"Team"},{"id":"ws-2","name":"Personal"}]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	environments := map[string]string{
+		"ws-1": `{"environments":[{"id":"env-1","name":"Production","uid":"1-env-1"},{"id":"env-2","name":"Staging","uid":"1-env-2"}]}`,
+		"ws-2": `{"environments":[{"id":"env-2","name":"Staging","uid":"1-env-2"},{"id":"env-3","name":"Local","uid":"1-env-3"}]}`,
+	}
+
+	path := "/environments"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		body, ok := environments[r.URL.Query().Get("workspace")]
+		if !ok {
+			t.Errorf("Unexpected workspace requested: %s", r.URL.Query().Get("workspace"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(body)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.AllEnvironments(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct{ uid, workspace string }{
+		{"1-env-1", "ws-1"},
+		{"1-env-2", "ws-1"},
+		{"1-env-3", "ws-2"},
+	}
+
+	if len(r) != len(want) {
+		t.Fatalf("Incorrect number of environments, have: %d, want: %d", len(r), len(want))
+	}
+
+	for i, w := range want {
+		if r[i].UID != w.uid || r[i].WorkspaceID != w.workspace {
+			t.Errorf("Environment is incorrect, have: %s in %s, want: %s in %s", r[i].UID, r[i].WorkspaceID, w.uid, w.workspace)
+		}
+	}
+}
+
+func TestAllEnvironmentsPartialError(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	getMux.HandleFunc("/workspaces", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"workspaces":[{"id":"ws-1"},{"id":"ws-2"}]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	path := "/environments"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("workspace") == "ws-2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"environments":[{"id":"env-1","uid":"1-env-1"}]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.AllEnvironments(context.Background())
+	if err == nil {
+		t.Error("Expected error for failed workspace.")
+	}
+
+	if len(r) != 1 || r[0].WorkspaceID != "ws-1" {
+		t.Errorf("Expected environments of the listed workspace, have: %+v", r)
+	}
+}
+
+func TestMonitorRunHistoryFollowsCursors(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	pages := map[string]string{
+		"":       `{"runs":[{"id":"run-3","startedAt":"2020-06-03T10:00:00.000Z"}],"meta":{"nextCursor":"page-2"}}`,
+		"page-2": `{"runs":[{"id":"run-2","startedAt":"2020-06-02T10:00:00.000Z"}],"meta":{"nextCursor":"page-3"}}`,
+		"page-3": `{"runs":[{"id":"run-1","startedAt":"2020-06-01T10:00:00.000Z"}],"meta":{"nextCursor":""}}`,
+	}
+
+	var cursors []string
+	path := "/monitors/abcdef/runs"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(pages[cursor])); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	runs, err := getService.MonitorRunHistory(context.Background(), "abcdef", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(runs) != 3 || runs[0].ID != "run-3" || runs[2].ID != "run-1" {
+		t.Errorf("Runs are incorrect, have: %+v", runs)
+	}
+
+	if strings.Join(cursors, ",") != ",page-2,page-3" {
+		t.Errorf("Cursors are incorrect, have: %v", cursors)
+	}
+}
+
+func TestCommentsFollowsOffsets(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	pages := map[string]string{
+		"":  `{"data":[{"id":1,"threadId":1},{"id":2,"threadId":1}],"meta":{"total":3,"offset":0,"limit":2}}`,
+		"2": `{"data":[{"id":3,"threadId":2}],"meta":{"total":3,"offset":2,"limit":2}}`,
+	}
+
+	path := "/collections/abcdef/comments"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Query().Get("offset")]
+		if !ok {
+			t.Errorf("Unexpected offset requested: %s", r.URL.Query().Get("offset"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(page)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.Comments(context.Background(), resources.CollectionComments("abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r) != 3 || r[2].ID != 3 {
+		t.Errorf("Comments are incorrect, have: %+v", r)
+	}
+}
+
+func TestCommentListPageInfo(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	pages := map[string]string{
+		"":  `{"data":[{"id":1,"threadId":1},{"id":2,"threadId":1}],"meta":{"total":3,"offset":0,"limit":2}}`,
+		"2": `{"data":[{"id":3,"threadId":2}],"meta":{"total":3,"offset":2,"limit":2}}`,
+	}
+
+	path := "/collections/abcdef/comments"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(pages[r.URL.Query().Get("offset")])); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	list, err := getService.CommentList(context.Background(), resources.CollectionComments("abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list.Items) != 3 || list.Total != 3 || list.HasMore {
+		t.Errorf("Comment list is incorrect, have: %d comments of %d, has more: %t", len(list.Items), list.Total, list.HasMore)
+	}
+}
+
+func TestCollectionTags(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/collections/abcdef/tags"
+	subject := `{"tags":[{"slug":"needs-review"},{"slug":"v2"}]}`
+
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodGet)
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.CollectionTags(context.Background(), "abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have := strings.Join(r.Slugs(), ","); have != "needs-review,v2" {
+		t.Errorf("Tags are incorrect, have: %s, want: %s", have, "needs-review,v2")
+	}
+}
+
+func TestAllEnvironmentsWithProgress(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	const total = 10
+
+	getMux.HandleFunc("/workspaces", func(w http.ResponseWriter, r *http.Request) {
+		items := make([]string, total)
+		for i := range items {
+			items[i] = fmt.Sprintf(`{"id":"ws-%d"}`, i)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"workspaces":[` + strings.Join(items, ",") + `]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	path := "/environments"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"environments":[]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	var calls []int
+	progress := func(done, n int) {
+		if n != total {
+			t.Errorf("Total is incorrect, have: %d, want: %d", n, total)
+		}
+		calls = append(calls, done)
+	}
+
+	if _, err := getService.AllEnvironmentsWithProgress(context.Background(), progress); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(calls) != total {
+		t.Fatalf("Incorrect number of progress calls, have: %d, want: %d", len(calls), total)
+	}
+
+	for i, done := range calls {
+		if done != i+1 {
+			t.Errorf("Progress is not monotonic, have: %v", calls)
+			break
+		}
+	}
+}
+
+func TestWorkspaceActivity(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	pages := map[string]string{
+		"": `{"activities":[
+			{"id":"a-3","action":"update","timestamp":"2021-05-03T10:00:00.000Z","actor":{"id":"12345","name":"Taylor Lee","username":"taylor-lee"},"target":{"type":"collection","id":"1234-abcd","name":"Billing"}},
+			{"id":"a-2","action":"create","timestamp":"2021-05-02T10:00:00.000Z","actor":{"id":"12345"},"target":{"type":"environment","id":"1234-efgh"}}
+		],"meta":{"nextCursor":"page-2"}}`,
+		"page-2": `{"activities":[
+			{"id":"a-1","action":"delete","timestamp":"2021-05-01T10:00:00.000Z","actor":{"id":"678"},"target":{"type":"mock","id":"1234-ijkl"}}
+		],"meta":{"nextCursor":"page-3"}}`,
+	}
+
+	var calls int
+	path := "/workspaces/abcdef/activities"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		page, ok := pages[r.URL.Query().Get("cursor")]
+		if !ok {
+			t.Errorf("Unexpected page requested: %s", r.URL.Query().Get("cursor"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(page)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.WorkspaceActivity(context.Background(), "abcdef", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 2 || len(r) != 3 {
+		t.Fatalf("Expected 3 entries from 2 pages, have %d entries from %d pages", len(r), calls)
+	}
+
+	if r[0].ID != "a-3" || r[0].Action != "update" || r[0].Actor.Username != "taylor-lee" || r[0].Target.String() != "collection Billing" {
+		t.Errorf("Activity is incorrect, have: %+v", r[0])
+	}
+
+	if r[2].ID != "a-1" {
+		t.Errorf("Activity is not newest first, have: %s, want: %s", r[2].ID, "a-1")
+	}
+}
+
+func TestWorkspaceActivityLimit(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	calls := 0
+	path := "/workspaces/abcdef/activities"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"activities":[{"id":"a-2"},{"id":"a-1"}],"meta":{"nextCursor":"page-2"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.WorkspaceActivity(context.Background(), "abcdef", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r) != 1 || calls != 1 {
+		t.Errorf("Expected a single entry from a single page, have %d entries from %d pages", len(r), calls)
+	}
+}
+
+func TestWorkspaceActivityEmpty(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/workspaces/abcdef/activities"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"activities":[]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	r, err := getService.WorkspaceActivity(context.Background(), "abcdef", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r == nil || len(r) != 0 {
+		t.Errorf("Expected an empty slice, have: %#v", r)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	path := "/usage"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"usage":{
+			"period":{"start":"2021-06-01T00:00:00.000Z","end":"2021-07-01T00:00:00.000Z"},
+			"apiCalls":{"used":1200,"limit":1000},
+			"monitorRuns":{"used":250,"limit":1000},
+			"mockCalls":{"used":40,"limit":0}
+		}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	u, err := getService.Usage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !u.APICalls.Exceeded() || u.APICalls.Remaining() != 0 {
+		t.Errorf("API calls are incorrect, have: %+v", u.APICalls)
+	}
+
+	if u.MonitorRuns.Exceeded() || u.MonitorRuns.Remaining() != 750 {
+		t.Errorf("Monitor runs are incorrect, have: %+v", u.MonitorRuns)
+	}
+
+	if u.MockCalls.Exceeded() || u.MockCalls.Remaining() != -1 {
+		t.Errorf("Expected mock calls to be unlimited, have: %+v", u.MockCalls)
+	}
+
+	if have, want := u.Period.End.Sub(u.Period.Start), 30*24*time.Hour; have != want {
+		t.Errorf("Period is incorrect, have: %s, want: %s", have, want)
+	}
+
+	if _, rows := u.Format(); len(rows) != 3 || rows[1].(resources.UsageMetric).Name != "Monitor runs" {
+		t.Errorf("Formatted usage is incorrect, have: %+v", rows)
+	}
+}
+
+func TestUsageNotAvailable(t *testing.T) {
+	for _, tt := range []struct {
+		status int
+		body   string
+	}{
+		{http.StatusOK, `{"usage":null}`},
+		{http.StatusNotFound, `{"error":{"name":"notFound","message":"Usage not found"}}`},
+	} {
+		teardown := setupGetTest()
+
+		path := "/usage"
+		getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			if _, err := w.Write([]byte(tt.body)); err != nil {
+				t.Error(err)
+			}
+		})
+
+		ensurePath(t, getMux, path)
+
+		if _, err := getService.Usage(context.Background()); !errors.Is(err, sdk.ErrUsageNotAvailable) {
+			t.Errorf("Error is incorrect for status %d, have: %v, want: %v", tt.status, err, sdk.ErrUsageNotAvailable)
+		}
+
+		teardown()
+	}
+}
+
+func TestGetNonJSONIntoBytes(t *testing.T) {
+	teardown := setupGetTest()
+	defer teardown()
+
+	subject := "openapi: 3.0.0\ninfo:\n  title: Raw\n"
+
+	path := "/apis/api-1/versions/v-1/schemas/s-1"
+	getMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, getMux, path)
+
+	var raw []byte
+	if _, err := sdk.ServiceGet(getService, context.Background(), &raw, nil, "apis", "api-1", "versions", "v-1", "schemas", "s-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(raw) != subject {
+		t.Errorf("Body is incorrect, have: %q, want: %q", raw, subject)
+	}
+}
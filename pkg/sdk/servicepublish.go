@@ -0,0 +1,113 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+// Reasons an API version can't be published.
+const (
+	PublishReasonAlreadyPublished = "alreadyPublished"
+	PublishReasonMissingSchema    = "missingSchema"
+)
+
+// UnpublishableError is returned when an API version is in a state that
+// can't be published.  RequestError is nil when the state was detected
+// before the publish call was made.
+type UnpublishableError struct {
+	*client.RequestError
+	Reason string
+}
+
+func (e *UnpublishableError) Error() string {
+	return fmt.Sprintf("api version can't be published: %s", e.Reason)
+}
+
+// Unwrap returns the underlying RequestError, if any.
+func (e *UnpublishableError) Unwrap() error {
+	if e.RequestError == nil {
+		return nil
+	}
+
+	return e.RequestError
+}
+
+// PublishAPIVersion publishes an API version to an API network.  The version
+// is fetched first so versions that are already published or have no schema
+// are rejected with an *UnpublishableError before publishing.
+func (s *Service) PublishAPIVersion(ctx context.Context, apiID, versionID string, options resources.PublishOptions) (*resources.PublishedElement, error) {
+	if apiID == "" || versionID == "" {
+		return nil, errors.New("an API ID and a version ID are required for publishing an API version")
+	}
+
+	if options.Visibility == "" {
+		options.Visibility = resources.PublishVisibilityPrivate
+	}
+
+	if !options.Visibility.Valid() {
+		return nil, fmt.Errorf("invalid publish visibility: %s", options.Visibility)
+	}
+
+	version, err := s.APIVersion(ctx, apiID, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if version.Published {
+		return nil, &UnpublishableError{Reason: PublishReasonAlreadyPublished}
+	}
+
+	if len(version.Schema) == 0 {
+		return nil, &UnpublishableError{Reason: PublishReasonMissingSchema}
+	}
+
+	// swallow error here, the input structs will always marshal
+	requestBody, _ := json.Marshal(options)
+
+	var resource resources.PublishedElementResponse
+	if _, err := s.post(ctx, requestBody, &resource, nil, "apis", apiID, "versions", versionID, "publish"); err != nil {
+		return nil, unpublishableError(permissionError(err))
+	}
+
+	return &resource.Element, nil
+}
+
+// unpublishableError converts a 409 RequestError, or a 400 RequestError
+// naming the reason, into an UnpublishableError.
+func unpublishableError(err error) error {
+	var e *client.RequestError
+	if !errors.As(err, &e) {
+		return err
+	}
+
+	switch {
+	case e.StatusCode == http.StatusConflict:
+		return &UnpublishableError{RequestError: e, Reason: PublishReasonAlreadyPublished}
+	case e.StatusCode == http.StatusBadRequest && e.Name == PublishReasonMissingSchema:
+		return &UnpublishableError{RequestError: e, Reason: PublishReasonMissingSchema}
+	}
+
+	return err
+}
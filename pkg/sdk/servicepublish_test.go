@@ -0,0 +1,152 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+var (
+	publishMux     *http.ServeMux
+	publishService *sdk.Service
+)
+
+func setupPublishTest() func() {
+	teardown := setupService(&publishMux, &publishService)
+
+	return teardown
+}
+
+func handleAPIVersion(t *testing.T, published bool) {
+	t.Helper()
+
+	publishMux.HandleFunc("/apis/api-1/versions/v-1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodGet)
+		}
+
+		version := map[string]interface{}{"id": "v-1", "name": "1.0.0", "schema": []string{"s-1"}, "published": published}
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"version": version}); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestPublishAPIVersion(t *testing.T) {
+	teardown := setupPublishTest()
+	defer teardown()
+
+	handleAPIVersion(t, false)
+
+	path := "/apis/api-1/versions/v-1/publish"
+	publishMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPost)
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := string(body), `{"visibility":"public","releaseNotes":"First release"}`; have != want {
+			t.Errorf("Request body is incorrect, have: %s, want: %s", have, want)
+		}
+
+		if _, err := w.Write([]byte(`{"element":{"id":"el-1","type":"api","apiId":"api-1","versionId":"v-1","visibility":"public","url":"https://www.postman.com/acme/api/api-1"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, publishMux, path)
+
+	el, err := publishService.PublishAPIVersion(context.Background(), "api-1", "v-1", resources.PublishOptions{
+		Visibility:   resources.PublishVisibilityPublic,
+		ReleaseNotes: "First release",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if el.ID != "el-1" || el.Version != "v-1" || el.Visibility != resources.PublishVisibilityPublic {
+		t.Errorf("Published element is incorrect, have: %+v", el)
+	}
+}
+
+func TestPublishAPIVersionAlreadyPublished(t *testing.T) {
+	teardown := setupPublishTest()
+	defer teardown()
+
+	handleAPIVersion(t, true)
+
+	publishMux.HandleFunc("/apis/api-1/versions/v-1/publish", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no publish call for a published version")
+	})
+
+	_, err := publishService.PublishAPIVersion(context.Background(), "api-1", "v-1", resources.PublishOptions{})
+
+	var e *sdk.UnpublishableError
+	if !errors.As(err, &e) {
+		t.Fatalf("Expected an UnpublishableError, have: %v", err)
+	}
+
+	if e.Reason != sdk.PublishReasonAlreadyPublished {
+		t.Errorf("Reason is incorrect, have: %s, want: %s", e.Reason, sdk.PublishReasonAlreadyPublished)
+	}
+}
+
+func TestPublishAPIVersionConflict(t *testing.T) {
+	teardown := setupPublishTest()
+	defer teardown()
+
+	handleAPIVersion(t, false)
+
+	publishMux.HandleFunc("/apis/api-1/versions/v-1/publish", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		if _, err := w.Write([]byte(`{"error":{"name":"alreadyPublished","message":"This version has already been published."}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	_, err := publishService.PublishAPIVersion(context.Background(), "api-1", "v-1", resources.PublishOptions{})
+
+	var e *sdk.UnpublishableError
+	if !errors.As(err, &e) {
+		t.Fatalf("Expected an UnpublishableError, have: %v", err)
+	}
+
+	if e.Reason != sdk.PublishReasonAlreadyPublished || e.RequestError == nil || e.StatusCode != http.StatusConflict {
+		t.Errorf("Error is incorrect, have: %+v", e)
+	}
+}
+
+func TestPublishAPIVersionInvalidVisibility(t *testing.T) {
+	teardown := setupPublishTest()
+	defer teardown()
+
+	if _, err := publishService.PublishAPIVersion(context.Background(), "api-1", "v-1", resources.PublishOptions{Visibility: "team"}); err == nil {
+		t.Error("Expected an error for an invalid visibility")
+	}
+}
@@ -35,6 +35,31 @@ func (s *Service) ReplaceCollectionFromReader(ctx context.Context, reader io.Rea
 	return s.ReplaceFromReader(ctx, resources.CollectionType, reader, urlParams)
 }
 
+// SetCollectionTags replaces all tags on a collection and returns the
+// resulting tags.  An empty list removes every tag.
+func (s *Service) SetCollectionTags(ctx context.Context, id string, slugs []string) (resources.Tags, error) {
+	if id == "" {
+		return nil, errors.New("a collection ID is required for setting tags")
+	}
+
+	tags, err := resources.NewTags(slugs)
+	if err != nil {
+		return nil, err
+	}
+
+	input := resources.TagsResponse{Tags: tags}
+
+	// swallow error here, tags will always marshal
+	requestBody, _ := json.Marshal(input)
+
+	var resource resources.TagsResponse
+	if _, err := s.put(ctx, requestBody, &resource, "collections", id, "tags"); err != nil {
+		return nil, err
+	}
+
+	return resource.Tags, nil
+}
+
 // ReplaceEnvironmentFromReader replaces an existing environment.
 func (s *Service) ReplaceEnvironmentFromReader(ctx context.Context, reader io.Reader, resourceID string) (string, error) {
 	urlParams := make(map[string]string)
@@ -18,6 +18,7 @@ package sdk_test
 
 import (
 	"context"
+	"io/ioutil"
 	"net/http"
 	"strings"
 	"testing"
@@ -416,3 +417,64 @@ func TestReplaceFromReaderInvalidResourceError(t *testing.T) {
 		t.Errorf("Expected error.")
 	}
 }
+
+func TestSetCollectionTags(t *testing.T) {
+	teardown := setupReplaceTest()
+	defer teardown()
+
+	path := "/collections/abcdef/tags"
+	want := `{"tags":[{"slug":"needs-review"},{"slug":"v2"}]}`
+
+	replaceMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPut)
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(body) != want {
+			t.Errorf("Request body is incorrect, have: %s, want: %s", string(body), want)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(body); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, replaceMux, path)
+
+	r, err := replaceService.SetCollectionTags(context.Background(), "abcdef", []string{"needs-review", "v2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r) != 2 || r[0].Slug != "needs-review" {
+		t.Errorf("Tags are incorrect, have: %+v", r)
+	}
+}
+
+func TestSetCollectionTagsInvalid(t *testing.T) {
+	teardown := setupReplaceTest()
+	defer teardown()
+
+	ensurePath(t, replaceMux, "")
+
+	invalid := [][]string{
+		{"needs review"},
+		{"Needs-Review"},
+		{"a"},
+		{"-leading"},
+		{"trailing-"},
+		{strings.Repeat("a", resources.MaxTagLength+1)},
+		{"a1", "b1", "c1", "d1", "e1", "f1"},
+	}
+	for _, tags := range invalid {
+		if _, err := replaceService.SetCollectionTags(context.Background(), "abcdef", tags); err == nil {
+			t.Errorf("Expected error for tags %q.", tags)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+// scimPageSize is the number of SCIM users or groups requested per page.
+const scimPageSize = 100
+
+// SCIMService provisions team users through the Postman SCIM API.  SCIM
+// requests authenticate with a SCIM API key rather than the service's API
+// key.
+type SCIMService struct {
+	service *Service
+	token   string
+}
+
+// SCIM returns a SCIMService that authenticates with the SCIM API key token.
+func (s *Service) SCIM(token string) *SCIMService {
+	return &SCIMService{service: s, token: token}
+}
+
+func (s *SCIMService) request(ctx context.Context, method string, input []byte, output interface{}, queryParams map[string]string, path ...string) error {
+	if s.token == "" {
+		return errors.New("a SCIM API key is required for SCIM requests")
+	}
+
+	req := client.NewRequestWithContext(ctx, s.service.Options).
+		Method(method).
+		Path(append([]string{"scim", "v2"}, path...)...).
+		Params(queryParams).
+		WithoutAPIKey().
+		AddHeader("X-API-Key", s.token).
+		Into(output)
+
+	if input != nil {
+		req.AddHeader("Content-Type", "application/scim+json").
+			Body(bytes.NewReader(input))
+	}
+
+	_, err := req.Do()
+
+	return permissionError(err)
+}
+
+// list calls page with the body of each page of a SCIM list endpoint, using
+// SCIM's startIndex and count parameters, until all results have been seen.
+// page returns the number of resources in the body and the total reported.
+func (s *SCIMService) list(ctx context.Context, page func(body []byte) (count, total int, err error), path ...string) error {
+	startIndex := 1
+	for {
+		params := map[string]string{
+			"startIndex": strconv.Itoa(startIndex),
+			"count":      strconv.Itoa(scimPageSize),
+		}
+
+		var body json.RawMessage
+		if err := s.request(ctx, http.MethodGet, nil, &body, params, path...); err != nil {
+			return err
+		}
+
+		count, total, err := page(body)
+		if err != nil {
+			return err
+		}
+
+		startIndex += count
+		if count == 0 || startIndex > total {
+			return nil
+		}
+	}
+}
+
+// scimOperation is a single operation of a SCIM PatchOp request.
+type scimOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// patch sends a SCIM PatchOp request with the given operations.
+func (s *SCIMService) patch(ctx context.Context, operations []scimOperation, path ...string) error {
+	input := struct {
+		Schemas    []string        `json:"schemas"`
+		Operations []scimOperation `json:"Operations"`
+	}{
+		Schemas:    []string{resources.SCIMPatchOpSchema},
+		Operations: operations,
+	}
+
+	// swallow error here, the input struct will always marshal
+	requestBody, _ := json.Marshal(input)
+
+	var responseBody interface{}
+	return s.request(ctx, http.MethodPatch, requestBody, &responseBody, nil, path...)
+}
+
+// ListUsers returns every user provisioned in the team, paging through the
+// results.
+func (s *SCIMService) ListUsers(ctx context.Context) (resources.SCIMUsers, error) {
+	var users resources.SCIMUsers
+
+	err := s.list(ctx, func(body []byte) (int, int, error) {
+		var resource resources.SCIMUserListResponse
+		if err := json.Unmarshal(body, &resource); err != nil {
+			return 0, 0, err
+		}
+
+		users = append(users, resource.Resources...)
+
+		return len(resource.Resources), resource.TotalResults, nil
+	}, "Users")
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// GetUser returns a single provisioned user.
+func (s *SCIMService) GetUser(ctx context.Context, id string) (*resources.SCIMUser, error) {
+	if id == "" {
+		return nil, errors.New("a user ID is required for getting a SCIM user")
+	}
+
+	var resource resources.SCIMUser
+	if err := s.request(ctx, http.MethodGet, nil, &resource, nil, "Users", id); err != nil {
+		return nil, err
+	}
+
+	return &resource, nil
+}
+
+// CreateUser provisions a new user in the team and returns it.
+func (s *SCIMService) CreateUser(ctx context.Context, user resources.SCIMUser) (*resources.SCIMUser, error) {
+	if user.UserName == "" {
+		return nil, errors.New("a user name is required for creating a SCIM user")
+	}
+
+	if len(user.Schemas) == 0 {
+		user.Schemas = []string{resources.SCIMUserSchema}
+	}
+
+	// swallow error here, users will always marshal
+	requestBody, _ := json.Marshal(user)
+
+	var resource resources.SCIMUser
+	if err := s.request(ctx, http.MethodPost, requestBody, &resource, nil, "Users"); err != nil {
+		return nil, err
+	}
+
+	return &resource, nil
+}
+
+// DeactivateUser deactivates a provisioned user, removing them from the
+// team without deleting their account.
+func (s *SCIMService) DeactivateUser(ctx context.Context, id string) error {
+	if id == "" {
+		return errors.New("a user ID is required for deactivating a SCIM user")
+	}
+
+	return s.patch(ctx, []scimOperation{{Op: "replace", Value: map[string]interface{}{"active": false}}}, "Users", id)
+}
+
+// ListGroups returns every group in the team, paging through the results.
+func (s *SCIMService) ListGroups(ctx context.Context) (resources.SCIMGroups, error) {
+	var groups resources.SCIMGroups
+
+	err := s.list(ctx, func(body []byte) (int, int, error) {
+		var resource resources.SCIMGroupListResponse
+		if err := json.Unmarshal(body, &resource); err != nil {
+			return 0, 0, err
+		}
+
+		groups = append(groups, resource.Resources...)
+
+		return len(resource.Resources), resource.TotalResults, nil
+	}, "Groups")
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// GetGroup returns a single group with its members.
+func (s *SCIMService) GetGroup(ctx context.Context, id string) (*resources.SCIMGroup, error) {
+	if id == "" {
+		return nil, errors.New("a group ID is required for getting a SCIM group")
+	}
+
+	var resource resources.SCIMGroup
+	if err := s.request(ctx, http.MethodGet, nil, &resource, nil, "Groups", id); err != nil {
+		return nil, err
+	}
+
+	return &resource, nil
+}
+
+// CreateGroup creates a new group in the team and returns it.
+func (s *SCIMService) CreateGroup(ctx context.Context, group resources.SCIMGroup) (*resources.SCIMGroup, error) {
+	if group.DisplayName == "" {
+		return nil, errors.New("a display name is required for creating a SCIM group")
+	}
+
+	if len(group.Schemas) == 0 {
+		group.Schemas = []string{resources.SCIMGroupSchema}
+	}
+
+	// swallow error here, groups will always marshal
+	requestBody, _ := json.Marshal(group)
+
+	var resource resources.SCIMGroup
+	if err := s.request(ctx, http.MethodPost, requestBody, &resource, nil, "Groups"); err != nil {
+		return nil, err
+	}
+
+	return &resource, nil
+}
+
+// AddMember adds a provisioned user to a group.
+func (s *SCIMService) AddMember(ctx context.Context, groupID, userID string) error {
+	if groupID == "" || userID == "" {
+		return errors.New("a group ID and a user ID are required for adding a SCIM group member")
+	}
+
+	return s.patch(ctx, []scimOperation{{
+		Op:    "add",
+		Path:  "members",
+		Value: []resources.SCIMMember{{Value: userID}},
+	}}, "Groups", groupID)
+}
+
+// RemoveMember removes a user from a group.
+func (s *SCIMService) RemoveMember(ctx context.Context, groupID, userID string) error {
+	if groupID == "" || userID == "" {
+		return errors.New("a group ID and a user ID are required for removing a SCIM group member")
+	}
+
+	return s.patch(ctx, []scimOperation{{
+		Op:   "remove",
+		Path: fmt.Sprintf("members[value eq %q]", userID),
+	}}, "Groups", groupID)
+}
@@ -0,0 +1,303 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+var (
+	scimMux     *http.ServeMux
+	scimService *sdk.Service
+)
+
+func setupSCIMTest() func() {
+	teardown := setupService(&scimMux, &scimService)
+
+	return teardown
+}
+
+func checkSCIMAuth(t *testing.T, r *http.Request) {
+	if have := r.Header.Get("X-API-Key"); have != "scim-key" {
+		t.Errorf("X-API-Key header is incorrect, have: %s, want: %s", have, "scim-key")
+	}
+}
+
+func TestSCIMListUsers(t *testing.T) {
+	teardown := setupSCIMTest()
+	defer teardown()
+
+	path := "/scim/v2/Users"
+	pages := map[string]string{
+		"1": `{"schemas":["urn:ietf:params:scim:api:messages:2.0:ListResponse"],"totalResults":3,"startIndex":1,"itemsPerPage":2,"Resources":[` +
+			`{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"id":"405775fe15ed41872a8eea4c8aa2b38cda9749812cc55c99","userName":"taylor-lee@example.com","name":{"givenName":"Taylor","familyName":"Lee"},"externalId":"12345678","active":true,"meta":{"resourceType":"User","created":"2021-02-22T04:24:13.000Z","lastModified":"2021-02-22T04:24:13.000Z"}},` +
+			`{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"id":"b2c9f8a1","userName":"alex-cruz@example.com","name":{"givenName":"Alex","familyName":"Cruz"},"active":false}]}`,
+		"3": `{"totalResults":3,"startIndex":3,"itemsPerPage":1,"Resources":[{"id":"c3d0e9b2","userName":"sam-park@example.com","active":true}]}`,
+	}
+
+	scimMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		checkSCIMAuth(t, r)
+
+		subject, ok := pages[r.URL.Query().Get("startIndex")]
+		if !ok {
+			t.Errorf("Unexpected startIndex: %s", r.URL.Query().Get("startIndex"))
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(subject)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, scimMux, path)
+
+	r, err := scimService.SCIM("scim-key").ListUsers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r) != 3 {
+		t.Fatalf("Incorrect number of users, have: %d, want: %d", len(r), 3)
+	}
+
+	if r[0].UserName != "taylor-lee@example.com" || r[0].Name.GivenName != "Taylor" || !r[0].Active || r[0].Meta.ResourceType != "User" {
+		t.Errorf("User is incorrect, have: %+v", r[0])
+	}
+
+	if r[1].Active {
+		t.Errorf("Expected user %s to be inactive.", r[1].ID)
+	}
+}
+
+func TestSCIMCreateUser(t *testing.T) {
+	teardown := setupSCIMTest()
+	defer teardown()
+
+	path := "/scim/v2/Users"
+	want := `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"userName":"taylor-lee@example.com","name":{"givenName":"Taylor","familyName":"Lee"},"externalId":"12345678","active":true}`
+
+	scimMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPost)
+		}
+		checkSCIMAuth(t, r)
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(body) != want {
+			t.Errorf("Request body is incorrect, have: %s, want: %s", string(body), want)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		if _, err := w.Write([]byte(`{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"id":"405775fe","userName":"taylor-lee@example.com","active":true}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, scimMux, path)
+
+	user := resources.SCIMUser{
+		UserName:   "taylor-lee@example.com",
+		Name:       resources.SCIMName{GivenName: "Taylor", FamilyName: "Lee"},
+		ExternalID: "12345678",
+		Active:     true,
+	}
+
+	r, err := scimService.SCIM("scim-key").CreateUser(context.Background(), user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.ID != "405775fe" {
+		t.Errorf("User ID is incorrect, have: %s, want: %s", r.ID, "405775fe")
+	}
+}
+
+func TestSCIMDeactivateUser(t *testing.T) {
+	teardown := setupSCIMTest()
+	defer teardown()
+
+	path := "/scim/v2/Users/405775fe"
+	want := `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"replace","value":{"active":false}}]}`
+
+	scimMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPatch)
+		}
+		checkSCIMAuth(t, r)
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(body) != want {
+			t.Errorf("Request body is incorrect, have: %s, want: %s", string(body), want)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ensurePath(t, scimMux, path)
+
+	if err := scimService.SCIM("scim-key").DeactivateUser(context.Background(), "405775fe"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSCIMCreateGroup(t *testing.T) {
+	teardown := setupSCIMTest()
+	defer teardown()
+
+	path := "/scim/v2/Groups"
+	want := `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:Group"],"displayName":"Billing","members":[{"value":"405775fe"}]}`
+
+	scimMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPost)
+		}
+		checkSCIMAuth(t, r)
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(body) != want {
+			t.Errorf("Request body is incorrect, have: %s, want: %s", string(body), want)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		if _, err := w.Write([]byte(`{"schemas":["urn:ietf:params:scim:schemas:core:2.0:Group"],"id":"561631fq","displayName":"Billing","members":[{"value":"405775fe","display":"taylor-lee@example.com"}]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, scimMux, path)
+
+	group := resources.SCIMGroup{
+		DisplayName: "Billing",
+		Members:     []resources.SCIMMember{{Value: "405775fe"}},
+	}
+
+	r, err := scimService.SCIM("scim-key").CreateGroup(context.Background(), group)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.ID != "561631fq" || len(r.Members) != 1 || r.Members[0].Display != "taylor-lee@example.com" {
+		t.Errorf("Group is incorrect, have: %+v", r)
+	}
+}
+
+func TestSCIMListGroups(t *testing.T) {
+	teardown := setupSCIMTest()
+	defer teardown()
+
+	path := "/scim/v2/Groups"
+	scimMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		checkSCIMAuth(t, r)
+
+		page := `{"totalResults":2,"startIndex":1,"itemsPerPage":1,"Resources":[{"id":"561631fq","displayName":"Billing"}]}`
+		if r.URL.Query().Get("startIndex") == "2" {
+			page = `{"totalResults":2,"startIndex":2,"itemsPerPage":1,"Resources":[{"id":"672742gr","displayName":"Search"}]}`
+		}
+
+		if _, err := w.Write([]byte(page)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, scimMux, path)
+
+	r, err := scimService.SCIM("scim-key").ListGroups(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r) != 2 || r[1].DisplayName != "Search" {
+		t.Errorf("Groups are incorrect, have: %+v", r)
+	}
+}
+
+func TestSCIMGroupMembers(t *testing.T) {
+	teardown := setupSCIMTest()
+	defer teardown()
+
+	path := "/scim/v2/Groups/561631fq"
+	var bodies []string
+
+	scimMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPatch)
+		}
+		checkSCIMAuth(t, r)
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bodies = append(bodies, string(body))
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ensurePath(t, scimMux, path)
+
+	scim := scimService.SCIM("scim-key")
+	if err := scim.AddMember(context.Background(), "561631fq", "405775fe"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := scim.RemoveMember(context.Background(), "561631fq", "405775fe"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"add","path":"members","value":[{"value":"405775fe"}]}]}`,
+		`{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"remove","path":"members[value eq \"405775fe\"]"}]}`,
+	}
+
+	if len(bodies) != len(want) {
+		t.Fatalf("Expected %d requests, have: %d", len(want), len(bodies))
+	}
+
+	for i := range want {
+		if bodies[i] != want[i] {
+			t.Errorf("Request body is incorrect, have: %s, want: %s", bodies[i], want[i])
+		}
+	}
+}
+
+func TestSCIMRequiresToken(t *testing.T) {
+	teardown := setupSCIMTest()
+	defer teardown()
+
+	ensurePath(t, scimMux, "")
+
+	if _, err := scimService.SCIM("").ListUsers(context.Background()); err == nil {
+		t.Error("Expected error.")
+	}
+}
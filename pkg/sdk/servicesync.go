@@ -0,0 +1,128 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+// SyncWorkspaceDir syncs the collection files in dir, every file ending in
+// .json other than an export manifest, into a workspace.  Files are matched
+// to the workspace's collections by name: collections the workspace doesn't
+// have are created, and those it has are replaced unless their fingerprints
+// already match.  Syncing again after an interruption or a failure only
+// changes what is still out of date.  The report so far is returned along
+// with any error.
+func (s *Service) SyncWorkspaceDir(ctx context.Context, workspaceID, dir string, options resources.SyncOptions) (*resources.SyncReport, error) {
+	if workspaceID == "" {
+		return nil, errors.New("a workspace ID is required for syncing a workspace")
+	}
+
+	report := &resources.SyncReport{}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return report, err
+	}
+
+	workspace, err := s.Workspace(ctx, workspaceID)
+	if err != nil {
+		return report, err
+	}
+
+	remote := make(map[string]string, len(workspace.Collections))
+	for _, c := range workspace.Collections {
+		if _, ok := remote[c.Name]; ok {
+			// An empty UID marks a name shared by several collections.
+			remote[c.Name] = ""
+			continue
+		}
+		remote[c.Name] = c.UID
+	}
+
+	local := make(map[string]string)
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") || f.Name() == ExportManifestFile {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return report, err
+		}
+
+		var c resources.Collection
+		if err := json.Unmarshal(data, &c); err != nil {
+			return report, fmt.Errorf("%s: %w", f.Name(), err)
+		}
+
+		if c.Collection == nil || c.Info == nil || c.Info.Name == "" {
+			return report, fmt.Errorf("%s: the collection has no name", f.Name())
+		}
+
+		name := c.Info.Name
+		if other, ok := local[name]; ok {
+			return report, fmt.Errorf("%s: collection %q is also in %s", f.Name(), name, other)
+		}
+		local[name] = f.Name()
+
+		uid, ok := remote[name]
+		if !ok {
+			if !options.DryRun {
+				if _, err := s.CreateCollectionFromReader(ctx, bytes.NewReader(data), workspaceID); err != nil {
+					return report, fmt.Errorf("collection %q: %w", name, err)
+				}
+			}
+
+			report.Created = append(report.Created, name)
+			continue
+		}
+
+		if uid == "" {
+			return report, fmt.Errorf("collection %q: the workspace has more than one collection by that name", name)
+		}
+
+		current, err := s.Collection(ctx, uid)
+		if err != nil {
+			return report, fmt.Errorf("collection %q: %w", name, err)
+		}
+
+		if current.Fingerprint() == c.Fingerprint() {
+			report.Skipped = append(report.Skipped, name)
+			continue
+		}
+
+		if !options.DryRun {
+			if _, err := s.ReplaceCollectionFromReader(ctx, bytes.NewReader(data), uid); err != nil {
+				return report, fmt.Errorf("collection %q: %w", name, err)
+			}
+		}
+
+		report.Updated = append(report.Updated, name)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+var (
+	syncMux     *http.ServeMux
+	syncService *sdk.Service
+)
+
+func setupSyncTest() func() {
+	teardown := setupService(&syncMux, &syncService)
+
+	return teardown
+}
+
+func TestSyncWorkspaceDirCreatesOnlyNewCollections(t *testing.T) {
+	teardown := setupSyncTest()
+	defer teardown()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"existing.postman_collection.json": `{"info":{"name":"Existing","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[{"name":"Ping","request":{"method":"GET","url":"https://example.com/ping"}}]}`,
+		"new.postman_collection.json":      `{"info":{"name":"New","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[]}`,
+		sdk.ExportManifestFile:             `{"workspaceId":"ws","collections":[],"environments":[],"complete":true}`,
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	syncMux.HandleFunc("/workspaces/ws", func(w http.ResponseWriter, r *http.Request) {
+		if _, err := w.Write([]byte(`{"workspace":{"id":"ws","name":"Team","collections":[{"id":"x","name":"Existing","uid":"1-x"}]}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	syncMux.HandleFunc("/collections/1-x", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Unchanged collection was modified with %s.", r.Method)
+		}
+
+		if _, err := w.Write([]byte(`{"collection":{"info":{"_postman_id":"x","name":"Existing","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[{"id":"i","name":"Ping","request":{"method":"GET","url":"https://example.com/ping"}}]}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	var created []string
+	syncMux.HandleFunc("/collections", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Query().Get("workspace") != "ws" {
+			t.Errorf("Collection creation is incorrect, have: %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			Collection resources.Collection `json:"collection"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		created = append(created, body.Collection.Info.Name)
+
+		if _, err := w.Write([]byte(`{"collection":{"id":"n","name":"New","uid":"1-n"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, syncMux, "/workspaces/ws")
+
+	report, err := syncService.SyncWorkspaceDir(context.Background(), "ws", dir, resources.SyncOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(created) != 1 || created[0] != "New" {
+		t.Errorf("Expected only the new collection to be created, have: %v", created)
+	}
+
+	if len(report.Created) != 1 || report.Created[0] != "New" || len(report.Updated) != 0 || len(report.Skipped) != 1 || report.Skipped[0] != "Existing" {
+		t.Errorf("Sync report is incorrect, have: %+v", report)
+	}
+}
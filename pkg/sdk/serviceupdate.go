@@ -0,0 +1,321 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+// UpdateMonitorSchedule changes only the schedule of an existing monitor.
+func (s *Service) UpdateMonitorSchedule(ctx context.Context, id, cron, timezone string) (string, error) {
+	if err := resources.ValidateCron(cron); err != nil {
+		return "", err
+	}
+
+	if timezone == "" {
+		return "", errors.New("a timezone is required for updating a monitor schedule")
+	}
+
+	input := struct {
+		Monitor struct {
+			Schedule struct {
+				Cron     string `json:"cron"`
+				Timezone string `json:"timezone"`
+			} `json:"schedule"`
+		} `json:"monitor"`
+	}{}
+	input.Monitor.Schedule.Cron = cron
+	input.Monitor.Schedule.Timezone = timezone
+
+	return s.patchMonitor(ctx, id, input)
+}
+
+// PauseMonitor deactivates a monitor without changing its configuration.
+func (s *Service) PauseMonitor(ctx context.Context, id string) (string, error) {
+	return s.setMonitorActive(ctx, id, false)
+}
+
+// ResumeMonitor reactivates a paused monitor.
+func (s *Service) ResumeMonitor(ctx context.Context, id string) (string, error) {
+	return s.setMonitorActive(ctx, id, true)
+}
+
+func (s *Service) setMonitorActive(ctx context.Context, id string, active bool) (string, error) {
+	input := struct {
+		Monitor struct {
+			Active bool `json:"active"`
+		} `json:"monitor"`
+	}{}
+	input.Monitor.Active = active
+
+	return s.patchMonitor(ctx, id, input)
+}
+
+func (s *Service) patchMonitor(ctx context.Context, id string, input interface{}) (string, error) {
+	if id == "" {
+		return "", errors.New("a monitor ID is required for updating a monitor")
+	}
+
+	// swallow error here, the input structs will always marshal
+	requestBody, _ := json.Marshal(input)
+
+	var responseBody interface{}
+	if _, err := s.patch(ctx, requestBody, &responseBody, "monitors", id); err != nil {
+		return "", err
+	}
+
+	return responseID(responseBody, "monitor"), nil
+}
+
+// RenameWorkspace changes only the name of an existing workspace.
+func (s *Service) RenameWorkspace(ctx context.Context, id, newName string) (string, error) {
+	if newName == "" {
+		return "", errors.New("a name is required for renaming a workspace")
+	}
+
+	input := struct {
+		Workspace struct {
+			Name string `json:"name"`
+		} `json:"workspace"`
+	}{}
+	input.Workspace.Name = newName
+
+	return s.patchWorkspace(ctx, id, input)
+}
+
+// SetWorkspaceVisibility changes only the visibility of an existing
+// workspace.
+func (s *Service) SetWorkspaceVisibility(ctx context.Context, id string, visibility resources.WorkspaceVisibility) (string, error) {
+	if !visibility.Valid() {
+		return "", fmt.Errorf("invalid workspace visibility %q", visibility)
+	}
+
+	input := struct {
+		Workspace struct {
+			Type resources.WorkspaceVisibility `json:"type"`
+		} `json:"workspace"`
+	}{}
+	input.Workspace.Type = visibility
+
+	return s.patchWorkspace(ctx, id, input)
+}
+
+func (s *Service) patchWorkspace(ctx context.Context, id string, input interface{}) (string, error) {
+	if id == "" {
+		return "", errors.New("a workspace ID is required for updating a workspace")
+	}
+
+	// swallow error here, the input structs will always marshal
+	requestBody, _ := json.Marshal(input)
+
+	var responseBody interface{}
+	if _, err := s.patch(ctx, requestBody, &responseBody, "workspaces", id); err != nil {
+		return "", permissionError(err)
+	}
+
+	return responseID(responseBody, "workspace"), nil
+}
+
+// UpdateWorkspaceRoles adds or removes user and group roles on a workspace
+// and returns the resulting role assignments.
+func (s *Service) UpdateWorkspaceRoles(ctx context.Context, id string, changes []resources.RoleChange) (resources.WorkspaceRoles, error) {
+	if id == "" {
+		return nil, errors.New("a workspace ID is required for updating roles")
+	}
+
+	if len(changes) == 0 {
+		return nil, errors.New("at least one role change is required")
+	}
+
+	for _, c := range changes {
+		if err := c.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	input := struct {
+		Roles []resources.RoleChange `json:"roles"`
+	}{
+		Roles: changes,
+	}
+
+	// swallow error here, role changes will always marshal
+	requestBody, _ := json.Marshal(input)
+
+	var resource resources.WorkspaceRolesResponse
+	if _, err := s.patch(ctx, requestBody, &resource, "workspaces", id, "roles"); err != nil {
+		return nil, permissionError(err)
+	}
+
+	return resource.Roles, nil
+}
+
+// PatchEnvironmentVariables updates only the given variables of an
+// environment: upserts sets values, adding new variables at the end, and
+// deletes removes variables by key.  The order and types of the remaining
+// variables are preserved.  When the Postman API returns an ETag, the
+// update is conditional on the environment not having changed since it was
+// read.
+func (s *Service) PatchEnvironmentVariables(ctx context.Context, id string, upserts map[string]string, deletes []string) (string, error) {
+	if id == "" {
+		return "", errors.New("an environment ID is required for updating variables")
+	}
+
+	env, etag, err := s.environmentForUpdate(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	env.Values = patchVariables(env.Values, upserts, deletes)
+
+	return s.putEnvironmentIfMatch(ctx, id, env, etag)
+}
+
+// maxMutateAttempts bounds the read-modify-write cycles of
+// MutateEnvironment.
+const maxMutateAttempts = 3
+
+// MutateEnvironment applies fn to the current state of an environment and
+// saves the result.  When the Postman API returns an ETag, the update is
+// conditional on the environment not having changed since it was read, and
+// the whole cycle is retried up to maxMutateAttempts times when it has.
+// An error returned by fn is returned without saving.
+func (s *Service) MutateEnvironment(ctx context.Context, id string, fn func(*resources.Environment) error) (string, error) {
+	if id == "" {
+		return "", errors.New("an environment ID is required for mutating an environment")
+	}
+
+	var err error
+	for attempt := 0; attempt < maxMutateAttempts; attempt++ {
+		var (
+			env  *resources.Environment
+			etag string
+		)
+		if env, etag, err = s.environmentForUpdate(ctx, id); err != nil {
+			return "", err
+		}
+
+		if err = fn(env); err != nil {
+			return "", err
+		}
+
+		var uid string
+		if uid, err = s.putEnvironmentIfMatch(ctx, id, env, etag); err == nil {
+			return uid, nil
+		}
+
+		if !client.IsPreconditionFailed(err) {
+			return "", err
+		}
+	}
+
+	return "", fmt.Errorf("giving up after %d conflicting updates: %w", maxMutateAttempts, err)
+}
+
+// environmentForUpdate reads the latest state of an environment, bypassing
+// the cache, along with its ETag when the Postman API returns one.
+func (s *Service) environmentForUpdate(ctx context.Context, id string) (*resources.Environment, string, error) {
+	var resource resources.EnvironmentResponse
+	res, err := client.NewRequestWithContext(ctx, s.Options).
+		Get().
+		Path("environments", id).
+		Refresh().
+		Into(&resource).
+		Do()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &resource.Environment, res.Header.Get("ETag"), nil
+}
+
+// putEnvironmentIfMatch saves the name and variables of an environment,
+// conditional on etag when it's given.
+func (s *Service) putEnvironmentIfMatch(ctx context.Context, id string, env *resources.Environment, etag string) (string, error) {
+	input := struct {
+		Environment struct {
+			Name   string                   `json:"name"`
+			Values []resources.KeyValuePair `json:"values"`
+		} `json:"environment"`
+	}{}
+	input.Environment.Name = env.Name
+	input.Environment.Values = env.Values
+
+	// swallow error here, the input struct will always marshal
+	requestBody, _ := json.Marshal(input)
+
+	req := client.NewRequestWithContext(ctx, s.Options).
+		Put().
+		Path("environments", id).
+		AddHeader("Content-Type", "application/json").
+		Body(bytes.NewReader(requestBody))
+	if etag != "" {
+		req.AddHeader("If-Match", etag)
+	}
+
+	var responseBody interface{}
+	if _, err := req.Into(&responseBody).Do(); err != nil {
+		return "", err
+	}
+
+	return responseID(responseBody, "environment"), nil
+}
+
+// patchVariables returns values with deletes removed and upserts applied.
+// New variables are appended in key order so the result is deterministic.
+func patchVariables(values []resources.KeyValuePair, upserts map[string]string, deletes []string) []resources.KeyValuePair {
+	deleted := make(map[string]bool, len(deletes))
+	for _, k := range deletes {
+		deleted[k] = true
+	}
+
+	seen := make(map[string]bool, len(values))
+	ret := make([]resources.KeyValuePair, 0, len(values)+len(upserts))
+	for _, v := range values {
+		if deleted[v.Key] {
+			continue
+		}
+
+		if value, ok := upserts[v.Key]; ok {
+			v.Value = value
+		}
+		seen[v.Key] = true
+		ret = append(ret, v)
+	}
+
+	keys := make([]string, 0, len(upserts))
+	for k := range upserts {
+		if !seen[k] && !deleted[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		ret = append(ret, resources.KeyValuePair{Key: k, Value: upserts[k], Enabled: true, Type: "default"})
+	}
+
+	return ret
+}
@@ -0,0 +1,439 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/resources"
+)
+
+var (
+	updateMux     *http.ServeMux
+	updateService *sdk.Service
+)
+
+func setupUpdateTest() func() {
+	teardown := setupService(&updateMux, &updateService)
+
+	return teardown
+}
+
+func handleMonitorPatch(t *testing.T, path, want string) {
+	updateMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPatch)
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(body) != want {
+			t.Errorf("Request body is incorrect, have: %s, want: %s", string(body), want)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"monitor":{"uid":"5678-abcdef"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, updateMux, path)
+}
+
+func TestUpdateMonitorSchedule(t *testing.T) {
+	teardown := setupUpdateTest()
+	defer teardown()
+
+	handleMonitorPatch(t, "/monitors/abcdef",
+		`{"monitor":{"schedule":{"cron":"*/15 9-17 * * 1-5","timezone":"America/Chicago"}}}`)
+
+	r, err := updateService.UpdateMonitorSchedule(context.Background(), "abcdef", "*/15 9-17 * * 1-5", "America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r != "5678-abcdef" {
+		t.Errorf("Resource ID is incorrect, have: %s, want: %s", r, "5678-abcdef")
+	}
+}
+
+func TestUpdateMonitorScheduleInvalidCron(t *testing.T) {
+	teardown := setupUpdateTest()
+	defer teardown()
+
+	ensurePath(t, updateMux, "")
+
+	invalid := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"}
+	for _, cron := range invalid {
+		if _, err := updateService.UpdateMonitorSchedule(context.Background(), "abcdef", cron, "UTC"); err == nil {
+			t.Errorf("Expected error for cron expression %q.", cron)
+		}
+	}
+}
+
+func TestPauseMonitor(t *testing.T) {
+	teardown := setupUpdateTest()
+	defer teardown()
+
+	handleMonitorPatch(t, "/monitors/abcdef", `{"monitor":{"active":false}}`)
+
+	if _, err := updateService.PauseMonitor(context.Background(), "abcdef"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResumeMonitor(t *testing.T) {
+	teardown := setupUpdateTest()
+	defer teardown()
+
+	handleMonitorPatch(t, "/monitors/abcdef", `{"monitor":{"active":true}}`)
+
+	if _, err := updateService.ResumeMonitor(context.Background(), "abcdef"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPauseMonitorError(t *testing.T) {
+	teardown := setupUpdateTest()
+	defer teardown()
+
+	path := "/monitors/abcdef"
+	updateMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	ensurePath(t, updateMux, path)
+
+	if _, err := updateService.PauseMonitor(context.Background(), "abcdef"); err == nil {
+		t.Error("Expected error.")
+	}
+}
+
+func TestUpdateWorkspaceRoles(t *testing.T) {
+	teardown := setupUpdateTest()
+	defer teardown()
+
+	path := "/workspaces/abcdef/roles"
+	want := `{"roles":[{"op":"add","type":"user","id":"12345","role":"editor"},{"op":"remove","type":"group","id":"678","role":"viewer"}]}`
+
+	updateMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPatch)
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(body) != want {
+			t.Errorf("Request body is incorrect, have: %s, want: %s", string(body), want)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"roles":[{"id":"12345","type":"user","role":"editor"}]}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, updateMux, path)
+
+	changes := []resources.RoleChange{
+		{Op: resources.RoleChangeAdd, Type: resources.RoleMemberUser, ID: "12345", Role: resources.WorkspaceRoleEditor},
+		{Op: resources.RoleChangeRemove, Type: resources.RoleMemberGroup, ID: "678", Role: resources.WorkspaceRoleViewer},
+	}
+
+	r, err := updateService.UpdateWorkspaceRoles(context.Background(), "abcdef", changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r) != 1 || r[0].Role != resources.WorkspaceRoleEditor {
+		t.Errorf("Role assignments are incorrect, have: %+v", r)
+	}
+}
+
+func TestUpdateWorkspaceRolesInvalidChange(t *testing.T) {
+	teardown := setupUpdateTest()
+	defer teardown()
+
+	ensurePath(t, updateMux, "")
+
+	invalid := []resources.RoleChange{
+		{Op: "replace", Type: resources.RoleMemberUser, ID: "1", Role: resources.WorkspaceRoleEditor},
+		{Op: resources.RoleChangeAdd, Type: "team", ID: "1", Role: resources.WorkspaceRoleEditor},
+		{Op: resources.RoleChangeAdd, Type: resources.RoleMemberUser, Role: resources.WorkspaceRoleEditor},
+		{Op: resources.RoleChangeAdd, Type: resources.RoleMemberUser, ID: "1", Role: "owner"},
+	}
+
+	for _, c := range invalid {
+		if _, err := updateService.UpdateWorkspaceRoles(context.Background(), "abcdef", []resources.RoleChange{c}); err == nil {
+			t.Errorf("Expected error for change %+v.", c)
+		}
+	}
+}
+
+func handleWorkspacePatch(t *testing.T, path, want string) {
+	updateMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("Method is incorrect, have: %s, want: %s", r.Method, http.MethodPatch)
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(body) != want {
+			t.Errorf("Request body is incorrect, have: %s, want: %s", string(body), want)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(`{"workspace":{"id":"abcdef"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, updateMux, path)
+}
+
+func TestRenameWorkspace(t *testing.T) {
+	teardown := setupUpdateTest()
+	defer teardown()
+
+	handleWorkspacePatch(t, "/workspaces/abcdef", `{"workspace":{"name":"Shared APIs"}}`)
+
+	r, err := updateService.RenameWorkspace(context.Background(), "abcdef", "Shared APIs")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r != "abcdef" {
+		t.Errorf("Resource ID is incorrect, have: %s, want: %s", r, "abcdef")
+	}
+}
+
+func TestSetWorkspaceVisibility(t *testing.T) {
+	teardown := setupUpdateTest()
+	defer teardown()
+
+	handleWorkspacePatch(t, "/workspaces/abcdef", `{"workspace":{"type":"team"}}`)
+
+	if _, err := updateService.SetWorkspaceVisibility(context.Background(), "abcdef", resources.WorkspaceVisibilityTeam); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetWorkspaceVisibilityInvalid(t *testing.T) {
+	teardown := setupUpdateTest()
+	defer teardown()
+
+	ensurePath(t, updateMux, "")
+
+	for _, v := range []resources.WorkspaceVisibility{"", "secret", "Team"} {
+		if _, err := updateService.SetWorkspaceVisibility(context.Background(), "abcdef", v); err == nil {
+			t.Errorf("Expected error for visibility %q.", v)
+		}
+	}
+}
+
+func TestPatchEnvironmentVariables(t *testing.T) {
+	teardown := setupUpdateTest()
+	defer teardown()
+
+	path := "/environments/abcdef"
+	subject := `{"environment":{"id":"abcdef","name":"Staging","values":[` +
+		`{"key":"baseUrl","value":"https://staging.example.com","enabled":true,"type":"default"},` +
+		`{"key":"token","value":"old","enabled":true,"type":"secret"},` +
+		`{"key":"debug","value":"true","enabled":false,"type":"default"}]}}`
+	want := `{"environment":{"name":"Staging","values":[` +
+		`{"key":"baseUrl","value":"https://staging.example.com","enabled":true,"type":"default"},` +
+		`{"key":"token","value":"new","enabled":true,"type":"secret"},` +
+		`{"key":"userId","value":"42","enabled":true,"type":"default"}]}}`
+
+	updateMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("ETag", `"v1"`)
+			w.WriteHeader(http.StatusOK)
+			if _, err := w.Write([]byte(subject)); err != nil {
+				t.Error(err)
+			}
+		case http.MethodPut:
+			if have := r.Header.Get("If-Match"); have != `"v1"` {
+				t.Errorf("If-Match header is incorrect, have: %s, want: %s", have, `"v1"`)
+			}
+
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(body) != want {
+				t.Errorf("Request body is incorrect, have: %s, want: %s", string(body), want)
+			}
+
+			w.WriteHeader(http.StatusOK)
+			if _, err := w.Write([]byte(`{"environment":{"id":"abcdef","uid":"1234-abcdef"}}`)); err != nil {
+				t.Error(err)
+			}
+		default:
+			t.Errorf("Unexpected method: %s", r.Method)
+		}
+	})
+
+	ensurePath(t, updateMux, path)
+
+	upserts := map[string]string{"token": "new", "userId": "42"}
+	r, err := updateService.PatchEnvironmentVariables(context.Background(), "abcdef", upserts, []string{"debug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r != "1234-abcdef" {
+		t.Errorf("Resource ID is incorrect, have: %s, want: %s", r, "1234-abcdef")
+	}
+}
+
+func TestPatchEnvironmentVariablesConflict(t *testing.T) {
+	teardown := setupUpdateTest()
+	defer teardown()
+
+	path := "/environments/abcdef"
+	updateMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Header().Set("ETag", `"v1"`)
+			w.WriteHeader(http.StatusOK)
+			if _, err := w.Write([]byte(`{"environment":{"id":"abcdef","name":"Staging","values":[]}}`)); err != nil {
+				t.Error(err)
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusPreconditionFailed)
+	})
+
+	ensurePath(t, updateMux, path)
+
+	if _, err := updateService.PatchEnvironmentVariables(context.Background(), "abcdef", map[string]string{"a": "b"}, nil); err == nil {
+		t.Error("Expected error.")
+	}
+}
+
+func TestMutateEnvironmentRetriesConflict(t *testing.T) {
+	teardown := setupUpdateTest()
+	defer teardown()
+
+	path := "/environments/abcdef"
+	var gets, puts int
+	updateMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets++
+			// another writer saves between the first read and write
+			w.Header().Set("ETag", fmt.Sprintf(`"v%d"`, gets))
+			value := "1"
+			if gets > 1 {
+				value = "2"
+			}
+			w.WriteHeader(http.StatusOK)
+			if _, err := fmt.Fprintf(w, `{"environment":{"id":"abcdef","name":"Staging","values":[{"key":"count","value":"%s","enabled":true}]}}`, value); err != nil {
+				t.Error(err)
+			}
+			return
+		}
+
+		puts++
+		if have, want := r.Header.Get("If-Match"), fmt.Sprintf(`"v%d"`, puts); have != want {
+			t.Errorf("If-Match is incorrect, have: %s, want: %s", have, want)
+		}
+
+		if puts == 1 {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := `{"environment":{"name":"Staging","values":[{"key":"count","value":"3","enabled":true}]}}`; string(body) != want {
+			t.Errorf("Request body is incorrect, have: %s, want: %s", string(body), want)
+		}
+
+		if _, err := w.Write([]byte(`{"environment":{"uid":"5678-abcdef"}}`)); err != nil {
+			t.Error(err)
+		}
+	})
+
+	ensurePath(t, updateMux, path)
+
+	r, err := updateService.MutateEnvironment(context.Background(), "abcdef", func(env *resources.Environment) error {
+		n, err := strconv.Atoi(env.Values[0].Value)
+		if err != nil {
+			return err
+		}
+		env.Values[0].Value = strconv.Itoa(n + 1)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r != "5678-abcdef" {
+		t.Errorf("Environment UID is incorrect, have: %s, want: %s", r, "5678-abcdef")
+	}
+
+	if gets != 2 || puts != 2 {
+		t.Errorf("Attempts are incorrect, have: %d reads and %d writes, want: 2 and 2", gets, puts)
+	}
+}
+
+func TestMutateEnvironmentGivesUp(t *testing.T) {
+	teardown := setupUpdateTest()
+	defer teardown()
+
+	path := "/environments/abcdef"
+	updateMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Header().Set("ETag", `"v1"`)
+			if _, err := w.Write([]byte(`{"environment":{"id":"abcdef","name":"Staging","values":[]}}`)); err != nil {
+				t.Error(err)
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusPreconditionFailed)
+	})
+
+	ensurePath(t, updateMux, path)
+
+	_, err := updateService.MutateEnvironment(context.Background(), "abcdef", func(env *resources.Environment) error { return nil })
+	if !client.IsPreconditionFailed(err) {
+		t.Errorf("Expected precondition failed error, have: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Backoff bounds used while polling a mock server.
+const (
+	mockReadyInitialBackoff = 100 * time.Millisecond
+	mockReadyMaxBackoff     = 2 * time.Second
+)
+
+// WaitUntilMockReady polls mockURL until it responds with a successful
+// status, backing off between attempts.  It returns the last failure when
+// ctx is done first.  A mockURL that isn't an absolute http or https URL is
+// reported immediately.
+func (s *Service) WaitUntilMockReady(ctx context.Context, mockURL string) error {
+	c := http.DefaultClient
+	if s.Options != nil && s.Options.Client != nil {
+		c = s.Options.Client
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, mockURL, nil)
+	if err != nil {
+		return fmt.Errorf("invalid mock server URL %q: %w", mockURL, err)
+	}
+
+	if (req.URL.Scheme != "http" && req.URL.Scheme != "https") || req.URL.Host == "" {
+		return fmt.Errorf("invalid mock server URL %q: must be an absolute http or https URL", mockURL)
+	}
+
+	backoff := mockReadyInitialBackoff
+	for {
+		err := pollMock(c, req)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("mock server %s not ready: %w", mockURL, err)
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > mockReadyMaxBackoff {
+			backoff = mockReadyMaxBackoff
+		}
+	}
+}
+
+func pollMock(c *http.Client, req *http.Request) error {
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk"
+	"github.com/kevinswiber/postmanctl/pkg/sdk/client"
+)
+
+func TestWaitUntilMockReady(t *testing.T) {
+	var (
+		mux     *http.ServeMux
+		service *sdk.Service
+	)
+
+	teardown := setupService(&mux, &service)
+	defer teardown()
+
+	var attempts int32
+	path := "/mock"
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ensurePath(t, mux, path)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := service.WaitUntilMockReady(ctx, client.NewRequest(service.Options).Path("mock").URL().String()); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(&attempts); n != 3 {
+		t.Errorf("Incorrect number of attempts, have: %d, want: %d", n, 3)
+	}
+}
+
+func TestWaitUntilMockReadyContextExpires(t *testing.T) {
+	var (
+		mux     *http.ServeMux
+		service *sdk.Service
+	)
+
+	teardown := setupService(&mux, &service)
+	defer teardown()
+
+	path := "/mock"
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	ensurePath(t, mux, path)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+
+	if err := service.WaitUntilMockReady(ctx, client.NewRequest(service.Options).Path("mock").URL().String()); err == nil {
+		t.Error("Expected error when the mock never becomes ready.")
+	}
+}
+
+func TestWaitUntilMockReadyInvalidURL(t *testing.T) {
+	service := sdk.NewService(nil)
+
+	for _, mockURL := range []string{"", "://mock", "/mock", "mock.example.com"} {
+		done := make(chan error, 1)
+		go func() {
+			done <- service.WaitUntilMockReady(context.Background(), mockURL)
+		}()
+
+		select {
+		case err := <-done:
+			if err == nil {
+				t.Errorf("Expected error for mock URL %q.", mockURL)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected an immediate error for mock URL %q.", mockURL)
+		}
+	}
+}
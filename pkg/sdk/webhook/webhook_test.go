@@ -0,0 +1,59 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook_test
+
+import (
+	"testing"
+
+	"github.com/kevinswiber/postmanctl/pkg/sdk/webhook"
+)
+
+const (
+	testPayload   = "Hello, World!"
+	testSecret    = "It's a Secret to Everybody"
+	testSignature = "757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+)
+
+func TestSign(t *testing.T) {
+	if have := webhook.Sign([]byte(testPayload), testSecret); have != testSignature {
+		t.Errorf("Signature is incorrect, have: %s, want: %s", have, testSignature)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	cases := []struct {
+		name      string
+		payload   string
+		signature string
+		secret    string
+		want      bool
+	}{
+		{"match", testPayload, testSignature, testSecret, true},
+		{"prefixed", testPayload, "sha256=" + testSignature, testSecret, true},
+		{"tampered payload", testPayload + "!", testSignature, testSecret, false},
+		{"wrong secret", testPayload, testSignature, "guess", false},
+		{"truncated", testPayload, testSignature[:32], testSecret, false},
+		{"not hex", testPayload, "not-a-signature", testSecret, false},
+		{"no secret", testPayload, webhook.Sign([]byte(testPayload), ""), "", false},
+	}
+
+	for _, c := range cases {
+		if have := webhook.VerifySignature([]byte(c.payload), c.signature, c.secret); have != c.want {
+			t.Errorf("%s: verification is incorrect, have: %t, want: %t", c.name, have, c.want)
+		}
+	}
+}
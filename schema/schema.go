@@ -0,0 +1,25 @@
+/*
+Copyright © 2020 Kevin Swiber <kswiber@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schema embeds the Postman collection JSON schema.
+package schema
+
+import _ "embed" // required for go:embed
+
+// CollectionV21 is the Postman collection format v2.1.0 JSON schema.
+//
+//go:embed collection.schema.json
+var CollectionV21 []byte